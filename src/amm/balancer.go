@@ -0,0 +1,292 @@
+package amm
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	BalancerWeighted = "weighted"
+	BalancerStable   = "stable"
+)
+
+var (
+	BalancerOne           = big.NewInt(1000000000000000000)
+	BalancerAmpPrecision  = big.NewInt(1000)
+	BalancerMaxInRatio    = big.NewInt(300000000000000000)
+	balancerMaxPowError   = big.NewInt(10000)
+	balancerMaxIterations = 255
+
+	ErrMaxInRatio  = errors.New("amount in exceeds the max in ratio")
+	ErrUnknownPool = errors.New("unknown balancer pool kind")
+)
+
+type BalancerState struct {
+	PoolID common.Hash `json:"poolId"`
+	Kind   string      `json:"kind"`
+	// Balances are the raw token balances held by the Vault for the pool.
+	Balances []*big.Int `json:"balances"`
+	// ScalingFactors upscale each balance to 18 decimals, times one.
+	ScalingFactors []*big.Int `json:"scalingFactors"`
+	Weights        []*big.Int `json:"weights,omitempty"`
+	// Amp includes the amplification precision of 1000.
+	Amp          *big.Int `json:"amp,omitempty"`
+	LastLogBlock uint64   `json:"lastLogBlock"`
+	LastLogIndex uint     `json:"lastLogIndex"`
+}
+
+func (s *BalancerState) Clone() *BalancerState {
+	clone := *s
+	return &clone
+}
+
+func (s *BalancerState) Initialized() bool {
+	if len(s.Balances) < 2 || len(s.Balances) != len(s.ScalingFactors) {
+		return false
+	}
+	for _, balance := range s.Balances {
+		if balance == nil || balance.Sign() != 1 {
+			return false
+		}
+	}
+	switch s.Kind {
+	case BalancerWeighted:
+		return len(s.Weights) == len(s.Balances)
+	case BalancerStable:
+		return s.Amp != nil && s.Amp.Sign() == 1
+	}
+	return false
+}
+
+// ApplyDeltas adds the balance changes of a Vault event once, so overlapping
+// log ranges are harmless.
+func (s *BalancerState) ApplyDeltas(blockNumber uint64, logIndex uint, deltas []*big.Int) {
+	if s.seen(blockNumber, logIndex) || len(deltas) != len(s.Balances) {
+		return
+	}
+	balances := []*big.Int{}
+	for i, balance := range s.Balances {
+		balances = append(balances, new(big.Int).Add(balance, deltas[i]))
+	}
+	s.Balances = balances
+}
+
+// SetBalances replaces the balances with ones that include every change up to
+// and including blockNumber, so the logs of that block are not applied again.
+func (s *BalancerState) SetBalances(blockNumber uint64, balances []*big.Int) {
+	s.Balances = balances
+	if blockNumber >= s.LastLogBlock {
+		s.LastLogBlock = blockNumber
+		s.LastLogIndex = math.MaxUint
+	}
+}
+
+func (s *BalancerState) seen(blockNumber uint64, logIndex uint) bool {
+	if blockNumber < s.LastLogBlock || (blockNumber == s.LastLogBlock && logIndex <= s.LastLogIndex && s.LastLogBlock != 0) {
+		return true
+	}
+	s.LastLogBlock = blockNumber
+	s.LastLogIndex = logIndex
+	return false
+}
+
+// GetAmountOut quotes a swap given in, with the swap fee on the 1e18 scale
+// taken from the amount in as the Balancer pools do.
+func (s *BalancerState) GetAmountOut(i int, j int, amountIn *big.Int, fee *big.Int) (*big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(s.Balances) || j >= len(s.Balances) {
+		return nil, ErrInvalidCoins
+	}
+	amountIn = new(big.Int).Sub(amountIn, mulUpFixed(amountIn, fee))
+	amountIn = mulDownFixed(amountIn, s.ScalingFactors[i])
+	balances := []*big.Int{}
+	for k, balance := range s.Balances {
+		balances = append(balances, mulDownFixed(balance, s.ScalingFactors[k]))
+	}
+	var amountOut *big.Int
+	var err error
+	switch s.Kind {
+	case BalancerWeighted:
+		amountOut, err = weightedOutGivenIn(balances[i], s.Weights[i], balances[j], s.Weights[j], amountIn)
+	case BalancerStable:
+		amountOut, err = stableOutGivenIn(s.Amp, balances, i, j, amountIn)
+	default:
+		err = ErrUnknownPool
+	}
+	if err != nil {
+		return nil, err
+	}
+	return divDownFixed(amountOut, s.ScalingFactors[j]), nil
+}
+
+func mulDownFixed(a *big.Int, b *big.Int) *big.Int {
+	return mulDiv(a, b, BalancerOne)
+}
+
+func mulUpFixed(a *big.Int, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	if product.Sign() == 0 {
+		return product
+	}
+	product.Sub(product, big.NewInt(1))
+	product.Div(product, BalancerOne)
+	return product.Add(product, big.NewInt(1))
+}
+
+func divDownFixed(a *big.Int, b *big.Int) *big.Int {
+	return mulDiv(a, BalancerOne, b)
+}
+
+func divUpFixed(a *big.Int, b *big.Int) *big.Int {
+	if a.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	product := new(big.Int).Mul(a, BalancerOne)
+	product.Sub(product, big.NewInt(1))
+	product.Div(product, b)
+	return product.Add(product, big.NewInt(1))
+}
+
+func complementFixed(x *big.Int) *big.Int {
+	if x.Cmp(BalancerOne) == -1 {
+		return new(big.Int).Sub(BalancerOne, x)
+	}
+	return big.NewInt(0)
+}
+
+// powUpFixed follows FixedPoint.powUp, rounding LogExpMath.pow up by its
+// max relative error.
+func powUpFixed(x *big.Int, y *big.Int) (*big.Int, error) {
+	if y.Cmp(BalancerOne) == 0 {
+		return new(big.Int).Set(x), nil
+	}
+	if y.Cmp(new(big.Int).Mul(BalancerOne, big.NewInt(2))) == 0 {
+		return mulUpFixed(x, x), nil
+	}
+	if y.Cmp(new(big.Int).Mul(BalancerOne, big.NewInt(4))) == 0 {
+		square := mulUpFixed(x, x)
+		return mulUpFixed(square, square), nil
+	}
+	raw, err := logExpPow(x, y)
+	if err != nil {
+		return nil, err
+	}
+	maxError := mulUpFixed(raw, balancerMaxPowError)
+	maxError.Add(maxError, big.NewInt(1))
+	return raw.Add(raw, maxError), nil
+}
+
+// weightedOutGivenIn is a port of WeightedMath._calcOutGivenIn.
+func weightedOutGivenIn(balanceIn *big.Int, weightIn *big.Int, balanceOut *big.Int, weightOut *big.Int, amountIn *big.Int) (*big.Int, error) {
+	if amountIn.Cmp(mulDownFixed(balanceIn, BalancerMaxInRatio)) == 1 {
+		return nil, ErrMaxInRatio
+	}
+	denominator := new(big.Int).Add(balanceIn, amountIn)
+	base := divUpFixed(balanceIn, denominator)
+	exponent := divDownFixed(weightIn, weightOut)
+	power, err := powUpFixed(base, exponent)
+	if err != nil {
+		return nil, err
+	}
+	return mulDownFixed(balanceOut, complementFixed(power)), nil
+}
+
+// stableInvariant is a port of StableMath._calculateInvariant.
+func stableInvariant(amp *big.Int, balances []*big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(balances)))
+	sum := big.NewInt(0)
+	for _, balance := range balances {
+		sum.Add(sum, balance)
+	}
+	if sum.Sign() == 0 {
+		return sum, nil
+	}
+	invariant := new(big.Int).Set(sum)
+	ampTimesTotal := new(big.Int).Mul(amp, n)
+	for i := 0; i < balancerMaxIterations; i++ {
+		dP := new(big.Int).Set(invariant)
+		for _, balance := range balances {
+			dP.Mul(dP, invariant)
+			dP.Div(dP, new(big.Int).Mul(balance, n))
+		}
+		previous := invariant
+		numerator := new(big.Int).Mul(ampTimesTotal, sum)
+		numerator.Div(numerator, BalancerAmpPrecision)
+		numerator.Add(numerator, new(big.Int).Mul(dP, n))
+		numerator.Mul(numerator, invariant)
+		denominator := new(big.Int).Sub(ampTimesTotal, BalancerAmpPrecision)
+		denominator.Mul(denominator, invariant)
+		denominator.Div(denominator, BalancerAmpPrecision)
+		denominator.Add(denominator, new(big.Int).Mul(new(big.Int).Add(n, big.NewInt(1)), dP))
+		invariant = numerator.Div(numerator, denominator)
+		if absDiffAtMostOne(invariant, previous) {
+			return invariant, nil
+		}
+	}
+	return nil, ErrNotConverging
+}
+
+// stableBalanceGivenInvariant is a port of
+// StableMath._getTokenBalanceGivenInvariantAndAllOtherBalances.
+func stableBalanceGivenInvariant(amp *big.Int, balances []*big.Int, invariant *big.Int, index int) (*big.Int, error) {
+	n := big.NewInt(int64(len(balances)))
+	ampTimesTotal := new(big.Int).Mul(amp, n)
+	sum := new(big.Int).Set(balances[0])
+	pD := new(big.Int).Mul(balances[0], n)
+	for k := 1; k < len(balances); k++ {
+		pD.Mul(pD, balances[k])
+		pD.Mul(pD, n)
+		pD.Div(pD, invariant)
+		sum.Add(sum, balances[k])
+	}
+	sum.Sub(sum, balances[index])
+	invariantSquared := new(big.Int).Mul(invariant, invariant)
+	c := divRoundingUp(invariantSquared, new(big.Int).Mul(ampTimesTotal, pD))
+	c.Mul(c, BalancerAmpPrecision)
+	c.Mul(c, balances[index])
+	b := new(big.Int).Div(invariant, ampTimesTotal)
+	b.Mul(b, BalancerAmpPrecision)
+	b.Add(b, sum)
+	balance := divRoundingUp(new(big.Int).Add(invariantSquared, c), new(big.Int).Add(invariant, b))
+	for i := 0; i < balancerMaxIterations; i++ {
+		previous := balance
+		numerator := new(big.Int).Mul(balance, balance)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(balance, big.NewInt(2))
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, invariant)
+		balance = divRoundingUp(numerator, denominator)
+		if absDiffAtMostOne(balance, previous) {
+			return balance, nil
+		}
+	}
+	return nil, ErrNotConverging
+}
+
+// stableOutGivenIn is a port of StableMath._calcOutGivenIn.
+func stableOutGivenIn(amp *big.Int, balances []*big.Int, i int, j int, amountIn *big.Int) (*big.Int, error) {
+	invariant, err := stableInvariant(amp, balances)
+	if err != nil {
+		return nil, err
+	}
+	updated := append([]*big.Int{}, balances...)
+	updated[i] = new(big.Int).Add(balances[i], amountIn)
+	finalBalanceOut, err := stableBalanceGivenInvariant(amp, updated, invariant, j)
+	if err != nil {
+		return nil, err
+	}
+	amountOut := new(big.Int).Sub(balances[j], finalBalanceOut)
+	amountOut.Sub(amountOut, big.NewInt(1))
+	if amountOut.Sign() != 1 {
+		return big.NewInt(0), nil
+	}
+	return amountOut, nil
+}
+
+// BalancerScalingFactor returns the scaling factor of a token with the given
+// decimals.
+func BalancerScalingFactor(decimals int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(36-decimals), nil)
+}
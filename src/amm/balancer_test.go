@@ -0,0 +1,140 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+// The expected powers follow LogExpMath.pow step for step. Exact is x^y
+// rounded down, worked out with 100 digits.
+func TestLogExpPow(t *testing.T) {
+	tests := []struct {
+		x     string
+		y     string
+		pow   string
+		exact string
+	}{
+		{"2000000000000000000", "500000000000000000", "1414213562373095047", "1414213562373095048"},
+		{"1010000000000000000", "1500000000000000000", "1015037437733209916", "1015037437733209917"},
+		{"500000000000000000", "333333333333333333", "793700525984099738", "793700525984099737"},
+		{"769230769230769231", "3999999999999999999", "350127796645775709", "350127796645775708"},
+		{"999000999000999001", "250000000000000000", "999750156132907634", "999750156132907633"},
+		{"950000000000000000", "99000000000000000000", "6232136021404237", "6232136021404237"},
+		{"123456789012345678901", "700000000000000000", "29111225209066790156", "29111225209066790168"},
+		{"10000000000000000", "2500000000000000000", "10000000000000", "10000000000000"},
+		{"1000000000000000001", "1000000000000000001", "1000000000000000000", "1000000000000000001"},
+	}
+	for _, test := range tests {
+		x, y := bigInt(t, test.x), bigInt(t, test.y)
+		pow, err := logExpPow(x, y)
+		if err != nil {
+			t.Fatalf("%s^%s: %v", test.x, test.y, err)
+		}
+		if pow.String() != test.pow {
+			t.Errorf("%s^%s = %s, want %s", test.x, test.y, pow, test.pow)
+		}
+		// LogExpMath stays within the relative error powUp adds on top, plus
+		// the last wei, so the rounded up power is never below the exact one.
+		exact := bigInt(t, test.exact)
+		bound := mulUpFixed(exact, balancerMaxPowError)
+		bound.Add(bound, big.NewInt(1))
+		difference := new(big.Int).Sub(pow, exact)
+		if difference.Abs(difference).Cmp(bound) == 1 {
+			t.Errorf("%s^%s is %s away from %s", test.x, test.y, difference, test.exact)
+		}
+		up, err := powUpFixed(x, y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if up.Cmp(exact) == -1 {
+			t.Errorf("%s^%s rounded up to %s, below %s", test.x, test.y, up, test.exact)
+		}
+	}
+}
+
+func TestLogExpPowOutOfBounds(t *testing.T) {
+	// e^130 is the largest power LogExpMath takes.
+	_, err := logExpPow(ether(1000), ether(20))
+	if err != ErrPowOutOfBounds {
+		t.Fatalf("got %v, want ErrPowOutOfBounds", err)
+	}
+}
+
+// The expected amounts follow the Vault swap math of the pool kind: the fee
+// taken from the amount in, upscaling, WeightedMath or StableMath, and
+// downscaling of the amount out.
+func TestBalancerGetAmountOut(t *testing.T) {
+	e18 := BalancerScalingFactor(18)
+	e6 := BalancerScalingFactor(6)
+	balWeth := &BalancerState{
+		Kind:           BalancerWeighted,
+		Balances:       []*big.Int{units(30000000, 18), units(5000, 18)},
+		ScalingFactors: []*big.Int{e18, e18},
+		Weights:        []*big.Int{units(8, 17), units(2, 17)},
+	}
+	usdcWeth := &BalancerState{
+		Kind:           BalancerWeighted,
+		Balances:       []*big.Int{units(4000000, 6), units(2000, 18)},
+		ScalingFactors: []*big.Int{e6, e18},
+		Weights:        []*big.Int{units(5, 17), units(5, 17)},
+	}
+	threeTokens := &BalancerState{
+		Kind:           BalancerWeighted,
+		Balances:       []*big.Int{units(1000, 18), units(2000, 6), units(3000, 18)},
+		ScalingFactors: []*big.Int{e18, e6, e18},
+		Weights:        []*big.Int{units(4, 17), units(3, 17), units(3, 17)},
+	}
+	stables := &BalancerState{
+		Kind:           BalancerStable,
+		Balances:       []*big.Int{units(10000000, 18), units(12000000, 6), units(9000000, 6)},
+		ScalingFactors: []*big.Int{e18, e6, e6},
+		Amp:            big.NewInt(1500000),
+	}
+	imbalanced := &BalancerState{
+		Kind:           BalancerStable,
+		Balances:       []*big.Int{units(1000, 18), units(100, 18)},
+		ScalingFactors: []*big.Int{e18, e18},
+		Amp:            big.NewInt(50000),
+	}
+	tests := []struct {
+		name      string
+		pool      *BalancerState
+		fee       *big.Int
+		i         int
+		j         int
+		amountIn  *big.Int
+		amountOut string
+	}{
+		{"80/20 to the light side", balWeth, units(1, 15), 0, 1, units(1000, 18), "665944559192365000"},
+		{"80/20 to the heavy side", balWeth, units(1, 15), 1, 0, units(1, 18), "1498312902548580000000"},
+		{"50/50 from 6 decimals", usdcWeth, units(3, 15), 0, 1, units(10000, 6), "4972605780093116000"},
+		{"50/50 to 6 decimals", usdcWeth, units(3, 15), 1, 0, units(1, 18), "1993006486"},
+		{"three tokens", threeTokens, units(1, 16), 0, 2, units(10, 18), "39147597784482804000"},
+		{"stable to 6 decimals", stables, units(1, 14), 0, 1, units(1000, 18), "1000017156"},
+		{"stable large swap", stables, units(1, 14), 2, 0, units(5000000, 6), "4997493404143484029048671"},
+		{"stable into the thin side", imbalanced, units(4, 14), 0, 1, units(1, 18), "783113247680291495"},
+		{"stable out of the thin side", imbalanced, units(4, 14), 1, 0, units(1, 18), "1271115621504310548"},
+	}
+	for _, test := range tests {
+		amountOut, err := test.pool.GetAmountOut(test.i, test.j, test.amountIn, test.fee)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if amountOut.String() != test.amountOut {
+			t.Errorf("%s: got %s, want %s", test.name, amountOut, test.amountOut)
+		}
+	}
+}
+
+func TestBalancerGetAmountOutMaxInRatio(t *testing.T) {
+	pool := &BalancerState{
+		Kind:           BalancerWeighted,
+		Balances:       []*big.Int{ether(1000), ether(1000)},
+		ScalingFactors: []*big.Int{BalancerScalingFactor(18), BalancerScalingFactor(18)},
+		Weights:        []*big.Int{units(5, 17), units(5, 17)},
+	}
+	_, err := pool.GetAmountOut(0, 1, ether(400), big.NewInt(0))
+	if err != ErrMaxInRatio {
+		t.Fatalf("got %v, want ErrMaxInRatio", err)
+	}
+}
@@ -0,0 +1,29 @@
+package amm
+
+import (
+	"math/big"
+)
+
+var (
+	Q96        = new(big.Int).Lsh(big.NewInt(1), 96)
+	MaxUint160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+func mulDiv(a *big.Int, b *big.Int, denominator *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return product.Div(product, denominator)
+}
+
+func mulDivRoundingUp(a *big.Int, b *big.Int, denominator *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return divRoundingUp(product, denominator)
+}
+
+func divRoundingUp(a *big.Int, b *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
@@ -0,0 +1,179 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+)
+
+// A port of the Balancer LogExpMath library. Signed division in Solidity
+// truncates toward zero, so the port uses Quo and Rem rather than Div and
+// Mod.
+
+var (
+	one18 = big.NewInt(1000000000000000000)
+	one20 = new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+	one36 = new(big.Int).Exp(big.NewInt(10), big.NewInt(36), nil)
+
+	maxNaturalExponent = new(big.Int).Mul(big.NewInt(130), one18)
+	minNaturalExponent = new(big.Int).Mul(big.NewInt(-41), one18)
+	ln36LowerBound     = new(big.Int).Sub(one18, big.NewInt(100000000000000000))
+	ln36UpperBound     = new(big.Int).Add(one18, big.NewInt(100000000000000000))
+	mildExponentBound  = new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), 254), one20)
+
+	// x0 and x1 have 18 decimals and their powers of e none, the rest have
+	// 20 decimals.
+	logExpX0 = bigFromString("128000000000000000000")
+	logExpA0 = bigFromString("38877084059945950922200000000000000000000000000000000000")
+	logExpX1 = bigFromString("64000000000000000000")
+	logExpA1 = bigFromString("6235149080811616882910000000")
+	logExpX  = []*big.Int{
+		bigFromString("3200000000000000000000"),
+		bigFromString("1600000000000000000000"),
+		bigFromString("800000000000000000000"),
+		bigFromString("400000000000000000000"),
+		bigFromString("200000000000000000000"),
+		bigFromString("100000000000000000000"),
+		bigFromString("50000000000000000000"),
+		bigFromString("25000000000000000000"),
+		bigFromString("12500000000000000000"),
+		bigFromString("6250000000000000000"),
+	}
+	logExpA = []*big.Int{
+		bigFromString("7896296018268069516100000000000000"),
+		bigFromString("888611052050787263676000000"),
+		bigFromString("298095798704172827474000"),
+		bigFromString("5459815003314423907810"),
+		bigFromString("738905609893065022723"),
+		bigFromString("271828182845904523536"),
+		bigFromString("164872127070012814685"),
+		bigFromString("128402541668774148407"),
+		bigFromString("113314845306682631683"),
+		bigFromString("106449445891785942956"),
+	}
+
+	ErrPowOutOfBounds = errors.New("pow argument out of bounds")
+)
+
+func bigFromString(s string) *big.Int {
+	value, _ := new(big.Int).SetString(s, 10)
+	return value
+}
+
+// logExpPow is LogExpMath.pow: x to the power of y, both with 18 decimals.
+func logExpPow(x *big.Int, y *big.Int) (*big.Int, error) {
+	if y.Sign() == 0 {
+		return new(big.Int).Set(one18), nil
+	}
+	if x.Sign() == 0 {
+		return big.NewInt(0), nil
+	}
+	if x.Sign() < 0 || x.BitLen() > 255 || y.Sign() < 0 || y.Cmp(mildExponentBound) != -1 {
+		return nil, ErrPowOutOfBounds
+	}
+	var logxTimesY *big.Int
+	if ln36LowerBound.Cmp(x) == -1 && x.Cmp(ln36UpperBound) == -1 {
+		ln36x := ln36(x)
+		quo, rem := new(big.Int).QuoRem(ln36x, one18, new(big.Int))
+		logxTimesY = quo.Mul(quo, y)
+		logxTimesY.Add(logxTimesY, rem.Mul(rem, y).Quo(rem, one18))
+	} else {
+		logxTimesY = new(big.Int).Mul(ln(x), y)
+	}
+	logxTimesY.Quo(logxTimesY, one18)
+	if logxTimesY.Cmp(minNaturalExponent) == -1 || logxTimesY.Cmp(maxNaturalExponent) == 1 {
+		return nil, ErrPowOutOfBounds
+	}
+	return exp(logxTimesY), nil
+}
+
+// exp is LogExpMath.exp for an exponent already checked against the natural
+// exponent bounds.
+func exp(x *big.Int) *big.Int {
+	if x.Sign() < 0 {
+		result := new(big.Int).Mul(one18, one18)
+		return result.Quo(result, exp(new(big.Int).Neg(x)))
+	}
+	x = new(big.Int).Set(x)
+	firstAN := big.NewInt(1)
+	if x.Cmp(logExpX0) != -1 {
+		x.Sub(x, logExpX0)
+		firstAN = logExpA0
+	} else if x.Cmp(logExpX1) != -1 {
+		x.Sub(x, logExpX1)
+		firstAN = logExpA1
+	}
+	x.Mul(x, big.NewInt(100))
+	product := new(big.Int).Set(one20)
+	// Only x2 to x9 are used here, x10 and x11 are left to the series.
+	for k := 0; k < 8; k++ {
+		if x.Cmp(logExpX[k]) != -1 {
+			x.Sub(x, logExpX[k])
+			product.Mul(product, logExpA[k]).Quo(product, one20)
+		}
+	}
+	seriesSum := new(big.Int).Set(one20)
+	term := new(big.Int).Set(x)
+	seriesSum.Add(seriesSum, term)
+	for k := int64(2); k <= 12; k++ {
+		term.Mul(term, x).Quo(term, one20).Quo(term, big.NewInt(k))
+		seriesSum.Add(seriesSum, term)
+	}
+	result := product.Mul(product, seriesSum)
+	result.Quo(result, one20)
+	result.Mul(result, firstAN)
+	return result.Quo(result, big.NewInt(100))
+}
+
+// ln is LogExpMath._ln for a positive argument with 18 decimals.
+func ln(a *big.Int) *big.Int {
+	if a.Cmp(one18) == -1 {
+		inverse := new(big.Int).Mul(one18, one18)
+		inverse.Quo(inverse, a)
+		return new(big.Int).Neg(ln(inverse))
+	}
+	a = new(big.Int).Set(a)
+	sum := big.NewInt(0)
+	if a.Cmp(new(big.Int).Mul(logExpA0, one18)) != -1 {
+		a.Quo(a, logExpA0)
+		sum.Add(sum, logExpX0)
+	}
+	if a.Cmp(new(big.Int).Mul(logExpA1, one18)) != -1 {
+		a.Quo(a, logExpA1)
+		sum.Add(sum, logExpX1)
+	}
+	sum.Mul(sum, big.NewInt(100))
+	a.Mul(a, big.NewInt(100))
+	for k := range logExpX {
+		if a.Cmp(logExpA[k]) != -1 {
+			a.Mul(a, one20).Quo(a, logExpA[k])
+			sum.Add(sum, logExpX[k])
+		}
+	}
+	z := new(big.Int).Sub(a, one20)
+	z.Mul(z, one20).Quo(z, new(big.Int).Add(a, one20))
+	seriesSum := oddSeries(z, one20, 11)
+	return sum.Add(sum, seriesSum).Quo(sum, big.NewInt(100))
+}
+
+// ln36 is LogExpMath._ln_36, which keeps 36 decimals for arguments close to
+// one and returns the logarithm with 36 decimals.
+func ln36(x *big.Int) *big.Int {
+	x = new(big.Int).Mul(x, one18)
+	z := new(big.Int).Sub(x, one36)
+	z.Mul(z, one36).Quo(z, new(big.Int).Add(x, one36))
+	return oddSeries(z, one36, 15)
+}
+
+// oddSeries returns 2 * (z + z^3/3 + ... + z^last/last) at the given scale,
+// the series both logarithms end with.
+func oddSeries(z *big.Int, scale *big.Int, last int64) *big.Int {
+	zSquared := new(big.Int).Mul(z, z)
+	zSquared.Quo(zSquared, scale)
+	num := new(big.Int).Set(z)
+	seriesSum := new(big.Int).Set(z)
+	for k := int64(3); k <= last; k += 2 {
+		num.Mul(num, zSquared).Quo(num, scale)
+		seriesSum.Add(seriesSum, new(big.Int).Quo(num, big.NewInt(k)))
+	}
+	return seriesSum.Mul(seriesSum, big.NewInt(2))
+}
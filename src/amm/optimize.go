@@ -0,0 +1,68 @@
+package amm
+
+import (
+	"math/big"
+)
+
+var (
+	goldenNumerator   = big.NewInt(381966)
+	goldenDenominator = big.NewInt(1000000)
+)
+
+type V2Hop struct {
+	ReserveIn  *big.Int
+	ReserveOut *big.Int
+	Fee        *big.Int
+}
+
+// OptimalV2Input returns the input that maximizes output minus input for a
+// chain of constant product hops. Every hop is x -> p*x / (q + r*x), and so is
+// their composition, which makes the optimum (sqrt(P*Q) - Q) / R.
+func OptimalV2Input(hops []V2Hop) *big.Int {
+	p, q, r := big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	for _, hop := range hops {
+		feeMultiplier := new(big.Int).Sub(FeeDenominator, hop.Fee)
+		hopP := new(big.Int).Mul(feeMultiplier, hop.ReserveOut)
+		hopQ := new(big.Int).Mul(hop.ReserveIn, FeeDenominator)
+		r = new(big.Int).Add(new(big.Int).Mul(hopQ, r), new(big.Int).Mul(feeMultiplier, p))
+		p = new(big.Int).Mul(p, hopP)
+		q = new(big.Int).Mul(q, hopQ)
+	}
+	if p.Cmp(q) != 1 || r.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	optimum := new(big.Int).Sqrt(new(big.Int).Mul(p, q))
+	optimum.Sub(optimum, q)
+	return optimum.Div(optimum, r)
+}
+
+// GoldenSectionSearch maximizes profit over [low, high] until the bracket is
+// no wider than tolerance. profit is expected to be unimodal on the interval.
+func GoldenSectionSearch(profit func(*big.Int) *big.Int, low *big.Int, high *big.Int, tolerance *big.Int) *big.Int {
+	low, high = new(big.Int).Set(low), new(big.Int).Set(high)
+	if tolerance.Sign() != 1 {
+		tolerance = big.NewInt(1)
+	}
+	for new(big.Int).Sub(high, low).Cmp(tolerance) == 1 {
+		step := new(big.Int).Sub(high, low)
+		step.Mul(step, goldenNumerator)
+		step.Div(step, goldenDenominator)
+		// Narrow brackets round the step to zero, which would never shrink
+		// them.
+		if step.Sign() == 0 {
+			step.SetInt64(1)
+		}
+		left := new(big.Int).Add(low, step)
+		right := new(big.Int).Sub(high, step)
+		if left.Cmp(right) != -1 {
+			break
+		}
+		if profit(left).Cmp(profit(right)) == 1 {
+			high = right
+		} else {
+			low = left
+		}
+	}
+	middle := new(big.Int).Add(low, high)
+	return middle.Rsh(middle, 1)
+}
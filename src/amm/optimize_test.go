@@ -0,0 +1,94 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+func chainProfit(hops []V2Hop) func(*big.Int) *big.Int {
+	return func(amountIn *big.Int) *big.Int {
+		amount := amountIn
+		for _, hop := range hops {
+			amount = GetAmountOut(amount, hop.ReserveIn, hop.ReserveOut, hop.Fee)
+		}
+		return new(big.Int).Sub(amount, amountIn)
+	}
+}
+
+func TestOptimalV2InputMaximizesProfit(t *testing.T) {
+	tests := []struct {
+		name string
+		hops []V2Hop
+	}{
+		{"two hops", []V2Hop{
+			{ether(1000), ether(2000000), V2DefaultFee},
+			{ether(1990000), ether(1010), V2DefaultFee},
+		}},
+		{"three hops with other fees", []V2Hop{
+			{ether(500), ether(1500000), big.NewInt(2500)},
+			{ether(800000), ether(800000), big.NewInt(1000)},
+			{ether(1400000), ether(520), V2DefaultFee},
+		}},
+		{"thin pools", []V2Hop{
+			{big.NewInt(1000000), big.NewInt(3000000), V2DefaultFee},
+			{big.NewInt(2500000), big.NewInt(1100000), V2DefaultFee},
+		}},
+	}
+	for _, test := range tests {
+		profit := chainProfit(test.hops)
+		optimum := OptimalV2Input(test.hops)
+		if optimum.Sign() != 1 {
+			t.Errorf("%s: no input for a profitable cycle", test.name)
+			continue
+		}
+		searched := GoldenSectionSearch(profit, big.NewInt(1), test.hops[0].ReserveIn, big.NewInt(1))
+		// The closed form ignores the rounding of the pools, which costs a
+		// few wei at most.
+		shortfall := new(big.Int).Sub(profit(searched), profit(optimum))
+		if shortfall.Cmp(big.NewInt(3)) == 1 {
+			t.Errorf("%s: %s in makes %s, the search found %s making %s", test.name, optimum, profit(optimum), searched, profit(searched))
+		}
+		// The profit falls off on both sides.
+		step := new(big.Int).Div(optimum, big.NewInt(100))
+		for _, amountIn := range []*big.Int{new(big.Int).Sub(optimum, step), new(big.Int).Add(optimum, step)} {
+			if profit(amountIn).Cmp(profit(optimum)) == 1 {
+				t.Errorf("%s: %s in makes more than the optimum %s", test.name, amountIn, optimum)
+			}
+		}
+	}
+}
+
+func TestOptimalV2InputWithoutArbitrage(t *testing.T) {
+	hops := []V2Hop{
+		{ether(1000), ether(2000000), V2DefaultFee},
+		{ether(2000000), ether(1000), V2DefaultFee},
+	}
+	if OptimalV2Input(hops).Sign() != 0 {
+		t.Fatal("got an input for a cycle that only pays fees")
+	}
+}
+
+func TestGoldenSectionSearch(t *testing.T) {
+	// -(x - 1234567)^2 peaks at 1234567.
+	peak := big.NewInt(1234567)
+	profit := func(x *big.Int) *big.Int {
+		distance := new(big.Int).Sub(x, peak)
+		return distance.Neg(distance.Mul(distance, distance))
+	}
+	found := GoldenSectionSearch(profit, big.NewInt(0), big.NewInt(10000000), big.NewInt(10))
+	distance := new(big.Int).Sub(found, peak)
+	if distance.Abs(distance).Cmp(big.NewInt(10)) == 1 {
+		t.Fatalf("found %s, want %s", found, peak)
+	}
+}
+
+// A bracket of a few wei rounds the step to zero.
+func TestGoldenSectionSearchNarrowBracket(t *testing.T) {
+	profit := func(x *big.Int) *big.Int {
+		return new(big.Int).Neg(new(big.Int).Abs(new(big.Int).Sub(x, big.NewInt(2))))
+	}
+	found := GoldenSectionSearch(profit, big.NewInt(0), big.NewInt(5), big.NewInt(1))
+	if found.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("found %s, want 2", found)
+	}
+}
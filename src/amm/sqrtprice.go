@@ -0,0 +1,95 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrInvalidPriceOrLiquidity = errors.New("invalid price or liquidity")
+	ErrPriceOverflow           = errors.New("price overflow")
+)
+
+func GetAmount0Delta(sqrtRatioA *big.Int, sqrtRatioB *big.Int, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtRatioA.Cmp(sqrtRatioB) == 1 {
+		sqrtRatioA, sqrtRatioB = sqrtRatioB, sqrtRatioA
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtRatioB, sqrtRatioA)
+	if roundUp {
+		return divRoundingUp(mulDivRoundingUp(numerator1, numerator2, sqrtRatioB), sqrtRatioA)
+	}
+	amount := mulDiv(numerator1, numerator2, sqrtRatioB)
+	return amount.Div(amount, sqrtRatioA)
+}
+
+func GetAmount1Delta(sqrtRatioA *big.Int, sqrtRatioB *big.Int, liquidity *big.Int, roundUp bool) *big.Int {
+	if sqrtRatioA.Cmp(sqrtRatioB) == 1 {
+		sqrtRatioA, sqrtRatioB = sqrtRatioB, sqrtRatioA
+	}
+	difference := new(big.Int).Sub(sqrtRatioB, sqrtRatioA)
+	if roundUp {
+		return mulDivRoundingUp(liquidity, difference, Q96)
+	}
+	return mulDiv(liquidity, difference, Q96)
+}
+
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return sqrtPX96, nil
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	product := new(big.Int).Mul(amount, sqrtPX96)
+	if add {
+		// The contract falls back to a cheaper formula when the product
+		// overflows 256 bits, which rounds differently.
+		denominator := new(big.Int).Add(numerator1, product)
+		if product.Cmp(MaxUint256) != 1 && denominator.Cmp(MaxUint256) != 1 {
+			return mulDivRoundingUp(numerator1, sqrtPX96, denominator), nil
+		}
+		denominator = new(big.Int).Div(numerator1, sqrtPX96)
+		denominator.Add(denominator, amount)
+		return divRoundingUp(numerator1, denominator), nil
+	}
+	if product.Cmp(MaxUint256) == 1 || numerator1.Cmp(product) != 1 {
+		return nil, ErrPriceOverflow
+	}
+	denominator := new(big.Int).Sub(numerator1, product)
+	return mulDivRoundingUp(numerator1, sqrtPX96, denominator), nil
+}
+
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	if add {
+		quotient := mulDiv(amount, Q96, liquidity)
+		next := quotient.Add(quotient, sqrtPX96)
+		if next.Cmp(MaxUint160) == 1 {
+			return nil, ErrPriceOverflow
+		}
+		return next, nil
+	}
+	quotient := mulDivRoundingUp(amount, Q96, liquidity)
+	if sqrtPX96.Cmp(quotient) != 1 {
+		return nil, ErrPriceOverflow
+	}
+	return quotient.Sub(sqrtPX96, quotient), nil
+}
+
+func GetNextSqrtPriceFromInput(sqrtPX96 *big.Int, liquidity *big.Int, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() != 1 || liquidity.Sign() != 1 {
+		return nil, ErrInvalidPriceOrLiquidity
+	}
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+func GetNextSqrtPriceFromOutput(sqrtPX96 *big.Int, liquidity *big.Int, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() != 1 || liquidity.Sign() != 1 {
+		return nil, ErrInvalidPriceOrLiquidity
+	}
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
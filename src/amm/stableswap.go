@@ -0,0 +1,165 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	StableSwapFeeDenominator = big.NewInt(10000000000)
+	StableSwapPrecision      = big.NewInt(1000000000000000000)
+
+	ErrInvalidCoins   = errors.New("invalid coin index")
+	ErrNotConverging  = errors.New("stableswap invariant did not converge")
+	stableSwapMaxIter = 255
+)
+
+type StableSwapState struct {
+	Balances []*big.Int `json:"balances"`
+	// Rates scale each balance to 18 decimals, multiplied by the precision,
+	// as in the RATES constant of the Curve pool contracts.
+	Rates []*big.Int `json:"rates"`
+	Amp   *big.Int   `json:"amp"`
+}
+
+func (s *StableSwapState) Clone() *StableSwapState {
+	clone := *s
+	return &clone
+}
+
+func (s *StableSwapState) Initialized() bool {
+	if s.Amp == nil || s.Amp.Sign() != 1 || len(s.Balances) < 2 || len(s.Balances) != len(s.Rates) {
+		return false
+	}
+	for _, balance := range s.Balances {
+		if balance == nil || balance.Sign() != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *StableSwapState) xp() []*big.Int {
+	xp := []*big.Int{}
+	for i, balance := range p.Balances {
+		value := new(big.Int).Mul(balance, p.Rates[i])
+		xp = append(xp, value.Div(value, StableSwapPrecision))
+	}
+	return xp
+}
+
+func absDiffAtMostOne(a *big.Int, b *big.Int) bool {
+	difference := new(big.Int).Sub(a, b)
+	return difference.CmpAbs(big.NewInt(1)) != 1
+}
+
+// getD is a port of StableSwap.get_D.
+func (p *StableSwapState) getD(xp []*big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(xp)))
+	sum := big.NewInt(0)
+	for _, x := range xp {
+		sum.Add(sum, x)
+	}
+	if sum.Sign() == 0 {
+		return sum, nil
+	}
+	d := new(big.Int).Set(sum)
+	ann := new(big.Int).Mul(p.Amp, n)
+	for i := 0; i < stableSwapMaxIter; i++ {
+		dP := new(big.Int).Set(d)
+		for _, x := range xp {
+			if x.Sign() == 0 {
+				return nil, ErrNotConverging
+			}
+			dP.Mul(dP, d)
+			dP.Div(dP, new(big.Int).Mul(x, n))
+		}
+		previous := d
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Int).Mul(dP, n))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(new(big.Int).Sub(ann, big.NewInt(1)), d)
+		denominator.Add(denominator, new(big.Int).Mul(new(big.Int).Add(n, big.NewInt(1)), dP))
+		d = numerator.Div(numerator, denominator)
+		if absDiffAtMostOne(d, previous) {
+			return d, nil
+		}
+	}
+	return nil, ErrNotConverging
+}
+
+// getY is a port of StableSwap.get_y.
+func (p *StableSwapState) getY(i int, j int, x *big.Int, xp []*big.Int) (*big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(xp) || j >= len(xp) {
+		return nil, ErrInvalidCoins
+	}
+	d, err := p.getD(xp)
+	if err != nil {
+		return nil, err
+	}
+	n := big.NewInt(int64(len(xp)))
+	ann := new(big.Int).Mul(p.Amp, n)
+	c := new(big.Int).Set(d)
+	sum := big.NewInt(0)
+	for k := range xp {
+		var current *big.Int
+		if k == i {
+			current = x
+		} else if k != j {
+			current = xp[k]
+		} else {
+			continue
+		}
+		sum.Add(sum, current)
+		c.Mul(c, d)
+		c.Div(c, new(big.Int).Mul(current, n))
+	}
+	c.Mul(c, d)
+	c.Div(c, new(big.Int).Mul(ann, n))
+	b := new(big.Int).Add(sum, new(big.Int).Div(d, ann))
+	y := new(big.Int).Set(d)
+	for k := 0; k < stableSwapMaxIter; k++ {
+		previous := y
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(y, big.NewInt(2))
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		y = numerator.Div(numerator, denominator)
+		if absDiffAtMostOne(y, previous) {
+			return y, nil
+		}
+	}
+	return nil, ErrNotConverging
+}
+
+// GetDy is a port of StableSwap.get_dy for plain pools, with the fee on the
+// 1e10 denominator.
+func (p *StableSwapState) GetDy(i int, j int, dx *big.Int, fee *big.Int) (*big.Int, error) {
+	if i < 0 || j < 0 || i >= len(p.Balances) || j >= len(p.Balances) {
+		return nil, ErrInvalidCoins
+	}
+	xp := p.xp()
+	x := new(big.Int).Mul(dx, p.Rates[i])
+	x.Div(x, StableSwapPrecision)
+	x.Add(x, xp[i])
+	y, err := p.getY(i, j, x, xp)
+	if err != nil {
+		return nil, err
+	}
+	dy := new(big.Int).Sub(xp[j], y)
+	dy.Sub(dy, big.NewInt(1))
+	if dy.Sign() != 1 {
+		return big.NewInt(0), nil
+	}
+	dy.Mul(dy, StableSwapPrecision)
+	dy.Div(dy, p.Rates[j])
+	feeAmount := new(big.Int).Mul(fee, dy)
+	feeAmount.Div(feeAmount, StableSwapFeeDenominator)
+	return dy.Sub(dy, feeAmount), nil
+}
+
+// StableSwapRate returns the RATES entry for a coin with the given decimals.
+func StableSwapRate(decimals int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(36-decimals), nil)
+}
@@ -0,0 +1,66 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+func units(amount int64, decimals int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))
+}
+
+// The expected amounts follow get_dy of the 3pool Vyper contract, step for
+// step, at the balances, A and fee of each case.
+func TestStableSwapGetDy(t *testing.T) {
+	threePool := &StableSwapState{
+		Balances: []*big.Int{units(170000000, 18), units(180000000, 6), units(150000000, 6)},
+		Rates:    []*big.Int{StableSwapRate(18), StableSwapRate(6), StableSwapRate(6)},
+		Amp:      big.NewInt(2000),
+	}
+	imbalanced := &StableSwapState{
+		Balances: []*big.Int{units(1000, 18), units(100, 18)},
+		Rates:    []*big.Int{StableSwapRate(18), StableSwapRate(18)},
+		Amp:      big.NewInt(100),
+	}
+	tests := []struct {
+		name string
+		pool *StableSwapState
+		fee  int64
+		i    int
+		j    int
+		dx   *big.Int
+		dy   string
+	}{
+		{"DAI to USDC", threePool, 1000000, 0, 1, units(1000, 18), "999927450"},
+		{"USDC to USDT", threePool, 1000000, 1, 2, units(1000000, 6), "999803448720"},
+		{"USDT to DAI", threePool, 1000000, 2, 0, units(10, 6), "9999658842048096343"},
+		{"a sixth of the pool", threePool, 1000000, 0, 2, units(50000000, 18), "49981642385528"},
+		{"one unit", threePool, 1000000, 1, 0, big.NewInt(1), "999872548233"},
+		{"into the thin side", imbalanced, 4000000, 0, 1, units(1, 18), "873505909486806700"},
+		{"out of the thin side", imbalanced, 4000000, 1, 0, units(1, 18), "1141222768035016411"},
+		{"draining the thin side", imbalanced, 4000000, 0, 1, units(500, 18), "97388756373747733115"},
+	}
+	for _, test := range tests {
+		dy, err := test.pool.GetDy(test.i, test.j, test.dx, big.NewInt(test.fee))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if dy.Cmp(bigInt(t, test.dy)) != 0 {
+			t.Errorf("%s: got %s, want %s", test.name, dy, test.dy)
+		}
+	}
+}
+
+func TestStableSwapGetDyInvalidCoins(t *testing.T) {
+	pool := &StableSwapState{
+		Balances: []*big.Int{units(100, 18), units(100, 18)},
+		Rates:    []*big.Int{StableSwapRate(18), StableSwapRate(18)},
+		Amp:      big.NewInt(100),
+	}
+	for _, coins := range [][2]int{{0, 0}, {0, 2}, {-1, 1}} {
+		_, err := pool.GetDy(coins[0], coins[1], units(1, 18), big.NewInt(0))
+		if err != ErrInvalidCoins {
+			t.Errorf("coins %v: got %v, want %v", coins, err, ErrInvalidCoins)
+		}
+	}
+}
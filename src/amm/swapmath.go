@@ -0,0 +1,73 @@
+package amm
+
+import (
+	"math/big"
+)
+
+type SwapStep struct {
+	SqrtRatioNextX96 *big.Int
+	AmountIn         *big.Int
+	AmountOut        *big.Int
+	FeeAmount        *big.Int
+}
+
+// ComputeSwapStep is a port of SwapMath.computeSwapStep. A non-negative
+// amountRemaining is an exact input, a negative one an exact output.
+func ComputeSwapStep(sqrtRatioCurrentX96 *big.Int, sqrtRatioTargetX96 *big.Int, liquidity *big.Int, amountRemaining *big.Int, feePips *big.Int) (SwapStep, error) {
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) != -1
+	exactIn := amountRemaining.Sign() != -1
+	step := SwapStep{}
+	var err error
+	if exactIn {
+		amountRemainingLessFee := mulDiv(amountRemaining, new(big.Int).Sub(FeeDenominator, feePips), FeeDenominator)
+		if zeroForOne {
+			step.AmountIn = GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			step.AmountIn = GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if amountRemainingLessFee.Cmp(step.AmountIn) != -1 {
+			step.SqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne)
+		}
+	} else {
+		if zeroForOne {
+			step.AmountOut = GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			step.AmountOut = GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if new(big.Int).Neg(amountRemaining).Cmp(step.AmountOut) != -1 {
+			step.SqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, new(big.Int).Neg(amountRemaining), zeroForOne)
+		}
+	}
+	if err != nil {
+		return step, err
+	}
+	max := sqrtRatioTargetX96.Cmp(step.SqrtRatioNextX96) == 0
+	if zeroForOne {
+		if !max || !exactIn {
+			step.AmountIn = GetAmount0Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true)
+		}
+		if !max || exactIn {
+			step.AmountOut = GetAmount1Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false)
+		}
+	} else {
+		if !max || !exactIn {
+			step.AmountIn = GetAmount1Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, true)
+		}
+		if !max || exactIn {
+			step.AmountOut = GetAmount0Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, false)
+		}
+	}
+	if !exactIn && step.AmountOut.Cmp(new(big.Int).Neg(amountRemaining)) == 1 {
+		step.AmountOut = new(big.Int).Neg(amountRemaining)
+	}
+	if exactIn && step.SqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		step.FeeAmount = new(big.Int).Sub(amountRemaining, step.AmountIn)
+	} else {
+		step.FeeAmount = mulDivRoundingUp(step.AmountIn, feePips, new(big.Int).Sub(FeeDenominator, feePips))
+	}
+	return step, nil
+}
@@ -0,0 +1,85 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+// The cases are from the SwapMath tests of Uniswap v3-core. Prices like
+// 79623317895830914510639640423 are encodePriceSqrt(101, 100) there.
+func TestComputeSwapStep(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		target    string
+		liquidity string
+		remaining string
+		fee       int64
+		next      string
+		amountIn  string
+		amountOut string
+		feeAmount string
+	}{
+		{
+			name:    "exact in capped at the target, one for zero",
+			current: "79228162514264337593543950336", target: "79623317895830914510639640423",
+			liquidity: "2000000000000000000", remaining: "1000000000000000000", fee: 600,
+			next: "79623317895830914510639640423", amountIn: "9975124224178055", amountOut: "9925619580021728", feeAmount: "5988667735148",
+		},
+		{
+			name:    "exact in fully spent, one for zero",
+			current: "79228162514264337593543950336", target: "250541448375047931186413801569",
+			liquidity: "2000000000000000000", remaining: "1000000000000000000", fee: 600,
+			next: "118818475322642227089037862318", amountIn: "999400000000000000", amountOut: "666399946655997866", feeAmount: "600000000000000",
+		},
+		{
+			name:    "exact in capped at the target, zero for one",
+			current: "79228162514264337593543950336", target: "78834968213693974763009544974",
+			liquidity: "2000000000000000000", remaining: "1000000000000000000", fee: 600,
+			next: "78834968213693974763009544974", amountIn: "9975124224178055", amountOut: "9925619580021728", feeAmount: "5988667735148",
+		},
+		{
+			name:    "exact in fully spent, zero for one",
+			current: "79228162514264337593543950336", target: "25054144837504793118641380156",
+			liquidity: "2000000000000000000", remaining: "1000000000000000000", fee: 600,
+			next: "52829340877685095414778922676", amountIn: "999400000000000000", amountOut: "666399946655997866", feeAmount: "600000000000000",
+		},
+		{
+			name:    "exact in reaching a price of 1, zero for one",
+			current: "2", target: "1",
+			liquidity: "1", remaining: "3915081100057732413702495386755767", fee: 1,
+			next: "1", amountIn: "39614081257132168796771975168", amountOut: "0", feeAmount: "39614120871253040049813",
+		},
+		{
+			name:    "exact in taken as fee",
+			current: "2413", target: "79887613182836312",
+			liquidity: "1985041575832132834610021537970", remaining: "10", fee: 1872,
+			next: "2413", amountIn: "0", amountOut: "0", feeAmount: "10",
+		},
+		{
+			name:    "exact out capped at the target, one for zero",
+			current: "79228162514264337593543950336", target: "79623317895830914510639640423",
+			liquidity: "2000000000000000000", remaining: "-1000000000000000000", fee: 600,
+			next: "79623317895830914510639640423", amountIn: "9975124224178055", amountOut: "9925619580021728", feeAmount: "5988667735148",
+		},
+		{
+			name:    "exact out capped at the amount",
+			current: "417332158212080721273783715441582", target: "1452870262520218020823638996",
+			liquidity: "159344665391607089467575320103", remaining: "-1", fee: 1,
+			next: "417332158212080721273783715441581", amountIn: "1", amountOut: "1", feeAmount: "1",
+		},
+	}
+	for _, test := range tests {
+		step, err := ComputeSwapStep(bigInt(t, test.current), bigInt(t, test.target), bigInt(t, test.liquidity), bigInt(t, test.remaining), big.NewInt(test.fee))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		got := []*big.Int{step.SqrtRatioNextX96, step.AmountIn, step.AmountOut, step.FeeAmount}
+		want := []string{test.next, test.amountIn, test.amountOut, test.feeAmount}
+		for i, name := range []string{"next price", "amount in", "amount out", "fee"} {
+			if got[i].Cmp(bigInt(t, want[i])) != 0 {
+				t.Errorf("%s: %s %s, want %s", test.name, name, got[i], want[i])
+			}
+		}
+	}
+}
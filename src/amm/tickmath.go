@@ -0,0 +1,120 @@
+package amm
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+const (
+	MinTick = -887272
+	MaxTick = 887272
+)
+
+var (
+	MinSqrtRatio, _ = new(big.Int).SetString("4295128739", 10)
+	MaxSqrtRatio, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+
+	tickRatios = []string{
+		"fff97272373d413259a46990580e213a",
+		"fff2e50f5f656932ef12357cf3c7fdcc",
+		"ffe5caca7e10e4e61c3624eaa0941cd0",
+		"ffcb9843d60f6159c9db58835c926644",
+		"ff973b41fa98c081472e6896dfb254c0",
+		"ff2ea16466c96a3843ec78b326b52861",
+		"fe5dee046a99a2a811c461f1969c3053",
+		"fcbe86c7900a88aedcffc83b479aa3a4",
+		"f987a7253ac413176f2b074cf7815e54",
+		"f3392b0822b70005940c7a398e4b70f3",
+		"e7159475a2c29b7443b29c7fa6e889d9",
+		"d097f3bdfd2022b8845ad8f792aa5825",
+		"a9f746462d870fdf8a65dc1f90e061e5",
+		"70d869a156d2a1b890bb3df62baf32f7",
+		"31be135f97d08fd981231505542fcfa6",
+		"9aa508b5b7a84e1c677de54f3e99bc9",
+		"5d6af8dedb81196699c329225ee604",
+		"2216e584f5fa1ea926041bedfe98",
+		"48a170391f7dc42444e8fa2",
+	}
+	tickRatioInts     = parseTickRatios()
+	firstTickRatio, _ = new(big.Int).SetString("fffcb933bd6fad37aa2d162d1a594001", 16)
+
+	ErrTickOutOfRange      = errors.New("tick out of range")
+	ErrSqrtPriceOutOfRange = errors.New("sqrt price out of range")
+)
+
+func parseTickRatios() []*big.Int {
+	ratios := []*big.Int{}
+	for _, ratio := range tickRatios {
+		value, _ := new(big.Int).SetString(ratio, 16)
+		ratios = append(ratios, value)
+	}
+	return ratios
+}
+
+// GetSqrtRatioAtTick is a port of TickMath.getSqrtRatioAtTick.
+func GetSqrtRatioAtTick(tick int) (*big.Int, error) {
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	if absTick > MaxTick {
+		return nil, ErrTickOutOfRange
+	}
+	var ratio *big.Int
+	if absTick&0x1 != 0 {
+		ratio = new(big.Int).Set(firstTickRatio)
+	} else {
+		ratio = new(big.Int).Lsh(big.NewInt(1), 128)
+	}
+	for i, multiplier := range tickRatioInts {
+		if absTick&(0x2<<i) != 0 {
+			ratio.Mul(ratio, multiplier)
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio = new(big.Int).Div(MaxUint256, ratio)
+	}
+	remainder := new(big.Int).And(ratio, big.NewInt(0xffffffff))
+	ratio.Rsh(ratio, 32)
+	if remainder.Sign() != 0 {
+		ratio.Add(ratio, big.NewInt(1))
+	}
+	return ratio, nil
+}
+
+// GetTickAtSqrtRatio returns the greatest tick whose sqrt ratio is less than
+// or equal to sqrtPriceX96, the same result as TickMath.getTickAtSqrtRatio.
+func GetTickAtSqrtRatio(sqrtPriceX96 *big.Int) (int, error) {
+	if sqrtPriceX96.Cmp(MinSqrtRatio) == -1 || sqrtPriceX96.Cmp(MaxSqrtRatio) != -1 {
+		return 0, ErrSqrtPriceOutOfRange
+	}
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX96), new(big.Float).SetInt(Q96)).Float64()
+	tick := int(math.Floor(2 * math.Log(price) / math.Log(1.0001)))
+	if tick < MinTick {
+		tick = MinTick
+	}
+	if tick > MaxTick-1 {
+		tick = MaxTick - 1
+	}
+	for {
+		ratio, err := GetSqrtRatioAtTick(tick)
+		if err != nil {
+			return 0, err
+		}
+		if ratio.Cmp(sqrtPriceX96) == 1 {
+			tick--
+			continue
+		}
+		next, err := GetSqrtRatioAtTick(tick + 1)
+		if err != nil {
+			return 0, err
+		}
+		if next.Cmp(sqrtPriceX96) != 1 {
+			tick++
+			continue
+		}
+		return tick, nil
+	}
+}
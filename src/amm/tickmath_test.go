@@ -0,0 +1,65 @@
+package amm
+
+import (
+	"testing"
+)
+
+// The cases are from the TickMath tests of Uniswap v3-core.
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	tests := []struct {
+		tick      int
+		sqrtRatio string
+	}{
+		{MinTick, "4295128739"},
+		{MinTick + 1, "4295343490"},
+		{0, "79228162514264337593543950336"},
+		{MaxTick - 1, "1461373636630004318706518188784493106690254656249"},
+		{MaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+	for _, test := range tests {
+		sqrtRatio, err := GetSqrtRatioAtTick(test.tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sqrtRatio.Cmp(bigInt(t, test.sqrtRatio)) != 0 {
+			t.Errorf("tick %d: got %s, want %s", test.tick, sqrtRatio, test.sqrtRatio)
+		}
+	}
+	for _, tick := range []int{MinTick - 1, MaxTick + 1} {
+		_, err := GetSqrtRatioAtTick(tick)
+		if err != ErrTickOutOfRange {
+			t.Errorf("tick %d: got %v, want %v", tick, err, ErrTickOutOfRange)
+		}
+	}
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	tests := []struct {
+		sqrtRatio string
+		tick      int
+	}{
+		{"4295128739", MinTick},
+		{"4295128740", MinTick},
+		{"4295343490", MinTick + 1},
+		{"79228162514264337593543950335", -1},
+		{"79228162514264337593543950336", 0},
+		{"79623317895830914510639640423", 99},
+		{"1461373636630004318706518188784493106690254656249", MaxTick - 1},
+		{"1461446703485210103287273052203988822378723970341", MaxTick - 1},
+	}
+	for _, test := range tests {
+		tick, err := GetTickAtSqrtRatio(bigInt(t, test.sqrtRatio))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tick != test.tick {
+			t.Errorf("sqrt ratio %s: got tick %d, want %d", test.sqrtRatio, tick, test.tick)
+		}
+	}
+	for _, sqrtRatio := range []string{"4295128738", "1461446703485210103287273052203988822378723970342"} {
+		_, err := GetTickAtSqrtRatio(bigInt(t, sqrtRatio))
+		if err != ErrSqrtPriceOutOfRange {
+			t.Errorf("sqrt ratio %s: got %v, want %v", sqrtRatio, err, ErrSqrtPriceOutOfRange)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package amm
+
+import (
+	"math/big"
+)
+
+var (
+	FeeDenominator = big.NewInt(1000000)
+	V2DefaultFee   = big.NewInt(3000)
+)
+
+// GetAmountOut mirrors UniswapV2Library.getAmountOut. The fee is expressed in
+// hundredths of a bip (3000 = 0.3%) like the v3 pool fee, which gives the
+// same rounding as the 997/1000 form used on chain.
+func GetAmountOut(amountIn *big.Int, reserveIn *big.Int, reserveOut *big.Int, fee *big.Int) *big.Int {
+	if amountIn.Sign() != 1 || reserveIn.Sign() != 1 || reserveOut.Sign() != 1 {
+		return big.NewInt(0)
+	}
+	amountInWithFee := new(big.Int).Mul(amountIn, new(big.Int).Sub(FeeDenominator, fee))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, FeeDenominator)
+	denominator.Add(denominator, amountInWithFee)
+	return numerator.Div(numerator, denominator)
+}
+
+// GetAmountIn mirrors UniswapV2Library.getAmountIn.
+func GetAmountIn(amountOut *big.Int, reserveIn *big.Int, reserveOut *big.Int, fee *big.Int) *big.Int {
+	if amountOut.Sign() != 1 || reserveIn.Sign() != 1 || amountOut.Cmp(reserveOut) != -1 {
+		return nil
+	}
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, FeeDenominator)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, new(big.Int).Sub(FeeDenominator, fee))
+	numerator.Div(numerator, denominator)
+	return numerator.Add(numerator, big.NewInt(1))
+}
@@ -0,0 +1,98 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+func ether(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+func bigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		t.Fatalf("bad number %s", value)
+	}
+	return amount
+}
+
+// The cases are the swap test cases of Uniswap v2-core.
+func TestGetAmountOut(t *testing.T) {
+	tests := []struct {
+		amountIn   int64
+		reserveIn  int64
+		reserveOut int64
+		amountOut  string
+	}{
+		{1, 5, 10, "1662497915624478906"},
+		{1, 10, 5, "453305446940074565"},
+		{2, 5, 10, "2851015155847869602"},
+		{2, 10, 5, "831248957812239453"},
+		{1, 10, 10, "906610893880149131"},
+		{1, 100, 100, "987158034397061298"},
+		{1, 1000, 1000, "996006981039903216"},
+	}
+	for _, test := range tests {
+		amountOut := GetAmountOut(ether(test.amountIn), ether(test.reserveIn), ether(test.reserveOut), V2DefaultFee)
+		if amountOut.Cmp(bigInt(t, test.amountOut)) != 0 {
+			t.Errorf("%d in on %d/%d: got %s, want %s", test.amountIn, test.reserveIn, test.reserveOut, amountOut, test.amountOut)
+		}
+	}
+}
+
+// A fee of 3000 has to round like amountIn * 997 / 1000 does on chain.
+func TestGetAmountOutRoundsLikeThePair(t *testing.T) {
+	tests := []struct {
+		amountIn   int64
+		reserveIn  int64
+		reserveOut int64
+	}{
+		{2, 100, 100},
+		{1, 1, 1},
+		{997, 1000003, 999983},
+		{123456789, 987654321, 1000000007},
+		{1000000, 3, 5000000000},
+	}
+	for _, test := range tests {
+		amountInWithFee := big.NewInt(test.amountIn * 997)
+		want := new(big.Int).Mul(amountInWithFee, big.NewInt(test.reserveOut))
+		want.Div(want, new(big.Int).Add(big.NewInt(test.reserveIn*1000), amountInWithFee))
+		amountOut := GetAmountOut(big.NewInt(test.amountIn), big.NewInt(test.reserveIn), big.NewInt(test.reserveOut), V2DefaultFee)
+		if amountOut.Cmp(want) != 0 {
+			t.Errorf("%d in on %d/%d: got %s, want %s", test.amountIn, test.reserveIn, test.reserveOut, amountOut, want)
+		}
+	}
+}
+
+func TestGetAmountOutWithoutLiquidity(t *testing.T) {
+	if GetAmountOut(big.NewInt(1), big.NewInt(0), big.NewInt(100), V2DefaultFee).Sign() != 0 {
+		t.Fatal("got an output from an empty pool")
+	}
+	if GetAmountOut(big.NewInt(0), big.NewInt(100), big.NewInt(100), V2DefaultFee).Sign() != 0 {
+		t.Fatal("got an output for no input")
+	}
+}
+
+func TestGetAmountIn(t *testing.T) {
+	amountIn := GetAmountIn(big.NewInt(1), big.NewInt(100), big.NewInt(100), V2DefaultFee)
+	if amountIn.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("got %s, want 2", amountIn)
+	}
+	if GetAmountIn(big.NewInt(100), big.NewInt(100), big.NewInt(100), V2DefaultFee) != nil {
+		t.Fatal("got an input for the whole reserve")
+	}
+	// The input buys at least the output, and one wei less does not.
+	reserveIn, reserveOut := ether(5), ether(10)
+	for _, amountOut := range []*big.Int{big.NewInt(1), ether(1), bigInt(t, "1662497915624478906"), ether(9)} {
+		amountIn := GetAmountIn(amountOut, reserveIn, reserveOut, V2DefaultFee)
+		if GetAmountOut(amountIn, reserveIn, reserveOut, V2DefaultFee).Cmp(amountOut) == -1 {
+			t.Errorf("%s in buys less than %s", amountIn, amountOut)
+		}
+		less := new(big.Int).Sub(amountIn, big.NewInt(2))
+		if less.Sign() == 1 && GetAmountOut(less, reserveIn, reserveOut, V2DefaultFee).Cmp(amountOut) != -1 {
+			t.Errorf("%s in is more than %s needs", amountIn, amountOut)
+		}
+	}
+}
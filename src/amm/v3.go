@@ -0,0 +1,293 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrNoLiquidity = errors.New("pool state is not initialized")
+
+	tickSpacings = map[int64]int{
+		100:   1,
+		500:   10,
+		3000:  60,
+		10000: 200,
+	}
+)
+
+type Tick struct {
+	LiquidityGross *big.Int `json:"liquidityGross"`
+	LiquidityNet   *big.Int `json:"liquidityNet"`
+}
+
+type V3State struct {
+	SqrtPriceX96 *big.Int         `json:"sqrtPriceX96"`
+	Liquidity    *big.Int         `json:"liquidity"`
+	Tick         int              `json:"tick"`
+	TickSpacing  int              `json:"tickSpacing"`
+	Ticks        map[int]Tick     `json:"ticks"`
+	TickBitmap   map[int]*big.Int `json:"tickBitmap"`
+	// Synced is set when every Mint and Burn since the pool was created has
+	// been applied, so the initialized ticks are complete.
+	Synced       bool   `json:"synced"`
+	LastLogBlock uint64 `json:"lastLogBlock"`
+	LastLogIndex uint   `json:"lastLogIndex"`
+}
+
+func TickSpacingForFee(fee *big.Int) int {
+	if fee == nil {
+		return 0
+	}
+	return tickSpacings[fee.Int64()]
+}
+
+func NewV3State(tickSpacing int) *V3State {
+	return &V3State{
+		TickSpacing: tickSpacing,
+		Ticks:       make(map[int]Tick),
+		TickBitmap:  make(map[int]*big.Int),
+	}
+}
+
+// Clone copies the state, so the copy can be changed without touching the
+// original. The big ints are shared, since they are replaced, never changed.
+func (s *V3State) Clone() *V3State {
+	clone := *s
+	clone.Ticks = make(map[int]Tick, len(s.Ticks))
+	for tick, value := range s.Ticks {
+		clone.Ticks[tick] = value
+	}
+	clone.TickBitmap = make(map[int]*big.Int, len(s.TickBitmap))
+	for word, value := range s.TickBitmap {
+		clone.TickBitmap[word] = value
+	}
+	return &clone
+}
+
+func (s *V3State) Initialized() bool {
+	return s.Synced && s.SqrtPriceX96 != nil && s.Liquidity != nil && s.TickSpacing > 0
+}
+
+// SetSlot updates price, tick and active liquidity as reported by a Swap event.
+func (s *V3State) SetSlot(blockNumber uint64, logIndex uint, sqrtPriceX96 *big.Int, liquidity *big.Int, tick int) {
+	if s.seen(blockNumber, logIndex) {
+		return
+	}
+	s.SqrtPriceX96 = sqrtPriceX96
+	s.Liquidity = liquidity
+	s.Tick = tick
+}
+
+// Seed sets price, tick and active liquidity as read from slot0 and
+// liquidity. Pools that are not initialized yet report a zero price and are
+// left to their first Swap event.
+func (s *V3State) Seed(sqrtPriceX96 *big.Int, liquidity *big.Int, tick int) {
+	if sqrtPriceX96 == nil || sqrtPriceX96.Sign() == 0 {
+		return
+	}
+	s.SqrtPriceX96 = sqrtPriceX96
+	s.Liquidity = liquidity
+	s.Tick = tick
+}
+
+// ApplyLog applies a Mint or Burn once, so overlapping log ranges are harmless.
+func (s *V3State) ApplyLog(blockNumber uint64, logIndex uint, tickLower int, tickUpper int, liquidityDelta *big.Int) {
+	if s.seen(blockNumber, logIndex) {
+		return
+	}
+	s.UpdatePosition(tickLower, tickUpper, liquidityDelta)
+}
+
+func (s *V3State) seen(blockNumber uint64, logIndex uint) bool {
+	if blockNumber < s.LastLogBlock || (blockNumber == s.LastLogBlock && logIndex <= s.LastLogIndex && s.LastLogBlock != 0) {
+		return true
+	}
+	s.LastLogBlock = blockNumber
+	s.LastLogIndex = logIndex
+	return false
+}
+
+// UpdatePosition applies a Mint (positive delta) or Burn (negative delta).
+func (s *V3State) UpdatePosition(tickLower int, tickUpper int, liquidityDelta *big.Int) {
+	if liquidityDelta.Sign() == 0 || s.TickSpacing == 0 {
+		return
+	}
+	s.updateTick(tickLower, liquidityDelta, false)
+	s.updateTick(tickUpper, liquidityDelta, true)
+	if s.SqrtPriceX96 != nil && s.Liquidity != nil && tickLower <= s.Tick && s.Tick < tickUpper {
+		s.Liquidity = new(big.Int).Add(s.Liquidity, liquidityDelta)
+	}
+}
+
+func (s *V3State) updateTick(tick int, liquidityDelta *big.Int, upper bool) {
+	info, ok := s.Ticks[tick]
+	if !ok {
+		info = Tick{LiquidityGross: big.NewInt(0), LiquidityNet: big.NewInt(0)}
+	}
+	grossBefore := info.LiquidityGross
+	info.LiquidityGross = new(big.Int).Add(info.LiquidityGross, liquidityDelta)
+	if upper {
+		info.LiquidityNet = new(big.Int).Sub(info.LiquidityNet, liquidityDelta)
+	} else {
+		info.LiquidityNet = new(big.Int).Add(info.LiquidityNet, liquidityDelta)
+	}
+	if (grossBefore.Sign() == 0) != (info.LiquidityGross.Sign() == 0) {
+		s.flipTick(tick)
+	}
+	if info.LiquidityGross.Sign() == 0 {
+		delete(s.Ticks, tick)
+		return
+	}
+	s.Ticks[tick] = info
+}
+
+func (s *V3State) flipTick(tick int) {
+	wordPos, bitPos := tickPosition(floorDiv(tick, s.TickSpacing))
+	word, ok := s.TickBitmap[wordPos]
+	if !ok {
+		word = big.NewInt(0)
+	}
+	word = new(big.Int).Xor(word, new(big.Int).Lsh(big.NewInt(1), bitPos))
+	if word.Sign() == 0 {
+		delete(s.TickBitmap, wordPos)
+		return
+	}
+	s.TickBitmap[wordPos] = word
+}
+
+func floorDiv(a int, b int) int {
+	quotient := a / b
+	if a < 0 && a%b != 0 {
+		quotient--
+	}
+	return quotient
+}
+
+func tickPosition(compressed int) (int, uint) {
+	return compressed >> 8, uint(compressed & 0xff)
+}
+
+func mostSignificantBit(word *big.Int) int {
+	return word.BitLen() - 1
+}
+
+func leastSignificantBit(word *big.Int) int {
+	return int(word.TrailingZeroBits())
+}
+
+// nextInitializedTickWithinOneWord is a port of TickBitmap.nextInitializedTickWithinOneWord.
+func (s *V3State) nextInitializedTickWithinOneWord(tick int, lte bool) (int, bool) {
+	compressed := floorDiv(tick, s.TickSpacing)
+	if lte {
+		wordPos, bitPos := tickPosition(compressed)
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bitPos+1), big.NewInt(1))
+		masked := s.word(wordPos).And(s.word(wordPos), mask)
+		if masked.Sign() != 0 {
+			return (compressed - (int(bitPos) - mostSignificantBit(masked))) * s.TickSpacing, true
+		}
+		return (compressed - int(bitPos)) * s.TickSpacing, false
+	}
+	wordPos, bitPos := tickPosition(compressed + 1)
+	mask := new(big.Int).Lsh(new(big.Int).Rsh(MaxUint256, bitPos), bitPos)
+	masked := s.word(wordPos).And(s.word(wordPos), mask)
+	if masked.Sign() != 0 {
+		return (compressed + 1 + (leastSignificantBit(masked) - int(bitPos))) * s.TickSpacing, true
+	}
+	return (compressed + 1 + (255 - int(bitPos))) * s.TickSpacing, false
+}
+
+func (s *V3State) word(wordPos int) *big.Int {
+	word, ok := s.TickBitmap[wordPos]
+	if !ok {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(word)
+}
+
+// Swap simulates UniswapV3Pool.swap without a price limit and returns the
+// pool deltas together with the resulting state. Ticks are shared with the
+// receiver since a swap never changes them.
+func (s *V3State) Swap(zeroForOne bool, amountSpecified *big.Int, fee *big.Int) (*big.Int, *big.Int, *V3State, error) {
+	if !s.Initialized() {
+		return nil, nil, nil, ErrNoLiquidity
+	}
+	var sqrtPriceLimitX96 *big.Int
+	if zeroForOne {
+		sqrtPriceLimitX96 = new(big.Int).Add(MinSqrtRatio, big.NewInt(1))
+	} else {
+		sqrtPriceLimitX96 = new(big.Int).Sub(MaxSqrtRatio, big.NewInt(1))
+	}
+	exactInput := amountSpecified.Sign() == 1
+	amountSpecifiedRemaining := new(big.Int).Set(amountSpecified)
+	amountCalculated := big.NewInt(0)
+	sqrtPriceX96 := s.SqrtPriceX96
+	tick := s.Tick
+	liquidity := s.Liquidity
+	for amountSpecifiedRemaining.Sign() != 0 && sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStartX96 := sqrtPriceX96
+		tickNext, initialized := s.nextInitializedTickWithinOneWord(tick, zeroForOne)
+		if tickNext < MinTick {
+			tickNext = MinTick
+		} else if tickNext > MaxTick {
+			tickNext = MaxTick
+		}
+		sqrtPriceNextX96, err := GetSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sqrtPriceTargetX96 := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) == -1) || (!zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) == 1) {
+			sqrtPriceTargetX96 = sqrtPriceLimitX96
+		}
+		step, err := ComputeSwapStep(sqrtPriceX96, sqrtPriceTargetX96, liquidity, amountSpecifiedRemaining, fee)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sqrtPriceX96 = step.SqrtRatioNextX96
+		if exactInput {
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, step.AmountIn)
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, step.FeeAmount)
+			amountCalculated.Sub(amountCalculated, step.AmountOut)
+		} else {
+			amountSpecifiedRemaining.Add(amountSpecifiedRemaining, step.AmountOut)
+			amountCalculated.Add(amountCalculated, step.AmountIn)
+			amountCalculated.Add(amountCalculated, step.FeeAmount)
+		}
+		if sqrtPriceX96.Cmp(sqrtPriceNextX96) == 0 {
+			if initialized {
+				liquidityNet := s.Ticks[tickNext].LiquidityNet
+				if liquidityNet == nil {
+					liquidityNet = big.NewInt(0)
+				}
+				if zeroForOne {
+					liquidity = new(big.Int).Sub(liquidity, liquidityNet)
+				} else {
+					liquidity = new(big.Int).Add(liquidity, liquidityNet)
+				}
+			}
+			if zeroForOne {
+				tick = tickNext - 1
+			} else {
+				tick = tickNext
+			}
+		} else if sqrtPriceX96.Cmp(sqrtPriceStartX96) != 0 {
+			tick, err = GetTickAtSqrtRatio(sqrtPriceX96)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+	var amount0, amount1 *big.Int
+	specified := new(big.Int).Sub(amountSpecified, amountSpecifiedRemaining)
+	if zeroForOne == exactInput {
+		amount0, amount1 = specified, amountCalculated
+	} else {
+		amount0, amount1 = amountCalculated, specified
+	}
+	next := *s
+	next.SqrtPriceX96 = sqrtPriceX96
+	next.Tick = tick
+	next.Liquidity = liquidity
+	return amount0, amount1, &next, nil
+}
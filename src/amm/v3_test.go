@@ -0,0 +1,50 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+)
+
+// The pool is the medium fee pool at a 1:1 price with 2e18 of full range
+// liquidity and 2e18 more on each side of the current price from the swap
+// tests of Uniswap v3-core. Swapping 1e18 in crosses into the extra liquidity.
+func TestV3SwapAcrossTicks(t *testing.T) {
+	liquidity := ether(2)
+	state := NewV3State(60)
+	state.Synced = true
+	state.Seed(Q96, big.NewInt(0), 0)
+	state.UpdatePosition(-887220, 887220, liquidity)
+	state.UpdatePosition(-887220, -60, liquidity)
+	state.UpdatePosition(60, 887220, liquidity)
+	tests := []struct {
+		name       string
+		zeroForOne bool
+		sqrtPrice  string
+		tick       int
+	}{
+		{"token0 in", true, "63344413041367156775711234356", -4476},
+		{"token1 in", false, "99094796746911377506159333248", 4475},
+	}
+	for _, test := range tests {
+		amount0, amount1, next, err := state.Swap(test.zeroForOne, ether(1), V2DefaultFee)
+		if err != nil {
+			t.Fatal(err)
+		}
+		amountIn, amountOut := amount0, amount1
+		if !test.zeroForOne {
+			amountIn, amountOut = amount1, amount0
+		}
+		if amountIn.Cmp(ether(1)) != 0 || amountOut.Cmp(bigInt(t, "-795933705287758544")) != 0 {
+			t.Errorf("%s: amounts %s and %s, want %s and -795933705287758544", test.name, amountIn, amountOut, ether(1))
+		}
+		if next.SqrtPriceX96.Cmp(bigInt(t, test.sqrtPrice)) != 0 || next.Tick != test.tick {
+			t.Errorf("%s: price %s at tick %d, want %s at %d", test.name, next.SqrtPriceX96, next.Tick, test.sqrtPrice, test.tick)
+		}
+		if next.Liquidity.Cmp(ether(4)) != 0 {
+			t.Errorf("%s: liquidity %s after the crossing, want %s", test.name, next.Liquidity, ether(4))
+		}
+	}
+	if state.SqrtPriceX96.Cmp(Q96) != 0 || state.Liquidity.Cmp(liquidity) != 0 {
+		t.Fatal("swap changed the pool it was simulated on")
+	}
+}
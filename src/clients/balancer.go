@@ -0,0 +1,299 @@
+package clients
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/contracts"
+)
+
+var (
+	balancerEventTopics = map[common.Hash]bool{
+		crypto.Keccak256Hash([]byte("SwapFeePercentageChanged(uint256)")):                                 true,
+		crypto.Keccak256Hash([]byte("AmpUpdateStarted(uint256,uint256,uint256,uint256)")):                 true,
+		crypto.Keccak256Hash([]byte("AmpUpdateStopped(uint256)")):                                         true,
+		crypto.Keccak256Hash([]byte("GradualWeightUpdateScheduled(uint256,uint256,uint256[],uint256[])")): true,
+	}
+)
+
+// isBalancerPoolEvent reports events the pools themselves emit when their
+// fee, amplification or weights change. Balance changes come from the Vault.
+func isBalancerPoolEvent(blockLog types.Log) bool {
+	return len(blockLog.Topics) > 0 && balancerEventTopics[blockLog.Topics[0]]
+}
+
+func balancerPoolAddress(poolId [32]byte) common.Address {
+	return common.BytesToAddress(poolId[:20])
+}
+
+func (p Pool) canQuoteBalancer() bool {
+	return p.Balancer != nil && p.Balancer.Initialized() && p.Fee != nil && len(p.Coins) == len(p.Balancer.Balances)
+}
+
+func (p Pool) quoteBalancer(tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) *big.Int {
+	i := p.coinIndex(tokenIn)
+	j := p.coinIndex(tokenOut)
+	if i < 0 || j < 0 || amountIn.Sign() != 1 {
+		return big.NewInt(0)
+	}
+	amountOut, err := p.Balancer.GetAmountOut(i, j, amountIn, p.Fee)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return amountOut
+}
+
+func (p *Pool) setBalancerBalances(blockNumber uint64, balances []*big.Int) {
+	p.Balancer.SetBalances(blockNumber, balances)
+	p.updateBalancerReserves()
+}
+
+func (p *Pool) applyBalancerDeltas(blockLog types.Log, deltas []*big.Int) {
+	p.Balancer.ApplyDeltas(blockLog.BlockNumber, blockLog.Index, deltas)
+	p.updateBalancerReserves()
+}
+
+func (p *Pool) updateBalancerReserves() {
+	p.Reserve0 = p.Balancer.Balances[0]
+	p.Reserve1 = p.Balancer.Balances[1]
+	if !p.Balancer.Initialized() {
+		p.Enabled = false
+	}
+}
+
+func (c *UniswapClient) balancerVault(pool Pool) (*contracts.IBalancerVault, error) {
+	dex, err := c.dexOf(pool)
+	if err != nil {
+		return nil, err
+	}
+	return contracts.NewIBalancerVault(dex.Factory, c.client)
+}
+
+// loadBalancerPool reads the tokens, balances and swap parameters of a pool.
+// Pools that are neither weighted nor stable, or that hold their own BPT,
+// are kept disabled.
+func (c *UniswapClient) loadBalancerPool(pool Pool) (Pool, error) {
+	vault, err := c.balancerVault(pool)
+	if err != nil {
+		return pool, err
+	}
+	poolTokens, err := vault.GetPoolTokens(nil, pool.Balancer.PoolID)
+	if err != nil {
+		return pool, err
+	}
+	if len(poolTokens.Tokens) < 2 {
+		return pool, errors.New("balancer pool has less than two tokens")
+	}
+	contract, err := contracts.NewIBalancerPool(pool.Address, c.client)
+	if err != nil {
+		return pool, err
+	}
+	fee, err := contract.GetSwapFeePercentage(nil)
+	if err != nil {
+		return pool, err
+	}
+	state := &amm.BalancerState{PoolID: pool.Balancer.PoolID}
+	weights, err := contract.GetNormalizedWeights(nil)
+	if err == nil {
+		state.Kind = amm.BalancerWeighted
+		state.Weights = weights
+	} else {
+		amp, err := contract.GetAmplificationParameter(nil)
+		if err == nil {
+			state.Kind = amm.BalancerStable
+			state.Amp = amp.Value
+		}
+	}
+	for _, token := range poolTokens.Tokens {
+		erc20, err := contracts.NewIERC20Metadata(token, c.client)
+		if err != nil {
+			return pool, err
+		}
+		decimals, err := erc20.Decimals(nil)
+		if err != nil {
+			return pool, err
+		}
+		state.ScalingFactors = append(state.ScalingFactors, amm.BalancerScalingFactor(int64(decimals)))
+	}
+	pool.Coins = poolTokens.Tokens
+	pool.Token0 = pool.Coins[0]
+	pool.Token1 = pool.Coins[1]
+	pool.Fee = fee
+	pool.Balancer = state
+	pool.setBalancerBalances(poolTokens.LastChangeBlock.Uint64(), poolTokens.Balances)
+	if state.Kind == "" || pool.coinIndex(pool.Address) != -1 || !c.hasBaseLiquidity(pool) || !c.tokenPolicyAllows(pool) {
+		pool.Enabled = false
+	}
+	return pool, nil
+}
+
+func (c *UniswapClient) queryBalancerSwap(pool Pool, tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	vault, err := c.balancerVault(pool)
+	if err != nil {
+		return nil, err
+	}
+	rawContract := contracts.IBalancerVaultRaw{Contract: vault}
+	var out []interface{}
+	err = rawContract.Call(nil, &out, "queryBatchSwap", uint8(0), []contracts.IVaultBatchSwapStep{{
+		PoolId:        pool.Balancer.PoolID,
+		AssetInIndex:  big.NewInt(0),
+		AssetOutIndex: big.NewInt(1),
+		Amount:        amountIn,
+		UserData:      []byte{},
+	}}, []common.Address{tokenIn, tokenOut}, contracts.IVaultFundManagement{
+		Sender:    c.address,
+		Recipient: c.address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	deltas := out[0].([]*big.Int)
+	return new(big.Int).Neg(deltas[1]), nil
+}
+
+// verifyBalancerPool compares the local quote with queryBatchSwap for a trade
+// of a thousandth of the first token's balance. Pools with rate providers or
+// other pool specific math fail this check.
+func (c *UniswapClient) verifyBalancerPool(pool Pool) bool {
+	if !pool.canQuoteBalancer() {
+		return false
+	}
+	amountIn := new(big.Int).Div(pool.Balancer.Balances[0], big.NewInt(1000))
+	local := pool.quoteBalancer(pool.Coins[0], pool.Coins[1], amountIn)
+	remote, err := c.queryBalancerSwap(pool, pool.Coins[0], pool.Coins[1], amountIn)
+	if err != nil || remote.Sign() != 1 {
+		return false
+	}
+	if !withinTolerance(local, remote, big.NewInt(c.config.Strategy.BalancerQuoteTolerance)) {
+		log.Info().Str("pool", pool.Address.String()).Str("local", local.String()).Str("rpc", remote.String()).Msg("balancer quote mismatch")
+		return false
+	}
+	return true
+}
+
+func (c *UniswapClient) setBalancerReserves(newPools []Pool) {
+	for _, pool := range c.Pools {
+		if pool.Type != PoolTypeBalancer || !pool.Enabled {
+			continue
+		}
+		pool, err := c.loadBalancerPool(pool)
+		if err != nil {
+			log.Info().Err(err).Str("pool", pool.Address.String()).Msg("can not refresh balancer pool")
+			continue
+		}
+		c.setPool(pool)
+	}
+	c.addBalancerPools(newPools)
+}
+
+func (c *UniswapClient) addBalancerPools(newPools []Pool) {
+	for i, pool := range newPools {
+		log.Info().Int("index", i).Int("total", len(newPools)).Msg("balancer pool progress")
+		pool, err := c.loadBalancerPool(pool)
+		if err != nil {
+			pool.Enabled = false
+		}
+		if pool.Enabled && !c.verifyBalancerPool(pool) {
+			pool.Enabled = false
+		}
+		c.setPool(pool)
+	}
+}
+
+// resolveBalancerLog applies a Vault event. It returns the pool whose
+// balances changed, if it is one the bot tracks.
+func (c *UniswapClient) resolveBalancerLog(vaultEvents *contracts.IBalancerVault, dex Dex, blockLog types.Log) (Pool, bool) {
+	poolRegistered, err := vaultEvents.ParsePoolRegistered(blockLog)
+	if err == nil {
+		_, ok := c.Pools[poolRegistered.PoolAddress]
+		if ok {
+			return Pool{}, false
+		}
+		c.addBalancerPools([]Pool{newBalancerPool(dex, poolRegistered.PoolId, poolRegistered.PoolAddress)})
+		return Pool{}, false
+	}
+	if len(blockLog.Topics) < 2 {
+		return Pool{}, false
+	}
+	pool, ok := c.Pools[balancerPoolAddress(blockLog.Topics[1])]
+	if !ok || pool.Balancer == nil || len(pool.Balancer.Balances) != len(pool.Coins) {
+		return Pool{}, false
+	}
+	deltas := []*big.Int{}
+	for range pool.Coins {
+		deltas = append(deltas, big.NewInt(0))
+	}
+	addDelta := func(token common.Address, delta *big.Int) bool {
+		index := pool.coinIndex(token)
+		if index == -1 {
+			return false
+		}
+		deltas[index].Add(deltas[index], delta)
+		return true
+	}
+	swapEvent, err := vaultEvents.ParseSwap(blockLog)
+	if err == nil {
+		if !addDelta(swapEvent.TokenIn, swapEvent.AmountIn) || !addDelta(swapEvent.TokenOut, new(big.Int).Neg(swapEvent.AmountOut)) {
+			return Pool{}, false
+		}
+		pool.applyBalancerDeltas(blockLog, deltas)
+		if !c.hasBaseLiquidity(pool) {
+			pool.Enabled = false
+		}
+		c.setPool(pool)
+		return pool, true
+	}
+	balanceChanged, err := vaultEvents.ParsePoolBalanceChanged(blockLog)
+	if err == nil {
+		for i, token := range balanceChanged.Tokens {
+			delta := new(big.Int).Sub(balanceChanged.Deltas[i], balanceChanged.ProtocolFeeAmounts[i])
+			if !addDelta(token, delta) {
+				return Pool{}, false
+			}
+		}
+		pool.applyBalancerDeltas(blockLog, deltas)
+		if !c.hasBaseLiquidity(pool) {
+			pool.Enabled = false
+		}
+		c.setPool(pool)
+		return pool, true
+	}
+	balanceManaged, err := vaultEvents.ParsePoolBalanceManaged(blockLog)
+	if err == nil {
+		if !addDelta(balanceManaged.Token, new(big.Int).Add(balanceManaged.CashDelta, balanceManaged.ManagedDelta)) {
+			return Pool{}, false
+		}
+		pool.applyBalancerDeltas(blockLog, deltas)
+		if !c.hasBaseLiquidity(pool) {
+			pool.Enabled = false
+		}
+		c.setPool(pool)
+		return pool, true
+	}
+	_, registeredErr := vaultEvents.ParseTokensRegistered(blockLog)
+	_, deregisteredErr := vaultEvents.ParseTokensDeregistered(blockLog)
+	if registeredErr == nil || deregisteredErr == nil {
+		// The pool leaves the index with its old tokens before it is reloaded.
+		pool.Enabled = false
+		c.setPool(pool)
+		pool.Enabled = true
+		c.addBalancerPools([]Pool{pool})
+		pool = c.Pools[pool.Address]
+		return pool, pool.Enabled
+	}
+	return Pool{}, false
+}
+
+func newBalancerPool(dex Dex, poolId [32]byte, address common.Address) Pool {
+	return Pool{
+		Address:  address,
+		Type:     PoolTypeBalancer,
+		Dex:      dex.Name,
+		Enabled:  true,
+		Balancer: &amm.BalancerState{PoolID: poolId},
+	}
+}
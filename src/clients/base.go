@@ -0,0 +1,86 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/amm"
+)
+
+type BaseToken struct {
+	Address    common.Address
+	Symbol     string
+	MinReserve *big.Int
+}
+
+func tokenAmount(amount int64, decimals int64) *big.Int {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
+	return unit.Mul(unit, big.NewInt(amount))
+}
+
+func (c *UniswapClient) baseToken(address common.Address) (BaseToken, bool) {
+	for _, base := range c.profile.BaseTokens {
+		if base.Address == address {
+			return base, true
+		}
+	}
+	return BaseToken{}, false
+}
+
+// hasBaseLiquidity reports whether every base token side of the pool holds at
+// least the minimum reserve configured for that token.
+func (c *UniswapClient) hasBaseLiquidity(pool Pool) bool {
+	for _, token := range pool.coins() {
+		base, ok := c.baseToken(token)
+		if !ok {
+			continue
+		}
+		reserve := pool.reserveOf(token)
+		if reserve == nil || reserve.Cmp(base.MinReserve) == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// toETH values an amount of token at the mid price of its deepest pool with
+// the wrapped native token.
+func (c *UniswapClient) toETH(token common.Address, amount *big.Int) *big.Int {
+	if token == c.profile.WETH || amount == nil {
+		return amount
+	}
+	var best Pool
+	var bestReserve *big.Int
+	for edge := range c.index.graph.pairs[newPairKey(token, c.profile.WETH)] {
+		pool := c.Pools[edge.Address]
+		if pool.Type != PoolTypeV2 && pool.Type != PoolTypeV3 {
+			continue
+		}
+		_, wethReserve, _ := pool.reservesFor(token)
+		if wethReserve == nil {
+			continue
+		}
+		if bestReserve == nil || wethReserve.Cmp(bestReserve) == 1 {
+			best = pool
+			bestReserve = wethReserve
+		}
+	}
+	if bestReserve == nil {
+		return big.NewInt(0)
+	}
+	if best.Type == PoolTypeV3 && best.V3 != nil && best.V3.SqrtPriceX96 != nil {
+		priceX192 := new(big.Int).Mul(best.V3.SqrtPriceX96, best.V3.SqrtPriceX96)
+		q192 := new(big.Int).Mul(amm.Q96, amm.Q96)
+		value := new(big.Int).Mul(amount, priceX192)
+		if best.Token0 == token {
+			return value.Div(value, q192)
+		}
+		value = new(big.Int).Mul(amount, q192)
+		return value.Div(value, priceX192)
+	}
+	tokenReserve, wethReserve, _ := best.reservesFor(token)
+	if tokenReserve.Sign() != 1 {
+		return big.NewInt(0)
+	}
+	value := new(big.Int).Mul(amount, wethReserve)
+	return value.Div(value, tokenReserve)
+}
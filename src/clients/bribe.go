@@ -0,0 +1,250 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/config"
+	"strings"
+)
+
+// bribeHistoryTrades is how many of the latest settled trades are replayed
+// into the bribe strategy on start.
+const bribeHistoryTrades = 10000
+
+// BribeContext is what a bribe strategy knows about the trade it prices.
+type BribeContext struct {
+	// PathType names the protocols of the hops, like v2-v3-v2.
+	PathType  string
+	ProfitETH *big.Int
+	Gas       uint64
+}
+
+// BribeDecision is the share of the profit paid to the builder and why it was
+// chosen.
+type BribeDecision struct {
+	Percent int64
+	Reason  string
+}
+
+// BribeStrategy chooses the share of the profit startArbitrage pays to the
+// builder. It is only used from the run loop.
+type BribeStrategy interface {
+	Choose(ctx BribeContext) BribeDecision
+	// Record tells the strategy whether a bundle sent with the decision
+	// landed.
+	Record(pathType string, decision BribeDecision, landed bool)
+}
+
+func NewBribeStrategy(cfg config.StrategyConfig) BribeStrategy {
+	bribe := cfg.Bribe
+	switch bribe.Strategy {
+	case config.BribeGas:
+		return &GasBribe{TipPerGas: big.NewInt(bribe.TipPerGas), MinPercent: bribe.MinPercent, MaxPercent: bribe.MaxPercent}
+	case config.BribeAdaptive:
+		return NewAdaptiveBribe(bribe.MinPercent, bribe.MaxPercent, bribe.Step, bribe.TargetLandRate, bribe.Window, bribe.ExploreEvery)
+	}
+	return &FixedBribe{Percent: cfg.BribePercent}
+}
+
+// FixedBribe always pays the same share.
+type FixedBribe struct {
+	Percent int64
+}
+
+func (b *FixedBribe) Choose(ctx BribeContext) BribeDecision {
+	return BribeDecision{Percent: b.Percent, Reason: "fixed"}
+}
+
+func (b *FixedBribe) Record(pathType string, decision BribeDecision, landed bool) {}
+
+// GasBribe pays the share that comes to TipPerGas per unit of gas, since
+// builders order bundles by what they pay per gas.
+type GasBribe struct {
+	TipPerGas  *big.Int
+	MinPercent int64
+	MaxPercent int64
+}
+
+func (b *GasBribe) Choose(ctx BribeContext) BribeDecision {
+	if ctx.ProfitETH == nil || ctx.ProfitETH.Sign() != 1 || ctx.Gas == 0 {
+		return BribeDecision{Percent: b.MinPercent, Reason: "no profit or gas to scale by"}
+	}
+	tip := new(big.Int).Mul(b.TipPerGas, new(big.Int).SetUint64(ctx.Gas))
+	percent := new(big.Int).Mul(tip, big.NewInt(100))
+	percent.Add(percent, ctx.ProfitETH)
+	percent.Sub(percent, big.NewInt(1))
+	percent.Div(percent, ctx.ProfitETH)
+	chosen := clampPercent(percent, b.MinPercent, b.MaxPercent)
+	return BribeDecision{
+		Percent: chosen,
+		Reason:  fmt.Sprintf("%s wei per gas over %d gas needs %s%% of %s", b.TipPerGas, ctx.Gas, percent, ctx.ProfitETH),
+	}
+}
+
+func (b *GasBribe) Record(pathType string, decision BribeDecision, landed bool) {}
+
+func clampPercent(percent *big.Int, min int64, max int64) int64 {
+	if percent.Cmp(big.NewInt(min)) == -1 {
+		return min
+	}
+	if percent.Cmp(big.NewInt(max)) == 1 {
+		return max
+	}
+	return percent.Int64()
+}
+
+// bribeStats holds the outcomes of the last bundles sent at a level, oldest
+// first.
+type bribeStats struct {
+	Outcomes []bool
+}
+
+func (s bribeStats) landed() int {
+	landed := 0
+	for _, outcome := range s.Outcomes {
+		if outcome {
+			landed++
+		}
+	}
+	return landed
+}
+
+// landRate estimates how often bundles at the level land, starting from an
+// even chance for levels that were never tried.
+func (s bribeStats) landRate() float64 {
+	return float64(s.landed()+1) / float64(len(s.Outcomes)+2)
+}
+
+// AdaptiveBribe keeps, for each path type, whether the last window bundles at
+// each share of the profit landed, and pays the lowest share that lands often
+// enough. A level that keeps losing falls below the target, so the next one up
+// is tried. Every exploreEvery bundles of a path type try the level below the
+// chosen one, so a level that lost a few bundles gets another chance once the
+// competition moved on.
+type AdaptiveBribe struct {
+	levels       []int64
+	target       float64
+	window       int
+	exploreEvery int
+	stats        map[string]map[int64]*bribeStats
+	chosen       map[string]int
+}
+
+func NewAdaptiveBribe(minPercent int64, maxPercent int64, step int64, target float64, window int, exploreEvery int) *AdaptiveBribe {
+	levels := []int64{}
+	for percent := minPercent; percent < maxPercent; percent += step {
+		levels = append(levels, percent)
+	}
+	levels = append(levels, maxPercent)
+	return &AdaptiveBribe{
+		levels:       levels,
+		target:       target,
+		window:       window,
+		exploreEvery: exploreEvery,
+		stats:        make(map[string]map[int64]*bribeStats),
+		chosen:       make(map[string]int),
+	}
+}
+
+func (b *AdaptiveBribe) level(pathType string, percent int64) bribeStats {
+	stats, ok := b.stats[pathType][percent]
+	if !ok {
+		return bribeStats{}
+	}
+	return *stats
+}
+
+func (b *AdaptiveBribe) Choose(ctx BribeContext) BribeDecision {
+	b.chosen[ctx.PathType]++
+	explore := b.exploreEvery > 0 && b.chosen[ctx.PathType]%b.exploreEvery == 0
+	for i, percent := range b.levels {
+		stats := b.level(ctx.PathType, percent)
+		if stats.landRate() < b.target {
+			continue
+		}
+		if explore && i > 0 {
+			return b.explore(ctx.PathType, b.levels[i-1])
+		}
+		return BribeDecision{
+			Percent: percent,
+			Reason:  fmt.Sprintf("%d%% landed %d of the last %d %s bundles", percent, stats.landed(), len(stats.Outcomes), ctx.PathType),
+		}
+	}
+	if explore && len(b.levels) > 1 {
+		return b.explore(ctx.PathType, b.levels[len(b.levels)-2])
+	}
+	percent := b.levels[len(b.levels)-1]
+	return BribeDecision{
+		Percent: percent,
+		Reason:  fmt.Sprintf("no share lands %.0f%% of %s bundles, paying the most", b.target*100, ctx.PathType),
+	}
+}
+
+func (b *AdaptiveBribe) explore(pathType string, percent int64) BribeDecision {
+	stats := b.level(pathType, percent)
+	return BribeDecision{
+		Percent: percent,
+		Reason:  fmt.Sprintf("exploring %d%%, which landed %d of the last %d %s bundles", percent, stats.landed(), len(stats.Outcomes), pathType),
+	}
+}
+
+func (b *AdaptiveBribe) Record(pathType string, decision BribeDecision, landed bool) {
+	_, ok := b.stats[pathType]
+	if !ok {
+		b.stats[pathType] = make(map[int64]*bribeStats)
+	}
+	stats, ok := b.stats[pathType][decision.Percent]
+	if !ok {
+		stats = &bribeStats{}
+		b.stats[pathType][decision.Percent] = stats
+	}
+	stats.Outcomes = append(stats.Outcomes, landed)
+	if len(stats.Outcomes) > b.window {
+		stats.Outcomes = stats.Outcomes[len(stats.Outcomes)-b.window:]
+	}
+}
+
+// sentBundle is a bundle whose outcome the bribe strategy has not learned
+// yet. LastBlock is the last block it was sent for.
+type sentBundle struct {
+	TxHash    common.Hash
+	PathType  string
+	Bribe     BribeDecision
+	LastBlock uint64
+}
+
+func (c *UniswapClient) pathType(tx ArbitrageTx) string {
+	protocols := []string{}
+	for _, address := range tx.Pools {
+		protocols = append(protocols, c.Pools[address].Type)
+	}
+	return strings.Join(protocols, "-")
+}
+
+// settleBundles tells the bribe strategy which of the bundles sent up to the
+// block landed.
+func (c *UniswapClient) settleBundles(blockNumber uint64) {
+	pending := []sentBundle{}
+	for _, bundle := range c.sentBundles {
+		if bundle.LastBlock > blockNumber {
+			pending = append(pending, bundle)
+			continue
+		}
+		receipt, err := c.client.TransactionReceipt(c.ctx, bundle.TxHash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			log.Info().Err(err).Str("tx", bundle.TxHash.String()).Msg("can not check bundle")
+			pending = append(pending, bundle)
+			continue
+		}
+		landed := err == nil && receipt.Status == types.ReceiptStatusSuccessful
+		c.bribe.Record(bundle.PathType, bundle.Bribe, landed)
+		c.settleTrade(bundle.TxHash, landed)
+		log.Info().Str("tx", bundle.TxHash.String()).Str("pathType", bundle.PathType).Int64("bribePercent", bundle.Bribe.Percent).Bool("landed", landed).Msg("bundle settled")
+	}
+	c.sentBundles = pending
+}
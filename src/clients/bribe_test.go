@@ -0,0 +1,122 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"path/filepath"
+	"testing"
+)
+
+// settle sends rounds bundles of the path type, which land when they pay at
+// least threshold percent, and returns the share chosen last without
+// exploring.
+func settle(b *AdaptiveBribe, pathType string, threshold int64, rounds int) int64 {
+	last := int64(-1)
+	for i := 0; i < rounds; i++ {
+		decision := b.Choose(BribeContext{PathType: pathType})
+		b.Record(pathType, decision, decision.Percent >= threshold)
+		if b.chosen[pathType]%b.exploreEvery != 0 {
+			last = decision.Percent
+		}
+	}
+	return last
+}
+
+func TestAdaptiveBribeConverges(t *testing.T) {
+	b := NewAdaptiveBribe(1, 90, 5, 0.5, 20, 10)
+	chosen := settle(b, "v2-v2", 20, 200)
+	if chosen != 21 {
+		t.Fatalf("chose %d%%, want the lowest level that lands, 21%%", chosen)
+	}
+	// Other path types learn on their own.
+	decision := b.Choose(BribeContext{PathType: "v2-v3"})
+	if decision.Percent != 1 {
+		t.Fatalf("untried path type chose %d%%, want 1%%", decision.Percent)
+	}
+}
+
+func TestAdaptiveBribeComesBackDown(t *testing.T) {
+	b := NewAdaptiveBribe(1, 90, 5, 0.5, 20, 10)
+	settle(b, "v2-v2", 20, 200)
+	// The competition left, so lower levels that lost before land now.
+	chosen := settle(b, "v2-v2", 5, 2000)
+	if chosen != 6 {
+		t.Fatalf("chose %d%%, want 6%% once lower levels land again", chosen)
+	}
+}
+
+func TestAdaptiveBribeExploresBelowTheChosenLevel(t *testing.T) {
+	b := NewAdaptiveBribe(1, 90, 5, 0.5, 20, 10)
+	for i := 0; i < 20; i++ {
+		b.Record("v2-v2", BribeDecision{Percent: 1}, false)
+		b.Record("v2-v2", BribeDecision{Percent: 6}, true)
+	}
+	for i := 1; i <= 20; i++ {
+		decision := b.Choose(BribeContext{PathType: "v2-v2"})
+		want := int64(6)
+		if i%10 == 0 {
+			want = 1
+		}
+		if decision.Percent != want {
+			t.Fatalf("choice %d paid %d%%, want %d%%", i, decision.Percent, want)
+		}
+	}
+}
+
+func TestAdaptiveBribeKeepsAWindow(t *testing.T) {
+	b := NewAdaptiveBribe(1, 90, 5, 0.5, 20, 10)
+	for i := 0; i < 30; i++ {
+		b.Record("v2-v2", BribeDecision{Percent: 1}, i >= 20)
+	}
+	stats := b.level("v2-v2", 1)
+	if len(stats.Outcomes) != 20 || stats.landed() != 10 {
+		t.Fatalf("kept %d outcomes with %d landed, want 20 with 10", len(stats.Outcomes), stats.landed())
+	}
+}
+
+func TestBribeHistoryFromTrades(t *testing.T) {
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	c := &UniswapClient{chainId: big.NewInt(1), store: store, bribe: NewAdaptiveBribe(1, 90, 5, 0.5, 20, 10)}
+	trades := []struct {
+		hash    common.Hash
+		percent int64
+		landed  bool
+	}{
+		{common.HexToHash("0x01"), 1, false},
+		{common.HexToHash("0x02"), 1, false},
+		{common.HexToHash("0x03"), 6, true},
+		{common.HexToHash("0x04"), 6, false},
+	}
+	for _, trade := range trades {
+		err := store.RecordTrade(TradeRecord{ChainID: 1, Hash: trade.hash.String(), PathType: "v2-v3", BribePercent: trade.percent, Status: TradeSent})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.settleTrade(trade.hash, trade.landed)
+	}
+	// Unsettled and failed trades are not outcomes.
+	store.RecordTrade(TradeRecord{ChainID: 1, Hash: "0x05", PathType: "v2-v3", BribePercent: 11, Status: TradeSent})
+	store.RecordTrade(TradeRecord{ChainID: 1, PathType: "v2-v3", BribePercent: 11, Status: TradeFailed})
+	err = c.loadBribeHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := c.bribe.(*AdaptiveBribe)
+	if stats := b.level("v2-v3", 1); len(stats.Outcomes) != 2 || stats.landed() != 0 {
+		t.Fatalf("1%% has %v", stats.Outcomes)
+	}
+	if stats := b.level("v2-v3", 6); len(stats.Outcomes) != 2 || stats.landed() != 1 {
+		t.Fatalf("6%% has %v", stats.Outcomes)
+	}
+	if stats := b.level("v2-v3", 11); len(stats.Outcomes) != 0 {
+		t.Fatalf("11%% has %v", stats.Outcomes)
+	}
+	decision := b.Choose(BribeContext{PathType: "v2-v3"})
+	if decision.Percent != 6 {
+		t.Fatalf("chose %d%% after the restart, want 6%%", decision.Percent)
+	}
+}
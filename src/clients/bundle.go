@@ -0,0 +1,380 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	flashbotsSignatureHeader = "X-Flashbots-Signature"
+	bundleRequestTimeout     = 2 * time.Second
+)
+
+var (
+	ErrBundleRejected     = errors.New("no builder accepted the bundle")
+	ErrSimulationFailed   = errors.New("bundle simulation failed")
+	ErrTargetReverted     = errors.New("backrun target reverts")
+	ErrBelowProfitMinimum = errors.New("simulated net profit is below the minimum")
+)
+
+// BundleRelay sends bundles to a set of builders with eth_sendBundle and
+// simulates them with eth_callBundle. The payloads are signed with a
+// reputation key, which builders use to rank searchers and which holds no
+// funds.
+type BundleRelay struct {
+	builders  []string
+	simulator string
+	key       *ecdsa.PrivateKey
+	client    *http.Client
+}
+
+// BundleSubmission is the outcome of sending a bundle to one builder for one
+// block.
+type BundleSubmission struct {
+	Builder    string
+	Block      uint64
+	BundleHash common.Hash
+	Err        error
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+type sendBundleArgs struct {
+	Txs         []hexutil.Bytes `json:"txs"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+}
+
+type sendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+type callBundleArgs struct {
+	Txs              []hexutil.Bytes `json:"txs"`
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	StateBlockNumber string          `json:"stateBlockNumber"`
+}
+
+// callBundleResult holds the amounts in wei as decimal strings, which is
+// how the relays report them.
+type callBundleResult struct {
+	Results      []callBundleTxResult `json:"results"`
+	CoinbaseDiff string               `json:"coinbaseDiff"`
+	TotalGasUsed uint64               `json:"totalGasUsed"`
+}
+
+type callBundleTxResult struct {
+	TxHash            common.Hash `json:"txHash"`
+	GasUsed           uint64      `json:"gasUsed"`
+	GasFees           string      `json:"gasFees"`
+	CoinbaseDiff      string      `json:"coinbaseDiff"`
+	EthSentToCoinbase string      `json:"ethSentToCoinbase"`
+	Error             string      `json:"error"`
+	Revert            string      `json:"revert"`
+}
+
+// BundleSimulation is the outcome of simulating a bundle, with one result per
+// transaction.
+type BundleSimulation struct {
+	Results      []TxSimulation
+	CoinbaseDiff *big.Int
+	TotalGasUsed uint64
+}
+
+// TxSimulation is the outcome of one transaction of a simulated bundle. Err
+// is set when it reverted.
+type TxSimulation struct {
+	TxHash            common.Hash
+	GasUsed           uint64
+	GasFees           *big.Int
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	Err               error
+}
+
+// NewBundleRelay returns a relay for the builders. Bundles are simulated on
+// the first builder when there is no simulator.
+func NewBundleRelay(builders []string, simulator string, key *ecdsa.PrivateKey) *BundleRelay {
+	if simulator == "" && len(builders) > 0 {
+		simulator = builders[0]
+	}
+	return &BundleRelay{
+		builders:  builders,
+		simulator: simulator,
+		key:       key,
+		client:    &http.Client{Timeout: bundleRequestTimeout},
+	}
+}
+
+// signature is the X-Flashbots-Signature of a body: the address of the key
+// and its EIP-191 signature of the hex encoded keccak hash of the body.
+func (r *BundleRelay) signature(body []byte) (string, error) {
+	hash := hexutil.Encode(crypto.Keccak256(body))
+	signature, err := crypto.Sign(accounts.TextHash([]byte(hash)), r.key)
+	if err != nil {
+		return "", err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return crypto.PubkeyToAddress(r.key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
+
+// call sends a signed JSON-RPC request to a builder.
+func (r *BundleRelay) call(ctx context.Context, builder string, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: []interface{}{params}})
+	if err != nil {
+		return err
+	}
+	signature, err := r.signature(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, builder, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(flashbotsSignatureHeader, signature)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("POST %s: %s: %s", req.URL.Redacted(), resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var response rpcResponse
+	err = json.Unmarshal(respBody, &response)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil || len(response.Result) == 0 || string(response.Result) == "null" {
+		return nil
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+func encodeTxs(txs []*types.Transaction) ([]hexutil.Bytes, error) {
+	rawTxs := []hexutil.Bytes{}
+	for _, tx := range txs {
+		rawTx, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		rawTxs = append(rawTxs, rawTx)
+	}
+	return rawTxs, nil
+}
+
+func parseWei(value string) *big.Int {
+	amount, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
+// CallBundle simulates the transactions as a bundle for block on top of the
+// latest state.
+func (r *BundleRelay) CallBundle(ctx context.Context, txs []*types.Transaction, block uint64) (BundleSimulation, error) {
+	rawTxs, err := encodeTxs(txs)
+	if err != nil {
+		return BundleSimulation{}, err
+	}
+	var result callBundleResult
+	err = r.call(ctx, r.simulator, "eth_callBundle", callBundleArgs{Txs: rawTxs, BlockNumber: hexutil.Uint64(block), StateBlockNumber: "latest"}, &result)
+	if err != nil {
+		return BundleSimulation{}, err
+	}
+	simulation := BundleSimulation{
+		CoinbaseDiff: parseWei(result.CoinbaseDiff),
+		TotalGasUsed: result.TotalGasUsed,
+	}
+	for _, txResult := range result.Results {
+		txSimulation := TxSimulation{
+			TxHash:            txResult.TxHash,
+			GasUsed:           txResult.GasUsed,
+			GasFees:           parseWei(txResult.GasFees),
+			CoinbaseDiff:      parseWei(txResult.CoinbaseDiff),
+			EthSentToCoinbase: parseWei(txResult.EthSentToCoinbase),
+		}
+		if txResult.Error != "" || txResult.Revert != "" {
+			txSimulation.Err = decodeSimulationRevert(txResult.Error, txResult.Revert)
+		}
+		simulation.Results = append(simulation.Results, txSimulation)
+	}
+	if len(simulation.Results) != len(txs) {
+		return simulation, fmt.Errorf("simulation returned %d results for %d transactions", len(simulation.Results), len(txs))
+	}
+	return simulation, nil
+}
+
+// SendBundle sends the transactions as one bundle for each of the blocks to
+// every builder in parallel. It only fails when no builder accepted it.
+func (r *BundleRelay) SendBundle(ctx context.Context, txs []*types.Transaction, blocks []uint64) ([]BundleSubmission, error) {
+	rawTxs, err := encodeTxs(txs)
+	if err != nil {
+		return nil, err
+	}
+	submissions := make([]BundleSubmission, len(blocks)*len(r.builders))
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		for j, builder := range r.builders {
+			wg.Add(1)
+			go func(index int, builder string, block uint64) {
+				defer wg.Done()
+				var raw json.RawMessage
+				err := r.call(ctx, builder, "eth_sendBundle", sendBundleArgs{Txs: rawTxs, BlockNumber: hexutil.Uint64(block)}, &raw)
+				// Not every builder answers with the bundle hash.
+				var result sendBundleResult
+				if len(raw) != 0 && string(raw) != "null" {
+					parseErr := json.Unmarshal(raw, &result)
+					if parseErr != nil {
+						log.Info().Err(parseErr).Str("builder", builder).Str("result", string(raw)).Msg("can not parse bundle hash")
+					}
+				}
+				submissions[index] = BundleSubmission{Builder: builder, Block: block, BundleHash: result.BundleHash, Err: err}
+			}(i*len(r.builders)+j, builder, block)
+		}
+	}
+	wg.Wait()
+	problems := []error{}
+	for _, submission := range submissions {
+		if submission.Err == nil {
+			return submissions, nil
+		}
+		problems = append(problems, fmt.Errorf("%s: %w", submission.Builder, submission.Err))
+	}
+	return submissions, errors.Join(append([]error{ErrBundleRejected}, problems...)...)
+}
+
+// bundleSigningKey parses the configured reputation key, or makes a throwaway
+// one when none is configured.
+func bundleSigningKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	if hexKey != "" {
+		return crypto.HexToECDSA(hexKey)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	log.Warn().Str("address", crypto.PubkeyToAddress(key.PublicKey).Hex()).Msg("no bundle signing key configured, using a throwaway key without reputation")
+	return key, nil
+}
+
+// targetBlocks returns block and, when configured, the blocks after it.
+func (c *UniswapClient) targetBlocks(block uint64) []uint64 {
+	blocks := []uint64{}
+	for i := 0; i < c.config.Bundles.TargetBlocks; i++ {
+		blocks = append(blocks, block+uint64(i))
+	}
+	return blocks
+}
+
+// simulateBundle simulates the arbitrage for block, behind target when one is
+// given, and returns what it pays in gas and to the builder. It fails when a
+// transaction reverts or the profit left is not above the minimum. The profit
+// is what the simulation made, not the quote, since the pools can move before
+// the target block.
+func (c *UniswapClient) simulateBundle(tx ArbitrageTx, signedTx *types.Transaction, target *types.Transaction, block uint64, fees FeeEstimate, bribePercent int64) (*big.Int, *big.Int, error) {
+	gasCost := big.NewInt(0)
+	bribe := big.NewInt(0)
+	txs := []*types.Transaction{signedTx}
+	if target != nil {
+		txs = []*types.Transaction{target, signedTx}
+	}
+	simulation, err := c.relay.CallBundle(c.ctx, txs, block)
+	if err != nil {
+		return gasCost, bribe, fmt.Errorf("%w: %v", ErrSimulationFailed, err)
+	}
+	if target != nil && simulation.Results[0].Err != nil {
+		return gasCost, bribe, fmt.Errorf("%w: %v", ErrTargetReverted, simulation.Results[0].Err)
+	}
+	result := simulation.Results[len(simulation.Results)-1]
+	// The simulator prices gas at the base fee of the latest block, the
+	// target block's is predicted.
+	gasCost = fees.Cost(result.GasUsed)
+	bribe = result.EthSentToCoinbase
+	if result.Err != nil {
+		return gasCost, bribe, result.Err
+	}
+	profit := simulatedProfit(tx.ProfitETH, bribe, bribePercent)
+	netProfit := new(big.Int).Sub(profit, gasCost)
+	netProfit.Sub(netProfit, bribe)
+	log.Info().Str("path", tx.Path).Uint64("gasUsed", result.GasUsed).Str("gasFees", gasCost.String()).Str("simulatedGasFees", result.GasFees.String()).Str("bribe", bribe.String()).Str("coinbaseDiff", result.CoinbaseDiff.String()).Str("quotedProfit", tx.ProfitETH.String()).Str("profit", profit.String()).Str("netProfit", netProfit.String()).Msg("bundle simulated")
+	if netProfit.Cmp(big.NewInt(c.config.Bundles.MinNetProfit)) != 1 {
+		return gasCost, bribe, ErrBelowProfitMinimum
+	}
+	return gasCost, bribe, nil
+}
+
+// simulatedProfit is the profit of a simulated arbitrage. startArbitrage
+// sends bribePercent of it to the coinbase, so it follows from what the
+// simulation sent there. Without a bribe nothing is sent and the quote is
+// all there is.
+func simulatedProfit(quoted *big.Int, sent *big.Int, bribePercent int64) *big.Int {
+	if bribePercent <= 0 {
+		return new(big.Int).Set(quoted)
+	}
+	profit := new(big.Int).Mul(sent, big.NewInt(100))
+	return profit.Div(profit, big.NewInt(bribePercent))
+}
+
+// sendBundle sends the arbitrage to the builders, right behind target when
+// one is given.
+func (c *UniswapClient) sendBundle(tx *types.Transaction, target *types.Transaction, block uint64) error {
+	txs := []*types.Transaction{tx}
+	if target != nil {
+		txs = []*types.Transaction{target, tx}
+	}
+	submissions, err := c.relay.SendBundle(c.ctx, txs, c.targetBlocks(block))
+	if err != nil {
+		return err
+	}
+	for _, submission := range submissions {
+		if submission.Err != nil {
+			log.Info().Err(submission.Err).Str("builder", submission.Builder).Uint64("block", submission.Block).Msg("builder rejected bundle")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"io"
+	"math/big"
+	"mev_bot/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubBuilder is a local stand-in for a builder or relay endpoint. It checks
+// the signature of every request and answers with respond.
+type stubBuilder struct {
+	t        *testing.T
+	signer   common.Address
+	respond  func(method string, params json.RawMessage) (int, string)
+	mu       sync.Mutex
+	blocks   []uint64
+	requests int
+}
+
+func (b *stubBuilder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		b.t.Error(err)
+		return
+	}
+	signer, err := recoverFlashbotsSigner(r.Header.Get(flashbotsSignatureHeader), body)
+	if err != nil || signer != b.signer {
+		b.t.Errorf("signature of %s does not recover to %s: %v", signer, b.signer, err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var request struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	err = json.Unmarshal(body, &request)
+	if err != nil || len(request.Params) != 1 {
+		b.t.Errorf("bad request %s", body)
+		return
+	}
+	var args struct {
+		BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	}
+	json.Unmarshal(request.Params[0], &args)
+	b.mu.Lock()
+	b.requests++
+	b.blocks = append(b.blocks, uint64(args.BlockNumber))
+	b.mu.Unlock()
+	status, response := b.respond(request.Method, request.Params[0])
+	w.WriteHeader(status)
+	io.WriteString(w, response)
+}
+
+func recoverFlashbotsSigner(header string, body []byte) (common.Address, error) {
+	address, signature, ok := strings.Cut(header, ":")
+	if !ok {
+		return common.Address{}, errors.New("no address in the header")
+	}
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, errors.New("bad signature length")
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	hash := hexutil.Encode(crypto.Keccak256(body))
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(hash)), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	signer := crypto.PubkeyToAddress(*publicKey)
+	if signer != common.HexToAddress(address) {
+		return signer, errors.New("header address differs from the signer")
+	}
+	return signer, nil
+}
+
+func newStubBuilder(t *testing.T, key *ecdsa.PrivateKey, respond func(method string, params json.RawMessage) (int, string)) (*stubBuilder, string) {
+	builder := &stubBuilder{t: t, signer: crypto.PubkeyToAddress(key.PublicKey), respond: respond}
+	server := httptest.NewServer(builder)
+	t.Cleanup(server.Close)
+	return builder, server.URL
+}
+
+func acceptBundle(method string, params json.RawMessage) (int, string) {
+	return http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x00000000000000000000000000000000000000000000000000000000000000aa"}}`
+}
+
+func rejectBundle(method string, params json.RawMessage) (int, string) {
+	return http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"bundle rejected"}}`
+}
+
+func failBundle(method string, params json.RawMessage) (int, string) {
+	return http.StatusInternalServerError, "internal error"
+}
+
+func signedTestTx(t *testing.T, nonce uint64) *types.Transaction {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x01")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func testRelayKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSendBundleSignsAndFansOut(t *testing.T) {
+	key := testRelayKey(t)
+	// Every builder holds its answer until all requests arrived, which only
+	// happens when they are sent in parallel.
+	const total = 6
+	var arrived sync.WaitGroup
+	arrived.Add(total)
+	allArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(allArrived)
+	}()
+	parallel := func(method string, params json.RawMessage) (int, string) {
+		if method != "eth_sendBundle" {
+			t.Errorf("method %s", method)
+		}
+		arrived.Done()
+		select {
+		case <-allArrived:
+			return acceptBundle(method, params)
+		case <-time.After(time.Second):
+			return http.StatusGatewayTimeout, "requests were sent one after another"
+		}
+	}
+	builders := []*stubBuilder{}
+	urls := []string{}
+	for i := 0; i < 3; i++ {
+		builder, url := newStubBuilder(t, key, parallel)
+		builders = append(builders, builder)
+		urls = append(urls, url)
+	}
+	relay := NewBundleRelay(urls, "", key)
+	submissions, err := relay.SendBundle(context.Background(), []*types.Transaction{signedTestTx(t, 0), signedTestTx(t, 1)}, []uint64{100, 101})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(submissions) != total {
+		t.Fatalf("%d submissions, want %d", len(submissions), total)
+	}
+	for _, submission := range submissions {
+		if submission.Err != nil {
+			t.Errorf("%s block %d: %v", submission.Builder, submission.Block, submission.Err)
+		}
+		if submission.BundleHash != common.HexToHash("0xaa") {
+			t.Errorf("bundle hash %s", submission.BundleHash)
+		}
+	}
+	for _, builder := range builders {
+		if builder.requests != 2 || builder.blocks[0]+builder.blocks[1] != 201 {
+			t.Errorf("builder got blocks %v", builder.blocks)
+		}
+	}
+}
+
+func TestSendBundleOneBuilderAccepts(t *testing.T) {
+	key := testRelayKey(t)
+	_, rejecting := newStubBuilder(t, key, rejectBundle)
+	_, failing := newStubBuilder(t, key, failBundle)
+	_, accepting := newStubBuilder(t, key, acceptBundle)
+	relay := NewBundleRelay([]string{rejecting, failing, accepting}, "", key)
+	submissions, err := relay.SendBundle(context.Background(), []*types.Transaction{signedTestTx(t, 0)}, []uint64{100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := 0
+	for _, submission := range submissions {
+		if submission.Err != nil {
+			failed++
+		}
+	}
+	if failed != 2 {
+		t.Fatalf("%d failed submissions, want 2", failed)
+	}
+}
+
+func TestSendBundleAllBuildersFail(t *testing.T) {
+	key := testRelayKey(t)
+	_, rejecting := newStubBuilder(t, key, rejectBundle)
+	_, failing := newStubBuilder(t, key, failBundle)
+	relay := NewBundleRelay([]string{rejecting, failing}, "", key)
+	_, err := relay.SendBundle(context.Background(), []*types.Transaction{signedTestTx(t, 0)}, []uint64{100, 101})
+	if !errors.Is(err, ErrBundleRejected) {
+		t.Fatalf("error %v, want %v", err, ErrBundleRejected)
+	}
+	if !strings.Contains(err.Error(), "bundle rejected") || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("error %v does not name the builder failures", err)
+	}
+}
+
+func callBundleResponse(ethSentToCoinbase string) func(method string, params json.RawMessage) (int, string) {
+	return func(method string, params json.RawMessage) (int, string) {
+		if method != "eth_callBundle" {
+			return http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`
+		}
+		return http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"coinbaseDiff":"` + ethSentToCoinbase + `","totalGasUsed":100000,"results":[{"txHash":"0x00000000000000000000000000000000000000000000000000000000000000bb","gasUsed":100000,"gasFees":"100000","coinbaseDiff":"` + ethSentToCoinbase + `","ethSentToCoinbase":"` + ethSentToCoinbase + `"}]}}`
+	}
+}
+
+// The quote promises 1 ETH and the bribe is 10% of the profit, so what the
+// simulation sends to the coinbase tells the profit it made.
+func TestSimulateBundleUsesTheSimulatedProfit(t *testing.T) {
+	tests := []struct {
+		name              string
+		ethSentToCoinbase string
+		err               error
+	}{
+		{"as quoted", "100000000000000000", nil},
+		{"pools moved", "1000000000000000", ErrBelowProfitMinimum},
+	}
+	for _, test := range tests {
+		key := testRelayKey(t)
+		_, url := newStubBuilder(t, key, callBundleResponse(test.ethSentToCoinbase))
+		cfg := config.Default()
+		cfg.Bundles.MinNetProfit = 1e16
+		c := &UniswapClient{ctx: context.Background(), config: cfg, relay: NewBundleRelay([]string{url}, "", key)}
+		tx := ArbitrageTx{Path: "test", ProfitETH: big.NewInt(1e18)}
+		fees := FeeEstimate{BaseFee: big.NewInt(10), MaxPriorityFeePerGas: big.NewInt(1), MaxFeePerGas: big.NewInt(20)}
+		_, bribe, err := c.simulateBundle(tx, signedTestTx(t, 0), nil, 100, fees, 10)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+		if bribe.String() != test.ethSentToCoinbase {
+			t.Errorf("%s: bribe %s, want %s", test.name, bribe, test.ethSentToCoinbase)
+		}
+	}
+}
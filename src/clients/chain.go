@@ -0,0 +1,359 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/config"
+	"path/filepath"
+)
+
+// ChainProfile holds everything that differs between the chains the bot can
+// run on.
+type ChainProfile struct {
+	Name    string
+	ChainID int64
+	// WETH is the wrapped native token, which profits are measured in.
+	WETH       common.Address
+	Dexes      []Dex
+	BaseTokens []BaseToken
+	// BaseFee holds the parameters the next base fee is predicted with.
+	BaseFee BaseFeeParams
+	// Routers are decoded in pending transactions. Chains without a public
+	// mempool have none.
+	Routers  []Router
+	V3Quoter common.Address
+	// PrivateRPC receives the arbitrage transactions. Chains without a public
+	// mempool send them through the regular RPC.
+	PrivateRPC string
+	// Builders receive the arbitrage transactions as bundles with
+	// eth_sendBundle, which replaces PrivateRPC. The bundles are simulated
+	// with eth_callBundle on BundleSimulator first.
+	Builders        []string
+	BundleSimulator string
+	TxFormat        string
+	// CoinMarketCapPlatform is the platform name of the chain's contracts on
+	// CoinMarketCap.
+	CoinMarketCapPlatform string
+	// LegacyStatePath is read when the chain has no state file of its own yet.
+	LegacyStatePath string
+}
+
+var ChainProfiles = []ChainProfile{
+	{
+		Name:    "ethereum",
+		ChainID: 1,
+		WETH:    common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		BaseFee: BaseFeeParams{ChangeDenominator: 8, ElasticityMultiplier: 2},
+		Dexes: []Dex{
+			{
+				Name:            "uniswap-v2",
+				Factory:         common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				InitCodeHash:    uniswapV2InitCodeHash,
+				DeploymentBlock: 10000835,
+			},
+			{
+				Name:            "uniswap-v3",
+				Factory:         uniswapV3Factory,
+				Protocol:        PoolTypeV3,
+				InitCodeHash:    uniswapV3InitCodeHash,
+				DeploymentBlock: 12369621,
+			},
+			{
+				Name:            "sushiswap",
+				Factory:         common.HexToAddress("0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 10794229,
+			},
+			{
+				// Curve pools are enumerated from the main registry, which also
+				// emits PoolAdded for new ones.
+				Name:            "curve",
+				Factory:         common.HexToAddress("0x90E00ACe148ca3b23Ac1bC8C240C2a7Dd9c2d7f5"),
+				Protocol:        PoolTypeCurve,
+				DeploymentBlock: 12195750,
+			},
+			balancerVault(12272146),
+		},
+		BaseTokens: []BaseToken{
+			{Address: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), Symbol: "WETH", MinReserve: tokenAmount(10, 18)},
+			{Address: common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), Symbol: "USDC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"), Symbol: "USDT", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), Symbol: "DAI", MinReserve: tokenAmount(20000, 18)},
+			{Address: common.HexToAddress("0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"), Symbol: "WBTC", MinReserve: tokenAmount(1, 8)},
+		},
+		Routers: []Router{
+			{Address: common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"), V2Dex: "uniswap-v2"},
+			{Address: common.HexToAddress("0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F"), V2Dex: "sushiswap"},
+			{Address: uniswapV3Router, V3Dex: "uniswap-v3"},
+			{Address: uniswapV3Router02, V2Dex: "uniswap-v2", V3Dex: "uniswap-v3"},
+			{Address: common.HexToAddress("0xEf1c6E67703c7BD7107eed8303Fbe6EC2554BF6B"), V2Dex: "uniswap-v2", V3Dex: "uniswap-v3"},
+			{Address: common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a8C9D5C2f6dC6b"), V2Dex: "uniswap-v2", V3Dex: "uniswap-v3"},
+		},
+		V3Quoter:   uniswapV3Quoter,
+		PrivateRPC: "https://rpc.flashbots.net/fast",
+		Builders: []string{
+			"https://relay.flashbots.net",
+			"https://rpc.beaverbuild.org",
+			"https://rpc.titanbuilder.xyz",
+			"https://rsync-builder.xyz",
+		},
+		BundleSimulator:       "https://relay.flashbots.net",
+		TxFormat:              "Url: https://etherscan.io/tx/%s",
+		CoinMarketCapPlatform: "Ethereum",
+		LegacyStatePath:       "data/pools.json",
+	},
+	{
+		Name:    "arbitrum",
+		ChainID: 42161,
+		WETH:    common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+		Dexes: []Dex{
+			{
+				Name:            "uniswap-v2",
+				Factory:         common.HexToAddress("0xf1D7CC64Fb4452F05c498126312eBE29f30Fbcf9"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 150442611,
+			},
+			{
+				Name:            "uniswap-v3",
+				Factory:         uniswapV3Factory,
+				Protocol:        PoolTypeV3,
+				InitCodeHash:    uniswapV3InitCodeHash,
+				DeploymentBlock: 165,
+			},
+			{
+				Name:            "sushiswap",
+				Factory:         common.HexToAddress("0xc35DADB65012eC5796536bD9864eD8773aBc74C4"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 70,
+			},
+			balancerVault(222832),
+		},
+		BaseTokens: []BaseToken{
+			{Address: common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"), Symbol: "WETH", MinReserve: tokenAmount(10, 18)},
+			{Address: common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831"), Symbol: "USDC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9"), Symbol: "USDT", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xDA10009cBd5D07dd0CeCc66161FC93D7c9000da1"), Symbol: "DAI", MinReserve: tokenAmount(20000, 18)},
+			{Address: common.HexToAddress("0x2f2a2543B76A4166549F7aaB2e75Bef0aefC5B0f"), Symbol: "WBTC", MinReserve: tokenAmount(1, 8)},
+		},
+		V3Quoter:              uniswapV3Quoter,
+		TxFormat:              "Url: https://arbiscan.io/tx/%s",
+		CoinMarketCapPlatform: "Arbitrum",
+	},
+	{
+		Name:    "base",
+		ChainID: 8453,
+		WETH:    common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		BaseFee: opStackBaseFee,
+		Dexes: []Dex{
+			{
+				Name:            "uniswap-v2",
+				Factory:         common.HexToAddress("0x8909Dc15e40173Ff4699343b6eB8132c65e18eC6"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 6601915,
+			},
+			{
+				Name:            "uniswap-v3",
+				Factory:         common.HexToAddress("0x33128a8fC17869897dcE68Ed026d694621f6FDfD"),
+				Protocol:        PoolTypeV3,
+				InitCodeHash:    uniswapV3InitCodeHash,
+				DeploymentBlock: 1371680,
+			},
+			balancerVault(1196036),
+		},
+		BaseTokens: []BaseToken{
+			{Address: common.HexToAddress("0x4200000000000000000000000000000000000006"), Symbol: "WETH", MinReserve: tokenAmount(10, 18)},
+			{Address: common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"), Symbol: "USDC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xd9aAEc86B65D86f6A7B5B1b0c42FFA531710b6CA"), Symbol: "USDbC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb"), Symbol: "DAI", MinReserve: tokenAmount(20000, 18)},
+		},
+		V3Quoter:              common.HexToAddress("0x3d4e44Eb1374240CE5F1B871ab261CD16335B76a"),
+		TxFormat:              "Url: https://basescan.org/tx/%s",
+		CoinMarketCapPlatform: "Base",
+	},
+	{
+		Name:    "optimism",
+		ChainID: 10,
+		WETH:    common.HexToAddress("0x4200000000000000000000000000000000000006"),
+		BaseFee: opStackBaseFee,
+		Dexes: []Dex{
+			{
+				Name:            "uniswap-v2",
+				Factory:         common.HexToAddress("0x0c3c1c532F1e39EdF36BE9Fe0bE1410313E074Bf"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 112197986,
+			},
+			{
+				Name:         "uniswap-v3",
+				Factory:      uniswapV3Factory,
+				Protocol:     PoolTypeV3,
+				InitCodeHash: uniswapV3InitCodeHash,
+			},
+			balancerVault(7003431),
+		},
+		BaseTokens: []BaseToken{
+			{Address: common.HexToAddress("0x4200000000000000000000000000000000000006"), Symbol: "WETH", MinReserve: tokenAmount(10, 18)},
+			{Address: common.HexToAddress("0x0b2C639c533813f4Aa9D7837cAf62653d097Ff85"), Symbol: "USDC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0x94b008aA00579c1307B0EF2c499aD98a8ce58e58"), Symbol: "USDT", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xDA10009cBd5D07dd0CeCc66161FC93D7c9000da1"), Symbol: "DAI", MinReserve: tokenAmount(20000, 18)},
+			{Address: common.HexToAddress("0x68f180fcCe6836688e9084f035309E29Bf0A2095"), Symbol: "WBTC", MinReserve: tokenAmount(1, 8)},
+		},
+		V3Quoter:              uniswapV3Quoter,
+		TxFormat:              "Url: https://optimistic.etherscan.io/tx/%s",
+		CoinMarketCapPlatform: "Optimism",
+	},
+	{
+		Name:    "polygon",
+		ChainID: 137,
+		WETH:    common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"),
+		BaseFee: BaseFeeParams{ChangeDenominator: 16, ElasticityMultiplier: 2},
+		Dexes: []Dex{
+			{
+				Name:            "quickswap",
+				Factory:         common.HexToAddress("0x5757371414417b8C6CAad45bAeF941aBc7d3Ab32"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 4931780,
+			},
+			{
+				Name:            "uniswap-v3",
+				Factory:         uniswapV3Factory,
+				Protocol:        PoolTypeV3,
+				InitCodeHash:    uniswapV3InitCodeHash,
+				DeploymentBlock: 22757547,
+			},
+			{
+				Name:            "sushiswap",
+				Factory:         common.HexToAddress("0xc35DADB65012eC5796536bD9864eD8773aBc74C4"),
+				Protocol:        PoolTypeV2,
+				Fee:             amm.V2DefaultFee,
+				DeploymentBlock: 11333218,
+			},
+			balancerVault(15832990),
+		},
+		BaseTokens: []BaseToken{
+			{Address: common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"), Symbol: "WMATIC", MinReserve: tokenAmount(20000, 18)},
+			{Address: common.HexToAddress("0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619"), Symbol: "WETH", MinReserve: tokenAmount(10, 18)},
+			{Address: common.HexToAddress("0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"), Symbol: "USDC", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), Symbol: "USDC.e", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0xc2132D05D31c914a87C6611C10748AEb04B58e8F"), Symbol: "USDT", MinReserve: tokenAmount(20000, 6)},
+			{Address: common.HexToAddress("0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063"), Symbol: "DAI", MinReserve: tokenAmount(20000, 18)},
+			{Address: common.HexToAddress("0x1BFD67037B42Cf73acF2047067bd4F2C47D9BfD6"), Symbol: "WBTC", MinReserve: tokenAmount(1, 8)},
+		},
+		Routers: []Router{
+			{Address: common.HexToAddress("0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff"), V2Dex: "quickswap"},
+			{Address: common.HexToAddress("0x1b02dA8Cb0d097eB8D57A175b88c7D8b47997506"), V2Dex: "sushiswap"},
+			{Address: uniswapV3Router, V3Dex: "uniswap-v3"},
+			{Address: uniswapV3Router02, V3Dex: "uniswap-v3"},
+			{Address: common.HexToAddress("0xec7BE89e9d109e7e3Fec59c222CF297125FEFda2"), V3Dex: "uniswap-v3"},
+		},
+		V3Quoter:              uniswapV3Quoter,
+		TxFormat:              "Url: https://polygonscan.com/tx/%s",
+		CoinMarketCapPlatform: "Polygon",
+	},
+}
+
+var (
+	uniswapV2InitCodeHash = common.HexToHash("0x96e8ac4277198ff8b6f785478aa9a39f403cb768dd02cbee326c3e7da348845f")
+	uniswapV3InitCodeHash = common.HexToHash("0xe34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54")
+	uniswapV3Factory      = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	uniswapV3Quoter       = common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e")
+	uniswapV3Router       = common.HexToAddress("0xE592427A0AEce92De3Edee1F18E0157C05861564")
+	uniswapV3Router02     = common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45")
+)
+
+// balancerVault is the Balancer Vault, which has the same address on every
+// chain. Its pools register with it, and it emits the swaps and balance
+// changes of every pool.
+func balancerVault(deploymentBlock uint64) Dex {
+	return Dex{
+		Name:            "balancer-v2",
+		Factory:         common.HexToAddress("0xBA12222222228d8Ba445958a75a0704d566BF2C8"),
+		Protocol:        PoolTypeBalancer,
+		DeploymentBlock: deploymentBlock,
+	}
+}
+
+func ChainProfileByID(chainId *big.Int) (ChainProfile, error) {
+	for _, profile := range ChainProfiles {
+		if chainId.Cmp(big.NewInt(profile.ChainID)) == 0 {
+			return profile, nil
+		}
+	}
+	return ChainProfile{}, fmt.Errorf("no chain profile for chain id %s", chainId.String())
+}
+
+func (p ChainProfile) StatePath(stateDir string) string {
+	return filepath.Join(stateDir, p.Name, "pools.json")
+}
+
+func (p ChainProfile) TokensPath(stateDir string) string {
+	return filepath.Join(stateDir, p.Name, "tokens.json")
+}
+
+func (p ChainProfile) TokenVerdictsPath(stateDir string) string {
+	return filepath.Join(stateDir, p.Name, "token_verdicts.json")
+}
+
+func (p ChainProfile) DenylistPath(stateDir string) string {
+	return filepath.Join(stateDir, p.Name, "denylist.json")
+}
+
+// withConfig applies the overrides of a configured chain to its profile.
+func (p ChainProfile) withConfig(chain config.ChainConfig) (ChainProfile, error) {
+	if chain.Name != "" && chain.Name != p.Name {
+		return p, fmt.Errorf("%s is configured but the rpc is on %s", chain.Name, p.Name)
+	}
+	if chain.PrivateRPC != "" {
+		p.PrivateRPC = chain.PrivateRPC
+	}
+	if len(chain.Builders) > 0 {
+		p.Builders = chain.Builders
+	}
+	if chain.BundleSimulator != "" {
+		p.BundleSimulator = chain.BundleSimulator
+	}
+	if len(chain.Dexes) > 0 {
+		dexes := []Dex{}
+		for _, dex := range chain.Dexes {
+			switch dex.Protocol {
+			case PoolTypeV2, PoolTypeV3, PoolTypeCurve, PoolTypeBalancer:
+			default:
+				return p, fmt.Errorf("unknown protocol %q for %s", dex.Protocol, dex.Name)
+			}
+			var fee *big.Int
+			if dex.Fee != 0 {
+				fee = big.NewInt(dex.Fee)
+			}
+			dexes = append(dexes, Dex{
+				Name:            dex.Name,
+				Factory:         common.HexToAddress(dex.Factory),
+				Protocol:        dex.Protocol,
+				Fee:             fee,
+				InitCodeHash:    common.HexToHash(dex.InitCodeHash),
+				DeploymentBlock: dex.DeploymentBlock,
+			})
+		}
+		p.Dexes = dexes
+	}
+	if len(chain.BaseTokens) > 0 {
+		baseTokens := []BaseToken{}
+		for _, token := range chain.BaseTokens {
+			baseTokens = append(baseTokens, BaseToken{
+				Address:    common.HexToAddress(token.Address),
+				Symbol:     token.Symbol,
+				MinReserve: tokenAmount(token.MinReserve, token.Decimals),
+			})
+		}
+		p.BaseTokens = baseTokens
+	}
+	return p, nil
+}
@@ -3,18 +3,18 @@ package clients
 import (
 	"context"
 	"crypto/ecdsa"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rs/zerolog/log"
 	"math/big"
+	"mev_bot/amm"
+	"mev_bot/config"
 	"mev_bot/contracts"
 	"net/http"
 	"net/url"
-	"os"
-	"strings"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -24,21 +24,16 @@ import (
 )
 
 var (
-	PoolFactories = map[string]common.Address{
-		"v2": common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"),
-		"v3": common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"),
-	}
-	InitialDeploymentBlock = 10000835
-	TxFormat               = "Url: https://etherscan.io/tx/%s"
-	WETHAddress            = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
-	AddressZero            = common.Address{}
-	UniswapV3Quoter        = common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e")
-	BribePercent           = big.NewInt(5)
+	AddressZero       = common.Address{}
+	ErrGasCostTooHigh = errors.New("gas cost is higher")
+	ErrNoBuilders     = errors.New("backruns need builders to send bundles to")
 )
 
 type PoolSummaryFile struct {
-	Pools         map[common.Address]Pool `json:"pools"`
-	LastSeenBlock uint64                  `json:"lastSeenBlock"`
+	Version        int                       `json:"version"`
+	Pools          map[common.Address]Pool   `json:"pools"`
+	LastSeenBlock  uint64                    `json:"lastSeenBlock"`
+	FactoryCursors map[common.Address]uint64 `json:"factoryCursors"`
 }
 
 type Pool struct {
@@ -49,7 +44,12 @@ type Pool struct {
 	Reserve1 *big.Int
 	Fee      *big.Int
 	Type     string
+	Dex      string
 	Enabled  bool
+	V3       *amm.V3State         `json:",omitempty"`
+	Coins    []common.Address     `json:",omitempty"`
+	Curve    *amm.StableSwapState `json:",omitempty"`
+	Balancer *amm.BalancerState   `json:",omitempty"`
 }
 
 type UniswapClient struct {
@@ -58,54 +58,92 @@ type UniswapClient struct {
 	wsClient          *ethclient.Client
 	historyClient     *ethclient.Client
 	arbitrageContract *contracts.UniswapBotV2
-	botToken          string
-	chatId            string
+	config            config.Config
 	privKey           *ecdsa.PrivateKey
 	chainId           *big.Int
+	profile           ChainProfile
 	mevAddress        common.Address
 	address           common.Address
 	ctx               context.Context
 	prices            map[string]string
 	Pools             map[common.Address]Pool
 	FactoryAddresses  []common.Address
+	FactoryCursors    map[common.Address]uint64
 	LastSeenBlock     uint64
+	head              *types.Header
 	statePath         string
-	factoryAddressMap map[common.Address]bool
+	factories         map[common.Address]Dex
 	tokensPath        string
 	tokenMap          map[common.Address]bool
+	tokenVerdicts     map[common.Address]TokenVerdict
+	pendingTokens     map[common.Address]bool
+	verdictsPath      string
+	denylist          Denylist
+	denylistPath      string
+	balanceSlots      map[common.Address]balanceSlot
+	store             StateStore
+	dirtyPools        map[common.Address]bool
+	snapshotMu        sync.Mutex
+	lastSnapshot      time.Time
+	journal           []*blockJournal
+	currentBlock      *blockJournal
+	index             *PoolIndex
+	relay             *BundleRelay
+	bribe             BribeStrategy
+	sentBundles       []sentBundle
 }
 
-func NewUniswapClient(rpcURL string, wsURL string, historyURL string, botToken string, chatId string, mevAddress string, privKey string, ctx context.Context) (*UniswapClient, error) {
-	client, err := ethclient.DialContext(ctx, rpcURL)
+func NewUniswapClient(cfg config.Config, chain config.ChainConfig, store StateStore, ctx context.Context) (*UniswapClient, error) {
+	client, err := ethclient.DialContext(ctx, chain.RPCURL)
 	if err != nil {
 		return nil, err
 	}
-	wsClient, err := ethclient.DialContext(ctx, wsURL)
+	wsClient, err := ethclient.DialContext(ctx, chain.WSURL)
 	if err != nil {
 		return nil, err
 	}
-	historyClient, err := ethclient.DialContext(ctx, historyURL)
+	historyClient, err := ethclient.DialContext(ctx, chain.HistoryRPCURL)
 	if err != nil {
 		return nil, err
 	}
-	arbitrageClient, err := ethclient.DialContext(ctx, "https://rpc.flashbots.net/fast")
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := ChainProfileByID(chainId)
+	if err != nil {
+		return nil, err
+	}
+	profile, err = profile.withConfig(chain)
 	if err != nil {
 		return nil, err
 	}
-	signingKey, err := crypto.HexToECDSA(privKey)
+	arbitrageClient := client
+	if profile.PrivateRPC != "" && len(profile.Builders) == 0 {
+		arbitrageClient, err = ethclient.DialContext(ctx, profile.PrivateRPC)
+		if err != nil {
+			return nil, err
+		}
+	}
+	signingKey, err := crypto.HexToECDSA(cfg.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
+	var relay *BundleRelay
+	if len(profile.Builders) > 0 {
+		bundleKey, err := bundleSigningKey(cfg.Bundles.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+		relay = NewBundleRelay(profile.Builders, profile.BundleSimulator, bundleKey)
+	}
 	publicKey := signingKey.Public()
 	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
 	if !ok {
 		return nil, errors.New("public key error")
 	}
 	address := crypto.PubkeyToAddress(*publicKeyECDSA)
-	chainId, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, err
-	}
+	mevAddress := chain.MEVAddress
 	var botContract *contracts.UniswapBotV2
 	var botAddress common.Address
 	if mevAddress == "" {
@@ -139,39 +177,41 @@ func NewUniswapClient(rpcURL string, wsURL string, historyURL string, botToken s
 	if err != nil {
 		return nil, err
 	}
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-	filePath := wd + "/data/pools.json"
-	tokensPath := wd + "/data/tokens.json"
+	filePath := profile.StatePath(cfg.StateDir)
+	tokensPath := profile.TokensPath(cfg.StateDir)
 	return &UniswapClient{
-		client:        client,
-		wsClient:      wsClient,
-		historyClient: historyClient,
-		botToken:      botToken,
-		chatId:        chatId,
-		BotContract:   botContract,
-		privKey:       signingKey,
-		chainId:       chainId,
-		ctx:           ctx,
-		address:       address,
-		mevAddress:    botAddress,
-		prices:        make(map[string]string),
-		Pools:         make(map[common.Address]Pool),
-		LastSeenBlock: uint64(InitialDeploymentBlock),
-		FactoryAddresses: []common.Address{
-			PoolFactories["v2"],
-			PoolFactories["v3"],
-		},
-		factoryAddressMap: map[common.Address]bool{
-			PoolFactories["v2"]: true,
-			PoolFactories["v3"]: true,
-		},
+		client:            client,
+		wsClient:          wsClient,
+		historyClient:     historyClient,
+		config:            cfg,
+		BotContract:       botContract,
+		privKey:           signingKey,
+		chainId:           chainId,
+		profile:           profile,
+		ctx:               ctx,
+		address:           address,
+		mevAddress:        botAddress,
+		prices:            make(map[string]string),
+		Pools:             make(map[common.Address]Pool),
+		index:             NewPoolIndex(nil, cfg.Strategy.MaxPathDepth),
+		LastSeenBlock:     initialDeploymentBlock(profile.Dexes),
+		FactoryAddresses:  factoryAddresses(profile.Dexes),
+		FactoryCursors:    make(map[common.Address]uint64),
+		factories:         dexByFactory(profile.Dexes),
 		statePath:         filePath,
 		tokensPath:        tokensPath,
 		tokenMap:          make(map[common.Address]bool),
+		tokenVerdicts:     make(map[common.Address]TokenVerdict),
+		pendingTokens:     make(map[common.Address]bool),
+		verdictsPath:      profile.TokenVerdictsPath(cfg.StateDir),
+		denylist:          NewDenylist(),
+		denylistPath:      profile.DenylistPath(cfg.StateDir),
+		balanceSlots:      make(map[common.Address]balanceSlot),
+		store:             store,
+		dirtyPools:        make(map[common.Address]bool),
 		arbitrageContract: arbitrageContract,
+		relay:             relay,
+		bribe:             NewBribeStrategy(cfg.Strategy),
 	}, nil
 }
 
@@ -180,58 +220,96 @@ func (c *UniswapClient) InitializePools() ([]Pool, error) {
 	if err != nil {
 		return nil, err
 	}
-	//err = c.ReadTokens()
-	//if err != nil {
-	//	return nil, err
-	//}
+	err = c.loadBribeHistory()
+	if err != nil {
+		return nil, err
+	}
+	if c.config.Tokens.Policy != config.TokenPolicyOff {
+		err = c.ReadTokens()
+		if err != nil {
+			return nil, err
+		}
+	}
 	currentBlockNumber, err := c.client.BlockNumber(c.ctx)
 	if err != nil {
 		return nil, err
 	}
 	ch := make(chan []Pool)
 	log.Info().Msg("Getting pools")
-	for name, address := range PoolFactories {
-		if name == "v3" {
-			contract, err := contracts.NewIEvents(address, c.historyClient)
+	tickStart := currentBlockNumber
+	knownPools := make(map[common.Address]bool)
+	for address := range c.Pools {
+		knownPools[address] = true
+	}
+	for _, dex := range c.factories {
+		if dex.Protocol == PoolTypeCurve {
+			registry, err := contracts.NewICurveRegistry(dex.Factory, c.historyClient)
 			if err != nil {
 				return nil, err
 			}
-			go GetV3Pools(ch, contract, currentBlockNumber, c.LastSeenBlock)
-		} else {
-			contract, err := contracts.NewIEvents(address, c.historyClient)
+			go GetCurvePools(ch, registry, dex, knownPools)
+			continue
+		}
+		if dex.Protocol == PoolTypeBalancer {
+			vault, err := contracts.NewIBalancerVault(dex.Factory, c.historyClient)
 			if err != nil {
 				return nil, err
 			}
-			go GetV2Pools(ch, contract, currentBlockNumber, c.LastSeenBlock)
+			go GetBalancerPools(ch, vault, dex, currentBlockNumber, c.syncStart(dex), c.config.Batches.LogBlocks)
+			continue
+		}
+		contract, err := contracts.NewIEvents(dex.Factory, c.historyClient)
+		if err != nil {
+			return nil, err
+		}
+		start := c.syncStart(dex)
+		if dex.Protocol == PoolTypeV3 {
+			if start < tickStart {
+				tickStart = start
+			}
+			go GetV3Pools(ch, contract, dex, currentBlockNumber, start, c.config.Batches.LogBlocks)
+		} else {
+			go GetV2Pools(ch, contract, dex, currentBlockNumber, start, c.config.Batches.LogBlocks)
 		}
 	}
+	tickCh := make(chan []types.Log)
+	go GetV3Ticks(tickCh, c.historyClient, currentBlockNumber, tickStart, c.config.Batches.LogBlocks)
 	newPools := []Pool{}
-	for i := 0; i < len(PoolFactories); i++ {
+	for i := 0; i < len(c.factories); i++ {
 		collectedPools := <-ch
 		for _, pool := range collectedPools {
-			//_, ok1 := c.tokenMap[pool.Token1]
-			//_, ok2 := c.tokenMap[pool.Token0]
-			//if ok1 && ok2 {
-			//	newPools = append(newPools, pool)
-			//}
+			_, ok := c.Pools[pool.Address]
+			if ok {
+				continue
+			}
+			if !c.tokenPolicyAllows(pool) {
+				continue
+			}
 			newPools = append(newPools, pool)
 		}
 	}
-	c.LastSeenBlock = currentBlockNumber
+	err = c.applyV3Ticks(<-tickCh, newPools)
+	if err != nil {
+		return nil, err
+	}
+	c.setLastSeenBlock(currentBlockNumber)
 	log.Info().Msg("Finished getting pools")
 	return newPools, nil
 }
 
 func (c *UniswapClient) SetReserves(newPools []Pool) error {
-	wethReserveLimit, ok := new(big.Int).SetString("10000000000000000000", 10)
-	if !ok {
-		return errors.New("can not set weth reserve limit")
-	}
 	log.Info().Msg("Starting getting reserves")
 	zero := big.NewInt(0)
+	curvePools, newPools := splitPools(newPools, PoolTypeCurve)
+	c.setCurveReserves(curvePools)
+	balancerPools, newPools := splitPools(newPools, PoolTypeBalancer)
+	c.setBalancerReserves(balancerPools)
 	oldReserveParams := []contracts.UniswapBotV2ReserveParams{}
 	newReserveParams := []contracts.UniswapBotV2ReserveParams{}
 	for _, pool := range c.Pools {
+		if pool.Type != PoolTypeV2 && pool.Type != PoolTypeV3 {
+			continue
+		}
 		oldReserveParams = append(oldReserveParams, contracts.UniswapBotV2ReserveParams{
 			Token0: pool.Token0,
 			Token1: pool.Token1,
@@ -249,7 +327,7 @@ func (c *UniswapClient) SetReserves(newPools []Pool) error {
 	opts := bind.CallOpts{
 		From: c.address,
 	}
-	batchSize := 2000
+	batchSize := c.config.Batches.Reserves
 	for i := 0; i < len(oldReserveParams); i += batchSize {
 		var oldReservesRaw []interface{}
 		var paramSlice []contracts.UniswapBotV2ReserveParams
@@ -271,13 +349,10 @@ func (c *UniswapClient) SetReserves(newPools []Pool) error {
 			if pool.Reserve0.Cmp(zero) == 0 || pool.Reserve1.Cmp(zero) == 0 {
 				pool.Enabled = false
 			}
-			if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-				pool.Enabled = false
-			}
-			if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
+			if !c.hasBaseLiquidity(pool) {
 				pool.Enabled = false
 			}
-			c.Pools[oldParam.Pool] = pool
+			c.setPool(pool)
 		}
 	}
 
@@ -294,84 +369,151 @@ func (c *UniswapClient) SetReserves(newPools []Pool) error {
 		if pool.Reserve0.Cmp(zero) == 0 || pool.Reserve1.Cmp(zero) == 0 {
 			pool.Enabled = false
 		}
-		if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
+		if !c.hasBaseLiquidity(pool) {
 			pool.Enabled = false
 		}
-		if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-			pool.Enabled = false
-		}
-		c.Pools[pool.Address] = pool
+		c.setPool(pool)
+	}
+	err := c.seedV3Slots(c.LastSeenBlock)
+	if err != nil {
+		return err
 	}
-	err := c.SaveState()
+	c.checkPendingTokens()
+	err = c.SaveState()
 	log.Info().Msg("Saving reserves")
 	return err
 }
 
 func (c *UniswapClient) ReadState() error {
-	file, err := os.ReadFile(c.statePath)
+	err := c.ReadTokenVerdicts()
+	if err != nil {
+		return err
+	}
+	err = c.ReadDenylist()
+	if err != nil {
+		return err
+	}
 	var PoolSummary PoolSummaryFile
-	if err == nil {
-		err = json.Unmarshal(file, &PoolSummary)
+	found := false
+	if c.store != nil {
+		PoolSummary, found, err = c.store.LoadState(c.chainId.Uint64())
+		if err != nil {
+			return err
+		}
+	}
+	// A store that is still empty is filled from the JSON state by the first
+	// SaveState.
+	if !found {
+		PoolSummary, found, err = c.readStateFile()
+		if err != nil && !found {
+			return err
+		}
+	}
+	if found {
+		err = c.migrateState(&PoolSummary)
 		if err != nil {
 			return err
 		}
 		c.LastSeenBlock = PoolSummary.LastSeenBlock
 		c.Pools = PoolSummary.Pools
+		c.FactoryCursors = PoolSummary.FactoryCursors
+		c.index = NewPoolIndex(c.Pools, c.config.Strategy.MaxPathDepth)
 	}
-	log.Info().Uint64("lastSeenBlock", c.LastSeenBlock).Int("totalPools", len(c.Pools)).Msg("state read summary")
+	log.Info().Str("chain", c.profile.Name).Uint64("lastSeenBlock", c.LastSeenBlock).Int("totalPools", len(c.Pools)).Msg("state read summary")
 	return nil
 }
 
-func (c *UniswapClient) ReadTokens() error {
-	file, err := os.ReadFile(c.tokensPath)
-	var tokens []Token
-	if err == nil {
-		err = json.Unmarshal(file, &tokens)
+func (c *UniswapClient) SaveState() error {
+	if c.store != nil {
+		pools := []Pool{}
+		for _, pool := range c.Pools {
+			pools = append(pools, pool)
+		}
+		err := c.store.SaveBlock(c.chainId.Uint64(), pools, nil, c.LastSeenBlock, c.FactoryCursors)
 		if err != nil {
 			return err
 		}
-	} else {
-		err = RetrieveTopTokens()
+		c.dirtyPools = make(map[common.Address]bool)
+		err = c.SaveTokenVerdicts()
 		if err != nil {
 			return err
 		}
-		return c.ReadTokens()
+		return c.SaveDenylist()
 	}
-	for _, token := range tokens {
-		c.tokenMap[common.HexToAddress(token.ContractAddress)] = true
+	file, err := encodeState(c.stateSummary())
+	if err != nil {
+		return err
 	}
-	return nil
-}
-
-func (c *UniswapClient) SaveState() error {
-	var PoolSummary PoolSummaryFile
-	PoolSummary.Pools = c.Pools
-	PoolSummary.LastSeenBlock = c.LastSeenBlock
-	file, err := json.MarshalIndent(PoolSummary, "", " ")
+	c.snapshotMu.Lock()
+	err = writeFileAtomic(c.statePath, file)
+	c.snapshotMu.Unlock()
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(c.statePath, file, 0644)
+	c.lastSnapshot = time.Now()
 	log.Info().Msg("written")
-	return err
+	err = c.SaveTokenVerdicts()
+	if err != nil {
+		return err
+	}
+	return c.SaveDenylist()
 }
 
 func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]Pool, error) {
-	wethReserveLimit, ok := new(big.Int).SetString("10000000000000000000", 10)
-	if !ok {
-		return nil, errors.New("can not set weth reserve limit")
-	}
 	zero := big.NewInt(0)
 	contract, err := contracts.NewIEvents(c.address, c.client)
 	if err != nil {
 		return nil, err
 	}
-	logMap := make(map[common.Address]uint)
+	curveEvents, err := contracts.NewICurveRegistry(c.address, c.client)
+	if err != nil {
+		return nil, err
+	}
+	vaultEvents, err := contracts.NewIBalancerVault(c.address, c.client)
+	if err != nil {
+		return nil, err
+	}
+	logMap := make(map[common.Address]types.Log)
 	processedPools := make(map[common.Address]Pool)
+	curveUpdates := make(map[common.Address]bool)
+	balancerUpdates := make(map[common.Address]bool)
 	log.Info().Str("blockHash", blockHash.String()).Msg("resolving block events")
 	for _, blockLog := range logs {
-		_, ok := c.factoryAddressMap[blockLog.Address]
-		if ok {
+		if blockLog.Removed {
+			continue
+		}
+		c.journalLog(blockLog)
+		dex, ok := c.factories[blockLog.Address]
+		if ok && dex.Protocol == PoolTypeCurve {
+			poolAdded, err := curveEvents.ParsePoolAdded(blockLog)
+			if err != nil {
+				continue
+			}
+			_, ok := c.Pools[poolAdded.Pool]
+			if ok {
+				continue
+			}
+			registry, err := contracts.NewICurveRegistry(dex.Factory, c.client)
+			if err != nil {
+				return nil, err
+			}
+			pool, err := getCurvePool(registry, dex, poolAdded.Pool)
+			if err != nil {
+				continue
+			}
+			if !c.hasBaseLiquidity(pool) || !c.tokenPolicyAllows(pool) {
+				pool.Enabled = false
+			}
+			if pool.Enabled && !c.verifyCurvePool(pool) {
+				pool.Enabled = false
+			}
+			c.setPool(pool)
+		} else if ok && dex.Protocol == PoolTypeBalancer {
+			pool, ok := c.resolveBalancerLog(vaultEvents, dex, blockLog)
+			if ok {
+				processedPools[pool.Address] = pool
+			}
+		} else if ok {
 			poolCreated, err := contract.ParsePoolCreated(blockLog)
 			if err == nil {
 				_, ok := c.Pools[poolCreated.Pool]
@@ -380,8 +522,14 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 					Token0:  poolCreated.Token0,
 					Token1:  poolCreated.Token1,
 					Fee:     poolCreated.Fee,
-					Type:    "v3",
+					Type:    PoolTypeV3,
+					Dex:     dex.Name,
 					Enabled: true,
+					V3:      amm.NewV3State(int(poolCreated.TickSpacing.Int64())),
+				}
+				pool.V3.Synced = true
+				if !dex.isDexPool(pool) {
+					continue
 				}
 				reserves, err := c.BotContract.GetReserves(nil, contracts.UniswapBotV2ReserveParams{
 					Token0: pool.Token0,
@@ -397,13 +545,18 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 					if pool.Reserve0.Cmp(zero) == 0 || pool.Reserve1.Cmp(zero) == 0 {
 						pool.Enabled = false
 					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
+					if !c.hasBaseLiquidity(pool) || !c.tokenPolicyAllows(pool) {
 						pool.Enabled = false
 					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
+					// trackedLogs keeps the Mint logs of the pool that
+					// follow, so it starts out without liquidity at the
+					// price it has at the end of the block.
+					slots, err := c.readV3Slots([]common.Address{pool.Address}, blockLog.BlockNumber)
+					slot, found := slots[pool.Address]
+					if err == nil && found {
+						pool.V3.Seed(slot.SqrtPriceX96, big.NewInt(0), slot.Tick)
 					}
-					c.Pools[poolCreated.Pool] = pool
+					c.setPool(pool)
 				}
 			}
 			pairCreated, err := contract.ParsePairCreated(blockLog)
@@ -413,9 +566,14 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 					Address: pairCreated.Pair,
 					Token0:  pairCreated.Token0,
 					Token1:  pairCreated.Token1,
-					Type:    "v2",
+					Fee:     dex.fee(),
+					Type:    PoolTypeV2,
+					Dex:     dex.Name,
 					Enabled: true,
 				}
+				if !dex.isDexPool(pool) {
+					continue
+				}
 				reserves, err := c.BotContract.GetReserves(nil, contracts.UniswapBotV2ReserveParams{
 					Token0: pool.Token0,
 					Token1: pool.Token1,
@@ -430,113 +588,47 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 					if pool.Reserve0.Cmp(zero) == 0 || pool.Reserve1.Cmp(zero) == 0 {
 						pool.Enabled = false
 					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
+					if !c.hasBaseLiquidity(pool) || !c.tokenPolicyAllows(pool) {
 						pool.Enabled = false
 					}
-					c.Pools[pairCreated.Pair] = pool
+					c.setPool(pool)
 				}
 			}
 		} else {
-			oldIndex, ok := logMap[blockLog.Address]
-			if !ok {
-				logMap[blockLog.Address] = blockLog.Index
-			} else {
-				if blockLog.Index > oldIndex {
-					logMap[blockLog.Address] = blockLog.Index
-				} else {
-					continue
+			pool, ok := c.Pools[blockLog.Address]
+			if ok && pool.Type == PoolTypeCurve {
+				if isCurvePoolEvent(blockLog) {
+					curveUpdates[blockLog.Address] = true
 				}
+				continue
 			}
-			swapEvent, err := contract.ParseSwap(blockLog)
-			if err == nil {
-				pool, ok := c.Pools[blockLog.Address]
-				if ok {
-					pool.Reserve0 = new(big.Int).Add(pool.Reserve0, swapEvent.Amount0)
-					pool.Reserve1 = new(big.Int).Add(pool.Reserve1, swapEvent.Amount1)
-					if pool.Reserve0.Cmp(zero) != 1 || pool.Reserve1.Cmp(zero) != 1 {
-						pool.Enabled = false
-					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					c.Pools[blockLog.Address] = pool
-					_, ok := processedPools[blockLog.Address]
-					if !ok {
-						processedPools[blockLog.Address] = pool
-					}
+			if ok && pool.Type == PoolTypeBalancer {
+				if isBalancerPoolEvent(blockLog) {
+					balancerUpdates[blockLog.Address] = true
 				}
+				continue
 			}
-			mintEvent, err := contract.ParseMint(blockLog)
-			if err == nil {
-				pool, ok := c.Pools[blockLog.Address]
-				if ok {
-					pool.Reserve0 = new(big.Int).Add(pool.Reserve0, mintEvent.Amount0)
-					pool.Reserve1 = new(big.Int).Add(pool.Reserve1, mintEvent.Amount1)
-					if pool.Reserve0.Cmp(zero) != 1 || pool.Reserve1.Cmp(zero) != 1 {
-						pool.Enabled = false
-					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					c.Pools[blockLog.Address] = pool
-					_, ok := processedPools[blockLog.Address]
-					if !ok {
-						processedPools[blockLog.Address] = pool
-					}
-				}
-			}
-			burnEvent, err := contract.ParseBurn(blockLog)
-			if err == nil {
-				pool, ok := c.Pools[blockLog.Address]
-				if ok {
-					pool.Reserve0 = new(big.Int).Sub(pool.Reserve0, burnEvent.Amount0)
-					pool.Reserve1 = new(big.Int).Sub(pool.Reserve1, burnEvent.Amount1)
-					if pool.Reserve0.Cmp(zero) != 1 || pool.Reserve1.Cmp(zero) != 1 {
-						pool.Enabled = false
-					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					c.Pools[blockLog.Address] = pool
-					_, ok := processedPools[blockLog.Address]
-					if !ok {
-						processedPools[blockLog.Address] = pool
-					}
+			// The logs of a range span several blocks, so the order is by
+			// block first.
+			oldLog, ok := logMap[blockLog.Address]
+			if !ok {
+				logMap[blockLog.Address] = blockLog
+			} else {
+				if blockLog.BlockNumber > oldLog.BlockNumber || (blockLog.BlockNumber == oldLog.BlockNumber && blockLog.Index > oldLog.Index) {
+					logMap[blockLog.Address] = blockLog
+				} else {
+					continue
 				}
 			}
-			collectProtocolEvent, err := contract.ParseCollectProtocol(blockLog)
-			if err == nil {
-				pool, ok := c.Pools[blockLog.Address]
-				if ok {
-					pool.Reserve0 = new(big.Int).Sub(pool.Reserve0, collectProtocolEvent.Amount0)
-					pool.Reserve1 = new(big.Int).Sub(pool.Reserve1, collectProtocolEvent.Amount1)
-					if pool.Reserve0.Cmp(zero) != 1 || pool.Reserve1.Cmp(zero) != 1 {
-						pool.Enabled = false
-					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					c.Pools[blockLog.Address] = pool
-					_, ok := processedPools[blockLog.Address]
-					if !ok {
-						processedPools[blockLog.Address] = pool
-					}
+			// v3 pools are priced from slot0 and their initialized ticks,
+			// which the Swap, Mint and Burn events keep up to date.
+			pool, ok = c.Pools[blockLog.Address]
+			if ok && pool.Type == PoolTypeV3 && c.applyV3Log(contract, pool, blockLog) {
+				_, ok := processedPools[blockLog.Address]
+				if !ok {
+					processedPools[blockLog.Address] = c.Pools[blockLog.Address]
 				}
+				continue
 			}
 			syncEvent, err := contract.ParseSync(blockLog)
 			if err == nil {
@@ -547,13 +639,10 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 					if pool.Reserve0.Cmp(zero) != 1 || pool.Reserve1.Cmp(zero) != 1 {
 						pool.Enabled = false
 					}
-					if pool.Token0.String() == WETHAddress.String() && pool.Reserve0.Cmp(wethReserveLimit) == -1 {
-						pool.Enabled = false
-					}
-					if pool.Token1.String() == WETHAddress.String() && pool.Reserve1.Cmp(wethReserveLimit) == -1 {
+					if !c.hasBaseLiquidity(pool) {
 						pool.Enabled = false
 					}
-					c.Pools[blockLog.Address] = pool
+					c.setPool(pool)
 					_, ok := processedPools[blockLog.Address]
 					if !ok {
 						processedPools[blockLog.Address] = pool
@@ -562,327 +651,161 @@ func (c *UniswapClient) ResolveLogs(logs []types.Log, blockHash common.Hash) ([]
 			}
 		}
 	}
+	for address := range curveUpdates {
+		pool, err := c.refreshCurvePool(c.Pools[address])
+		if err != nil {
+			log.Info().Err(err).Str("pool", address.String()).Msg("can not refresh curve pool")
+			continue
+		}
+		c.setPool(pool)
+		processedPools[address] = pool
+	}
+	for address := range balancerUpdates {
+		pool, err := c.loadBalancerPool(c.Pools[address])
+		if err != nil {
+			log.Info().Err(err).Str("pool", address.String()).Msg("can not refresh balancer pool")
+			continue
+		}
+		c.setPool(pool)
+		processedPools[address] = pool
+	}
 	processedPoolsArr := []Pool{}
 	for _, pool := range processedPools {
+		if c.poolDenied(pool) {
+			continue
+		}
 		processedPoolsArr = append(processedPoolsArr, pool)
 	}
 	return processedPoolsArr, nil
 }
 
-func (c *UniswapClient) findDoublePathFirstEffected(effectedPools []Pool, ch chan []string, wethPools []Pool) {
-	paths := []string{}
-	now := time.Now()
-	addressCh := make(chan []string)
-	for _, wethPool := range wethPools {
-		go func(pool1 Pool, res chan []string) {
-			addressPaths := []string{}
-			var token1 common.Address
-			if pool1.Token0 == WETHAddress {
-				token1 = pool1.Token1
-			}
-			if pool1.Token1 == WETHAddress {
-				token1 = pool1.Token0
-			}
-			for _, pool2 := range effectedPools {
-				if pool2.Address == pool1.Address {
-					continue
-				}
-				if (pool2.Token1 == WETHAddress && pool2.Token0 == token1) || (pool2.Token0 == WETHAddress && pool2.Token1 == token1) {
-					addressPaths = append(addressPaths, strings.Join([]string{pool1.Address.String(), pool2.Address.String()}, "->"))
-				}
-			}
-			res <- addressPaths
-		}(wethPool, addressCh)
-	}
-	for i := 0; i < len(wethPools); i++ {
-		foundPaths := <-addressCh
-		paths = append(paths, foundPaths...)
-	}
-	log.Info().Float64("findDoublePathFirstEffected", time.Since(now).Seconds()).Msg("duration")
-	ch <- paths
-}
-
-func (c *UniswapClient) findDoublePathLastEffected(effectedPools []Pool, ch chan []string, wethPools []Pool) {
-	paths := []string{}
-	addressCh := make(chan []string)
-	now := time.Now()
-	for _, effectedPool := range effectedPools {
-		go func(pool1 Pool, res chan []string) {
-			addressPaths := []string{}
-			var token1 *common.Address
-			if pool1.Token0 == WETHAddress {
-				token1 = &pool1.Token1
-			}
-			if pool1.Token1 == WETHAddress {
-				token1 = &pool1.Token0
-			}
-			if token1 == nil {
-				res <- addressPaths
-				return
-			}
-			for _, pool2 := range wethPools {
-				if pool2.Address == pool1.Address {
-					continue
-				}
-				if (pool2.Token1 == WETHAddress && pool2.Token0 == *token1) || (pool2.Token0 == WETHAddress && pool2.Token1 == *token1) {
-					addressPaths = append(addressPaths, strings.Join([]string{pool1.Address.String(), pool2.Address.String()}, "->"))
-				}
-			}
-			res <- addressPaths
-		}(effectedPool, addressCh)
-	}
-	for i := 0; i < len(effectedPools); i++ {
-		foundPaths := <-addressCh
-		paths = append(paths, foundPaths...)
+func splitPools(pools []Pool, poolType string) ([]Pool, []Pool) {
+	matching := []Pool{}
+	others := []Pool{}
+	for _, pool := range pools {
+		if pool.Type == poolType {
+			matching = append(matching, pool)
+		} else {
+			others = append(others, pool)
+		}
 	}
-	log.Info().Float64("findDoublePathLastEffected", time.Since(now).Seconds()).Msg("duration")
-	ch <- paths
+	return matching, others
 }
 
-func (c *UniswapClient) findTriangularPathMidEffected(effectedPools []Pool, ch chan []string, wethPools []Pool) {
-	paths := []string{}
-	addressCh := make(chan []string)
-	now := time.Now()
-	for _, wethPool := range wethPools {
-		go func(pool1 Pool, res chan []string) {
-			addressPaths := []string{}
-			var token1 common.Address
-			if pool1.Token0 == WETHAddress {
-				token1 = pool1.Token1
-			}
-			if pool1.Token1 == WETHAddress {
-				token1 = pool1.Token0
-			}
-			for _, pool2 := range effectedPools {
-				if pool2.Address == pool1.Address {
-					continue
-				}
-				var token2 *common.Address
-				if pool2.Token0 == token1 {
-					token2 = &pool2.Token1
-				}
-				if pool2.Token1 == token1 {
-					token2 = &pool2.Token0
-				}
-				if token2 == nil {
-					continue
-				}
-				for _, pool3 := range wethPools {
-					if pool3.Address == pool2.Address || pool3.Address == pool1.Address {
-						continue
-					}
-					if (pool3.Token1 == WETHAddress && pool3.Token0 == *token2) || (pool3.Token0 == WETHAddress && pool3.Token1 == *token2) {
-						addressPaths = append(addressPaths, strings.Join([]string{pool1.Address.String(), pool2.Address.String(), pool3.Address.String()}, "->"))
-					}
-				}
-			}
-			addressCh <- addressPaths
-		}(wethPool, addressCh)
-	}
-	for i := 0; i < len(wethPools); i++ {
-		foundPaths := <-addressCh
-		paths = append(paths, foundPaths...)
-	}
-	log.Info().Float64("findTriangularPathMidEffected", time.Since(now).Seconds()).Msg("duration")
-	ch <- paths
+func (c *UniswapClient) setPool(pool Pool) {
+	c.journalPool(pool.Address)
+	c.Pools[pool.Address] = pool
+	c.dirtyPools[pool.Address] = true
+	c.index.Update(pool)
+	c.queueTokenChecks(pool)
 }
 
-func (c *UniswapClient) findTriangularPathFirstEffected(effectedPools []Pool, ch chan []string, wethPools []Pool, allPools []Pool) {
-	paths := []string{}
+// FindPaths returns the cycles through the pools that look profitable on the
+// view, searching for at most budget.
+func (c *UniswapClient) FindPaths(effectedPools []Pool, view *poolView, budget time.Duration) []Path {
 	now := time.Now()
-	addressCh := make(chan []string)
-	for _, effectedPool := range effectedPools {
-		go func(pool1 Pool, res chan []string) {
-			addressPaths := []string{}
-			var token1 *common.Address
-			if pool1.Token0 == WETHAddress {
-				token1 = &pool1.Token1
-			}
-			if pool1.Token1 == WETHAddress {
-				token1 = &pool1.Token0
-			}
-			if token1 == nil {
-				res <- addressPaths
-				return
-			}
-			for _, pool2 := range allPools {
-				if pool2.Address == pool1.Address {
-					continue
-				}
-				var token2 *common.Address
-				if pool2.Token0 == *token1 {
-					token2 = &pool2.Token1
-				}
-				if pool2.Token1 == *token1 {
-					token2 = &pool2.Token0
-				}
-				if token2 == nil {
-					continue
-				}
-				for _, pool3 := range wethPools {
-					if pool1.Address == pool3.Address || pool2.Address == pool3.Address {
-						continue
-					}
-					if (pool3.Token0 == WETHAddress && pool3.Token1 == *token2) || (pool3.Token1 == WETHAddress && pool3.Token0 == *token2) {
-						addressPaths = append(addressPaths, strings.Join([]string{pool1.Address.String(), pool2.Address.String(), pool3.Address.String()}, "->"))
-					}
-				}
-			}
-			res <- addressPaths
-		}(effectedPool, addressCh)
-	}
-	for i := 0; i < len(effectedPools); i++ {
-		foundPaths := <-addressCh
-		paths = append(paths, foundPaths...)
-	}
-	log.Info().Float64("findTriangularPathFirstEffected", time.Since(now).Seconds()).Msg("duration")
-	ch <- paths
-}
-
-func (c *UniswapClient) findTriangularPathLastEffected(effectedPools []Pool, ch chan []string, wethPools []Pool, allPools []Pool) {
-	now := time.Now()
-	paths := []string{}
-	addressCh := make(chan []string)
-	for _, wethPool := range wethPools {
-		go func(pool1 Pool, res chan []string) {
-			addressPaths := []string{}
-			var token1 common.Address
-			if pool1.Token0 == WETHAddress {
-				token1 = pool1.Token1
-			}
-			if pool1.Token1 == WETHAddress {
-				token1 = pool1.Token0
-			}
-			for _, pool2 := range allPools {
-				if pool2.Address == pool1.Address {
+	paths := []Path{}
+	addedMap := make(map[string]bool)
+	deadline := now.Add(budget)
+	for _, pool := range effectedPools {
+		if !pool.Enabled {
+			continue
+		}
+		complete := true
+		for _, base := range c.profile.BaseTokens {
+			cycles, ok := c.index.CyclesOf(pool.Address, base.Address, deadline)
+			complete = complete && ok
+			for _, path := range cycles {
+				key := path.String()
+				_, ok := addedMap[key]
+				if ok {
 					continue
 				}
-				var token2 *common.Address
-				if pool2.Token0 == token1 {
-					token2 = &pool2.Token1
-				}
-				if pool2.Token1 == token1 {
-					token2 = &pool2.Token0
-				}
-				if token2 == nil {
+				addedMap[key] = true
+				if c.pathDenied(path) {
 					continue
 				}
-				for _, pool3 := range effectedPools {
-					if pool3.Address == pool2.Address || pool3.Address == pool1.Address {
-						continue
-					}
-					if (pool3.Token1 == WETHAddress && pool3.Token0 == *token2) || (pool3.Token0 == WETHAddress && pool3.Token1 == *token2) {
-						addressPaths = append(addressPaths, strings.Join([]string{pool1.Address.String(), pool2.Address.String(), pool3.Address.String()}, "->"))
-					}
+				if c.isProfitableAtMargin(path, view) && c.pathTokensSafe(path) {
+					paths = append(paths, path)
 				}
 			}
-			res <- addressPaths
-		}(wethPool, addressCh)
-	}
-	for i := 0; i < len(wethPools); i++ {
-		foundPaths := <-addressCh
-		paths = append(paths, foundPaths...)
-	}
-	log.Info().Float64("findTriangularPathLastEffected", time.Since(now).Seconds()).Msg("duration")
-	ch <- paths
-}
-
-func (c *UniswapClient) CalculateWethAndAllPools() ([]Pool, []Pool) {
-	allPools := []Pool{}
-	wethPools := []Pool{}
-	for _, pool := range c.Pools {
-		if !pool.Enabled {
-			continue
 		}
-		if pool.Token1 == WETHAddress || pool.Token0 == WETHAddress {
-			wethPools = append(wethPools, pool)
-		}
-		allPools = append(allPools, pool)
-	}
-	return allPools, wethPools
-}
-
-func (c *UniswapClient) CalculateActivePoolAddresses() []common.Address {
-	addresses := c.FactoryAddresses
-	for _, pool := range c.Pools {
-		if !pool.Enabled {
-			continue
-		}
-		addresses = append(addresses, pool.Address)
-	}
-	return addresses
-}
-
-func (c *UniswapClient) FindPaths(effectedPools []Pool) []string {
-	paths := []string{}
-	addedMap := make(map[string]bool)
-	ch := make(chan []string)
-	allPools, wethPools := c.CalculateWethAndAllPools()
-	go c.findDoublePathFirstEffected(effectedPools, ch, wethPools)
-	go c.findDoublePathLastEffected(effectedPools, ch, wethPools)
-	go c.findTriangularPathFirstEffected(effectedPools, ch, wethPools, allPools)
-	go c.findTriangularPathMidEffected(effectedPools, ch, wethPools)
-	go c.findTriangularPathLastEffected(effectedPools, ch, wethPools, allPools)
-	for i := 0; i < 5; i++ {
-		foundPaths := <-ch
-		for _, path := range foundPaths {
-			_, ok := addedMap[path]
-			if !ok {
-				addedMap[path] = true
-				paths = append(paths, path)
-			}
+		if !complete {
+			log.Info().Int("checkedPaths", len(addedMap)).Msg("path search budget exceeded")
+			break
 		}
 	}
+	log.Info().Float64("findPaths", time.Since(now).Seconds()).Int("checkedPaths", len(addedMap)).Msg("duration")
 	return paths
 }
 
-func (c *UniswapClient) calculateOutcomeForPath(path string, ch chan ArbitrageTx) {
-	addressesAsStr := strings.Split(path, "->")
+// calculateOutcomeForPath quotes the path on the view. Quotes on a view with
+// pending swaps can not be checked against the chain, which does not have
+// them yet.
+func (c *UniswapClient) calculateOutcomeForPath(path Path, view *poolView, ch chan ArbitrageTx) {
+	borrowToken := path.Tokens[0]
 	poolAddresses := []common.Address{}
 	pools := []Pool{}
 	types := []*big.Int{}
 	quoters := []common.Address{}
 	quoteParams := []contracts.UniswapBotV2QuoteParams{}
 	rawContract := contracts.UniswapBotV2Raw{Contract: c.BotContract}
-	for _, addressStr := range addressesAsStr {
-		pool := c.Pools[common.HexToAddress(addressStr)]
-		if pool.Type == "v2" {
-			quoters = append(quoters, AddressZero)
+	for _, address := range path.Pools {
+		pool := view.pool(address)
+		if pool.Type == PoolTypeV3 {
+			quoters = append(quoters, c.profile.V3Quoter)
 		} else {
-			quoters = append(quoters, UniswapV3Quoter)
+			quoters = append(quoters, AddressZero)
 		}
 		pools = append(pools, pool)
 		poolAddresses = append(poolAddresses, pool.Address)
-		if pool.Type == "v2" {
-			types = append(types, big.NewInt(0))
-		} else {
-			types = append(types, big.NewInt(1))
-		}
+		types = append(types, hopType(pool))
 	}
 	tx := ArbitrageTx{
-		Path:               path,
-		BorrowTokenAddress: WETHAddress,
+		Path:               path.String(),
+		BorrowTokenAddress: borrowToken,
 		Pools:              poolAddresses,
 		Types:              types,
 		Profit:             big.NewInt(0),
+		ProfitETH:          big.NewInt(0),
 		Ratio:              big.NewFloat(0),
+		Optimizer:          OptimizerGrid,
 	}
-	for i := 1; i < 21; i++ {
-		var reserve *big.Int
-		if pools[0].Token1 == WETHAddress {
-			reserve = pools[0].Reserve1
-		} else {
-			reserve = pools[0].Reserve0
-		}
-		amount1 := new(big.Int).Mul(reserve, big.NewInt(int64(i)))
+	reserve := pools[0].reserveOf(borrowToken)
+	strategy := c.config.Strategy
+	for i := int64(1); i <= strategy.GridSteps; i++ {
+		amount1 := new(big.Int).Mul(reserve, big.NewInt(i*strategy.GridStepPercent))
 		amount := new(big.Int).Div(amount1, big.NewInt(int64(100)))
 		quoteParams = append(quoteParams, contracts.UniswapBotV2QuoteParams{
 			Pools:   poolAddresses,
 			Quoters: quoters,
 			Amount:  amount,
-			TokenIn: WETHAddress,
+			TokenIn: borrowToken,
 		})
 	}
+	if canQuoteLocally(pools) {
+		for _, param := range quoteParams {
+			outcome := quoteLocally(pools, path.Tokens, param.Amount)
+			tx.consider(param.Amount, outcome[len(outcome)-1])
+		}
+		tx.GridBorrowAmount = tx.BorrowAmount
+		tx.GridProfit = tx.Profit
+		optimizer, amount := c.optimizeBorrowAmount(pools, path.Tokens, reserve)
+		outcome := quoteLocally(pools, path.Tokens, amount)
+		if tx.consider(amount, outcome[len(outcome)-1]) {
+			tx.Optimizer = optimizer
+		}
+		if tx.Valid && !view.pending() {
+			c.confirmQuote(&tx, pools, path.Tokens, quoters)
+		}
+		tx.ProfitETH = c.toETH(borrowToken, tx.Profit)
+		ch <- tx
+		return
+	}
+	if view.pending() {
+		ch <- tx
+		return
+	}
 	var out []interface{}
 	err := rawContract.Call(nil, &out, "multiQuote", quoteParams)
 	if err != nil {
@@ -893,34 +816,69 @@ func (c *UniswapClient) calculateOutcomeForPath(path string, ch chan ArbitrageTx
 
 	for i, param := range quoteParams {
 		outcome := outcomes[i]
-		lastOut := outcome[len(outcome)-1]
-		profit := new(big.Int).Sub(lastOut, param.Amount)
-		if profit.Cmp(tx.Profit) == 1 {
-			tx.Profit = profit
-			tx.AmountOut = lastOut
-			tx.BorrowAmount = param.Amount
-			tx.Profit = profit
-			tx.Valid = true
-			profitFloat := new(big.Float).SetInt(tx.Profit)
-			amountFloat := new(big.Float).SetInt(tx.BorrowAmount)
-			ratio1 := new(big.Float).Mul(profitFloat, big.NewFloat(100))
-			ratio := new(big.Float).Quo(ratio1, amountFloat)
-			tx.Ratio = ratio
-		}
+		tx.consider(param.Amount, outcome[len(outcome)-1])
 	}
+	tx.ProfitETH = c.toETH(borrowToken, tx.Profit)
 	ch <- tx
 }
 
-func (c *UniswapClient) calculateOutcomes(paths []string) []ArbitrageTx {
+func (c *UniswapClient) quoteUniswap(pools []common.Address, quoters []common.Address, tokenIn common.Address, amount *big.Int) ([]*big.Int, error) {
+	rawContract := contracts.UniswapBotV2Raw{Contract: c.BotContract}
+	var out []interface{}
+	err := rawContract.Call(nil, &out, "quote", contracts.UniswapBotV2QuoteParams{
+		Pools:   pools,
+		Quoters: quoters,
+		Amount:  amount,
+		TokenIn: tokenIn,
+	})
+	if err != nil {
+		return nil, decodeRevert(err)
+	}
+	return out[0].([]*big.Int), nil
+}
+
+// quoteOnChain quotes the whole path with the bot contract.
+func (c *UniswapClient) quoteOnChain(pools []Pool, tokens []common.Address, quoters []common.Address, amount *big.Int) (*big.Int, error) {
+	addresses := []common.Address{}
+	for _, pool := range pools {
+		addresses = append(addresses, pool.Address)
+	}
+	outcome, err := c.quoteUniswap(addresses, quoters, tokens[0], amount)
+	if err != nil {
+		return nil, err
+	}
+	return outcome[len(outcome)-1], nil
+}
+
+func (c *UniswapClient) confirmQuote(tx *ArbitrageTx, pools []Pool, tokens []common.Address, quoters []common.Address) {
+	lastOut, err := c.quoteOnChain(pools, tokens, quoters, tx.BorrowAmount)
+	if err != nil {
+		log.Info().Err(err).Str("path", tx.Path).Msg("on chain quote failed")
+		tx.Valid = false
+		return
+	}
+	if lastOut.Cmp(tx.AmountOut) != 0 {
+		log.Info().Str("path", tx.Path).Str("local", tx.AmountOut.String()).Str("rpc", lastOut.String()).Msg("local quote mismatch")
+	}
+	borrowAmount := tx.BorrowAmount
+	tx.Profit = big.NewInt(0)
+	tx.Valid = false
+	tx.consider(borrowAmount, lastOut)
+}
+
+func (c *UniswapClient) calculateOutcomes(paths []Path, view *poolView) []ArbitrageTx {
 	txs := []ArbitrageTx{}
 	ch := make(chan ArbitrageTx)
 	for _, path := range paths {
-		go c.calculateOutcomeForPath(path, ch)
+		go c.calculateOutcomeForPath(path, view, ch)
 	}
 	for i := 0; i < len(paths); i++ {
 		tx := <-ch
 		txs = append(txs, tx)
 	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].ProfitETH.Cmp(txs[j].ProfitETH) == 1
+	})
 	return txs
 }
 
@@ -933,6 +891,9 @@ func (c *UniswapClient) Run() error {
 	if err != nil {
 		return err
 	}
+	if c.config.Ingestion.Mode == config.IngestionSubscribe {
+		return c.runSubscribed()
+	}
 	headerCh := make(chan *types.Header)
 	sub, err := c.wsClient.SubscribeNewHead(c.ctx, headerCh)
 	if err != nil {
@@ -941,129 +902,223 @@ func (c *UniswapClient) Run() error {
 	defer func() {
 		sub.Unsubscribe()
 	}()
+	pendingCh := make(chan *types.Transaction, pendingChannelSize)
+	pendingSub, err := c.watchMempool(pendingCh)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pendingSub.Unsubscribe()
+	}()
 	for {
 		select {
 		case blockHeader := <-headerCh:
 			now := time.Now()
-			hash := blockHeader.Hash()
-			blockNumber, err := c.client.BlockNumber(c.ctx)
+			_, err := c.handleReorg(blockHeader)
 			if err != nil {
 				return err
 			}
-			logs := []types.Log{}
-			allAddresses := c.CalculateActivePoolAddresses()
-			batchSize := 100000
-			for i := 0; i < len(allAddresses); i += batchSize {
-				var addresses []common.Address
-				if i+batchSize < len(allAddresses) {
-					addresses = allAddresses[i : i+batchSize]
-				} else {
-					addresses = allAddresses[i:]
-				}
-				partLogs, err := c.client.FilterLogs(c.ctx, ethereum.FilterQuery{
-					FromBlock: big.NewInt(int64(c.LastSeenBlock)),
-					Addresses: addresses,
-				})
-				if err != nil {
-					return err
-				}
-				logs = append(logs, partLogs...)
+			logs, err := c.fetchLogs(blockHeader)
+			if err != nil {
+				return err
 			}
 			log.Info().Float64("untilLogs", time.Since(now).Seconds()).Msg("untilLogs")
-			effectedPools, err := c.ResolveLogs(logs, hash)
+			err = c.processBlock(blockHeader, logs, now)
 			if err != nil {
 				return err
 			}
-			log.Info().Float64("untilResolve", time.Since(now).Seconds()).Msg("untilResolve duration")
-			c.LastSeenBlock = blockNumber
-			log.Info().Int("totalLogs", len(logs)).Msg("log summary")
-			foundPaths := c.FindPaths(effectedPools)
-			log.Info().Float64("untilOutcomes", time.Since(now).Seconds()).Msg("paths duration")
-			log.Info().Int("totalPaths", len(foundPaths)).Msg("path summary")
-			txs := c.calculateOutcomes(foundPaths)
-			log.Info().Float64("untilSendTx", time.Since(now).Seconds()).Msg("outcomes duration")
-			for _, tx := range txs {
-				if !tx.Valid {
-					continue
-				}
-				txhash, gasCost, bribe, err := c.SendTransaction(tx)
-				log.Info().Interface("tx", tx).Interface("gasCost", gasCost).Interface("bribe", bribe).Msg("possible trade")
-				if err != nil {
-					log.Info().Err(err).Msg("can not send tx")
-					if strings.Contains(err.Error(), "Malicious Pool: ") {
-						errMsg := err.Error()
-						poolAddress := errMsg[len(errMsg)-42:]
-						pool := c.Pools[common.HexToAddress(poolAddress)]
-						pool.Enabled = false
-						c.Pools[pool.Address] = pool
-					}
-					continue
-				}
-				message := "Profitable Trade"
-				message += "Pools: " + tx.Path
-				message += "Profit Ratio: %" + tx.Ratio.String()
-				message += "Loan Amount: " + tx.BorrowAmount.String()
-				message += "Loan Payment: " + tx.BorrowAmount.String()
-				message += "Amount Out: " + tx.AmountOut.String()
-				message += "Gas Cost: " + gasCost.String()
-				message += "Profit: " + tx.Profit.String()
-				message += fmt.Sprintf(TxFormat, txhash.String())
-				err = c.Notify(message)
-				if err != nil {
-					log.Info().Err(err).Msg("notify problem")
-				}
+		case tx := <-pendingCh:
+			c.handlePendingTx(tx)
+		case err := <-pendingSub.Err():
+			log.Info().Err(err).Msg("pending transaction subscription dropped, resubscribing")
+			pendingSub, err = c.watchMempool(pendingCh)
+			if err != nil {
+				return err
 			}
-			log.Info().Float64("totalDuration", time.Since(now).Seconds()).Msg("duration")
 		case err := <-sub.Err():
-			if strings.Contains(err.Error(), "read: connection reset by peer") {
-				log.Info().Msg("Reconnecting...")
-				return c.Run()
-			}
-			if strings.Contains(err.Error(), "i/o timeout") {
+			if reconnectable(err) {
 				log.Info().Msg("Reconnecting...")
 				return c.Run()
 			}
 			return err
+		case <-c.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// processBlock applies the logs up to the header and trades the paths of the
+// pools they changed.
+func (c *UniswapClient) processBlock(blockHeader *types.Header, logs []types.Log, now time.Time) error {
+	hash := blockHeader.Hash()
+	blockNumber := blockHeader.Number.Uint64()
+	removedBlock, ok := removedLogsBlock(logs)
+	if ok && removedBlock <= c.LastSeenBlock {
+		c.rollback(removedBlock - 1)
+	}
+	c.beginBlock(blockNumber, hash, blockHeader.ParentHash)
+	effectedPools, err := c.ResolveLogs(logs, hash)
+	c.endBlock()
+	if err != nil {
+		return err
+	}
+	log.Info().Float64("untilResolve", time.Since(now).Seconds()).Msg("untilResolve duration")
+	if blockNumber > c.LastSeenBlock {
+		c.setLastSeenBlock(blockNumber)
+	}
+	c.head = blockHeader
+	err = c.flushState()
+	if err != nil {
+		log.Error().Err(err).Msg("can not save block state")
+	}
+	c.snapshotState()
+	c.settleBundles(blockNumber)
+	log.Info().Int("totalLogs", len(logs)).Msg("log summary")
+	view := c.newPoolView()
+	foundPaths := c.FindPaths(effectedPools, view, c.config.Strategy.PathSearchBudget)
+	log.Info().Float64("untilOutcomes", time.Since(now).Seconds()).Msg("paths duration")
+	log.Info().Int("totalPaths", len(foundPaths)).Msg("path summary")
+	txs := c.calculateOutcomes(foundPaths, view)
+	log.Info().Float64("untilSendTx", time.Since(now).Seconds()).Msg("outcomes duration")
+	if hasValidTx(txs) && c.orphaned(blockHeader) {
+		log.Info().Str("block", hash.String()).Msg("block orphaned, opportunities abandoned")
+		return nil
+	}
+	for _, tx := range txs {
+		if !tx.Valid {
+			continue
 		}
+		c.trade(tx, nil, blockNumber+1)
+	}
+	c.checkPendingTokens()
+	log.Info().Float64("totalDuration", time.Since(now).Seconds()).Msg("duration")
+	return nil
+}
+
+// trade sends the arbitrage for block, right behind target when one is given,
+// records it and notifies about it.
+func (c *UniswapClient) trade(tx ArbitrageTx, target *types.Transaction, block uint64) {
+	txhash, gasCost, bribe, decision, err := c.sendArbitrage(tx, target, block)
+	log.Info().Interface("tx", tx).Interface("gasCost", gasCost).Interface("bribe", bribe).Int64("bribePercent", decision.Percent).Str("bribeReason", decision.Reason).Msg("possible trade")
+	c.recordTrade(tx, txhash, gasCost, bribe, decision, err)
+	if err != nil {
+		log.Info().Err(err).Msg("can not send tx")
+		c.recordTradeFailure(tx, err)
+		return
+	}
+	message := "Profitable Trade"
+	message += "Pools: " + tx.Path
+	message += "Profit Ratio: %" + tx.Ratio.String()
+	message += "Loan Amount: " + tx.BorrowAmount.String()
+	message += "Loan Payment: " + tx.BorrowAmount.String()
+	message += "Amount Out: " + tx.AmountOut.String()
+	message += "Gas Cost: " + gasCost.String()
+	message += fmt.Sprintf("Bribe: %s (%d%%, %s)", bribe, decision.Percent, decision.Reason)
+	message += "Profit: " + tx.Profit.String()
+	message += "Profit (ETH): " + tx.ProfitETH.String()
+	message += fmt.Sprintf(c.profile.TxFormat, txhash.String())
+	err = c.Notify(message)
+	if err != nil {
+		log.Info().Err(err).Msg("notify problem")
 	}
 }
 
 func (c *UniswapClient) SendTransaction(tx ArbitrageTx) (*common.Hash, *big.Int, *big.Int, error) {
+	txHash, gasCost, bribe, _, err := c.sendArbitrage(tx, nil, c.LastSeenBlock+1)
+	return txHash, gasCost, bribe, err
+}
+
+// sendArbitrage sends the arbitrage to the builders as a bundle for block,
+// behind target when one is given, or to the RPC on chains without builders.
+// Bundles are simulated first and pay off by their simulated gas and bribe,
+// transactions by their worst case gas cost. Backruns need a bundle, so they
+// are only sent to builders. The bribe strategy picks the share of the profit
+// paid to the builder once the gas of the trade is known. Fees are priced for
+// the target block, and bundles only need to cover the blocks they target.
+func (c *UniswapClient) sendArbitrage(tx ArbitrageTx, target *types.Transaction, block uint64) (*common.Hash, *big.Int, *big.Int, BribeDecision, error) {
 	gasCost := big.NewInt(0)
 	bribe := big.NewInt(0)
+	pathType := c.pathType(tx)
+	decision := c.bribe.Choose(BribeContext{PathType: pathType, ProfitETH: tx.ProfitETH})
 
 	opts, err := bind.NewKeyedTransactorWithChainID(c.privKey, c.chainId)
 	if err != nil {
-		return nil, gasCost, bribe, err
+		return nil, gasCost, bribe, decision, err
 	}
 	opts.NoSend = true
-	fakeTx, err := c.arbitrageContract.StartArbitrage(opts, tx.BorrowTokenAddress, tx.BorrowAmount, tx.Pools, tx.Types, tx.AmountOut, BribePercent)
+	if target != nil {
+		if c.relay == nil {
+			return nil, gasCost, bribe, decision, ErrNoBuilders
+		}
+		opts.GasLimit = c.config.Bundles.BackrunGasLimit
+	}
+	headroom := c.config.Fees.HeadroomBlocks
+	if c.relay != nil {
+		headroom = c.config.Bundles.TargetBlocks - 1
+	}
+	fees, err := c.estimateFees(block, headroom)
 	if err != nil {
-		return nil, gasCost, bribe, err
+		return nil, gasCost, bribe, decision, err
+	}
+	opts.GasFeeCap = fees.MaxFeePerGas
+	opts.GasTipCap = fees.MaxPriorityFeePerGas
+	signedTx, err := c.arbitrageContract.StartArbitrage(opts, tx.BorrowTokenAddress, tx.BorrowAmount, tx.Pools, tx.Types, tx.AmountOut, big.NewInt(decision.Percent))
+	if err != nil {
+		return nil, gasCost, bribe, decision, decodeRevert(err)
+	}
+	gasDecision := c.bribe.Choose(BribeContext{PathType: pathType, ProfitETH: tx.ProfitETH, Gas: signedTx.Gas()})
+	if gasDecision.Percent != decision.Percent {
+		opts.GasLimit = signedTx.Gas()
+		signedTx, err = c.arbitrageContract.StartArbitrage(opts, tx.BorrowTokenAddress, tx.BorrowAmount, tx.Pools, tx.Types, tx.AmountOut, big.NewInt(gasDecision.Percent))
+		if err != nil {
+			return nil, gasCost, bribe, gasDecision, decodeRevert(err)
+		}
+	}
+	decision = gasDecision
+	bribePercent := big.NewInt(decision.Percent)
+	if c.relay != nil {
+		gasCost, bribe, err = c.simulateBundle(tx, signedTx, target, block, fees, decision.Percent)
+		if err != nil {
+			return nil, gasCost, bribe, decision, err
+		}
+		err = c.sendBundle(signedTx, target, block)
+		if err != nil {
+			return nil, gasCost, bribe, decision, err
+		}
+		c.sentBundles = append(c.sentBundles, sentBundle{
+			TxHash:    signedTx.Hash(),
+			PathType:  pathType,
+			Bribe:     decision,
+			LastBlock: block + uint64(c.config.Bundles.TargetBlocks) - 1,
+		})
+		txHash := signedTx.Hash()
+		return &txHash, gasCost, bribe, decision, nil
 	}
-	bribe = new(big.Int).Mul(tx.Profit, BribePercent)
+	bribe = new(big.Int).Mul(tx.ProfitETH, bribePercent)
 	bribe = new(big.Int).Div(bribe, big.NewInt(100))
-	maxCost := new(big.Int).Mul(fakeTx.GasFeeCap(), big.NewInt(int64(fakeTx.Gas())))
-	realProfit := new(big.Int).Sub(tx.Profit, maxCost)
+	maxCost := fees.MaxCost(signedTx.Gas())
+	log.Info().Str("path", tx.Path).Str("baseFee", fees.BaseFee.String()).Str("maxPriorityFeePerGas", fees.MaxPriorityFeePerGas.String()).Str("maxFeePerGas", fees.MaxFeePerGas.String()).Str("cost", fees.Cost(signedTx.Gas()).String()).Str("maxCost", maxCost.String()).Msg("fees estimated")
+	realProfit := new(big.Int).Sub(tx.ProfitETH, maxCost)
 	realProfit = new(big.Int).Sub(realProfit, bribe)
 	if realProfit.Cmp(big.NewInt(0)) != 1 {
-		return nil, maxCost, bribe, errors.New("gas cost is higher")
+		return nil, maxCost, bribe, decision, ErrGasCostTooHigh
 	}
 	opts.NoSend = false
-	realTx, err := c.arbitrageContract.StartArbitrage(opts, tx.BorrowTokenAddress, tx.BorrowAmount, tx.Pools, tx.Types, tx.AmountOut, BribePercent)
+	opts.GasLimit = signedTx.Gas()
+	realTx, err := c.arbitrageContract.StartArbitrage(opts, tx.BorrowTokenAddress, tx.BorrowAmount, tx.Pools, tx.Types, tx.AmountOut, bribePercent)
 	if err != nil {
-		return nil, maxCost, bribe, err
+		return nil, maxCost, bribe, decision, decodeRevert(err)
 	}
 	txHash := realTx.Hash()
 
-	return &txHash, maxCost, bribe, nil
+	return &txHash, maxCost, bribe, decision, nil
 }
 
 func (c *UniswapClient) Notify(message string) error {
 	baseUrl := "https://api.telegram.org"
-	resource := "/bot" + c.botToken + "/sendMessage"
+	resource := "/bot" + c.config.Notifications.Telegram.BotToken + "/sendMessage"
 	params := url.Values{}
-	params.Add("chat_id", c.chatId)
+	params.Add("chat_id", c.config.Notifications.Telegram.ChatID)
 	params.Add("parse_mode", "Markdown")
 	params.Add("text", message)
 
@@ -0,0 +1,167 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/contracts"
+)
+
+var (
+	CurveETHAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+	curveEventTopics = curvePoolTopics()
+)
+
+// curvePoolTopics covers the events of the registry pools that change their
+// balances, amplification or fee.
+func curvePoolTopics() map[common.Hash]bool {
+	signatures := []string{
+		"TokenExchange(address,int128,uint256,int128,uint256)",
+		"TokenExchangeUnderlying(address,int128,uint256,int128,uint256)",
+		"RemoveLiquidityOne(address,uint256,uint256)",
+		"RemoveLiquidityOne(address,uint256,uint256,uint256)",
+		"RampA(uint256,uint256,uint256,uint256)",
+		"StopRampA(uint256,uint256)",
+		"NewFee(uint256,uint256)",
+	}
+	for n := 2; n <= 4; n++ {
+		amounts := fmt.Sprintf("uint256[%d]", n)
+		signatures = append(signatures,
+			fmt.Sprintf("AddLiquidity(address,%s,%s,uint256,uint256)", amounts, amounts),
+			fmt.Sprintf("RemoveLiquidity(address,%s,%s,uint256)", amounts, amounts),
+			fmt.Sprintf("RemoveLiquidityImbalance(address,%s,%s,uint256,uint256)", amounts, amounts),
+		)
+	}
+	topics := make(map[common.Hash]bool)
+	for _, signature := range signatures {
+		topics[crypto.Keccak256Hash([]byte(signature))] = true
+	}
+	return topics
+}
+
+func isCurvePoolEvent(blockLog types.Log) bool {
+	return len(blockLog.Topics) > 0 && curveEventTopics[blockLog.Topics[0]]
+}
+
+func (p Pool) coinIndex(token common.Address) int {
+	for i, coin := range p.Coins {
+		if coin == token {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p Pool) canQuoteCurve() bool {
+	return p.Curve != nil && p.Curve.Initialized() && p.Fee != nil && len(p.Coins) == len(p.Curve.Balances)
+}
+
+func (p Pool) quoteCurve(tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) *big.Int {
+	i := p.coinIndex(tokenIn)
+	j := p.coinIndex(tokenOut)
+	if i < 0 || j < 0 || amountIn.Sign() != 1 {
+		return big.NewInt(0)
+	}
+	amountOut, err := p.Curve.GetDy(i, j, amountIn, p.Fee)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return amountOut
+}
+
+// setCurveState copies what the registry reports for a pool. The first two
+// coins double as Token0 and Token1 so code that only knows pairs keeps
+// working.
+func (p *Pool) setCurveState(balances []*big.Int, amp *big.Int, fee *big.Int) {
+	p.Curve.Balances = balances
+	p.Curve.Amp = amp
+	p.Fee = fee
+	p.Reserve0 = balances[0]
+	p.Reserve1 = balances[1]
+	if !p.Curve.Initialized() || p.coinIndex(CurveETHAddress) != -1 {
+		p.Enabled = false
+	}
+}
+
+func (c *UniswapClient) curveRegistry(pool Pool) (*contracts.ICurveRegistry, error) {
+	dex, err := c.dexOf(pool)
+	if err != nil {
+		return nil, err
+	}
+	return contracts.NewICurveRegistry(dex.Factory, c.client)
+}
+
+func (c *UniswapClient) refreshCurvePool(pool Pool) (Pool, error) {
+	registry, err := c.curveRegistry(pool)
+	if err != nil {
+		return pool, err
+	}
+	balances, err := registry.GetBalances(nil, pool.Address)
+	if err != nil {
+		return pool, err
+	}
+	amp, err := registry.GetA(nil, pool.Address)
+	if err != nil {
+		return pool, err
+	}
+	fees, err := registry.GetFees(nil, pool.Address)
+	if err != nil {
+		return pool, err
+	}
+	pool.setCurveState(balances[:len(pool.Coins)], amp, fees[0])
+	if !c.hasBaseLiquidity(pool) {
+		pool.Enabled = false
+	}
+	return pool, nil
+}
+
+// verifyCurvePool compares the local get_dy with the pool's own for a trade
+// of a thousandth of the first coin's balance. Lending and meta pools price
+// their coins with rates the registry does not expose and fail this check.
+func (c *UniswapClient) verifyCurvePool(pool Pool) bool {
+	if !pool.canQuoteCurve() {
+		return false
+	}
+	contract, err := contracts.NewICurvePool(pool.Address, c.client)
+	if err != nil {
+		return false
+	}
+	amountIn := new(big.Int).Div(pool.Curve.Balances[0], big.NewInt(1000))
+	local := pool.quoteCurve(pool.Coins[0], pool.Coins[1], amountIn)
+	remote, err := contract.GetDy(nil, big.NewInt(0), big.NewInt(1), amountIn)
+	if err != nil || remote.Sign() != 1 {
+		return false
+	}
+	if !withinTolerance(local, remote, big.NewInt(c.config.Strategy.CurveQuoteTolerance)) {
+		log.Info().Str("pool", pool.Address.String()).Str("local", local.String()).Str("rpc", remote.String()).Msg("curve quote mismatch")
+		return false
+	}
+	return true
+}
+
+func (c *UniswapClient) setCurveReserves(newPools []Pool) {
+	for _, pool := range c.Pools {
+		if pool.Type != PoolTypeCurve || !pool.Enabled {
+			continue
+		}
+		pool, err := c.refreshCurvePool(pool)
+		if err != nil {
+			log.Info().Err(err).Str("pool", pool.Address.String()).Msg("can not refresh curve pool")
+			continue
+		}
+		c.setPool(pool)
+	}
+	for _, pool := range newPools {
+		if !c.hasBaseLiquidity(pool) {
+			pool.Enabled = false
+		}
+		if pool.Enabled && !c.verifyCurvePool(pool) {
+			pool.Enabled = false
+		}
+		c.setPool(pool)
+	}
+}
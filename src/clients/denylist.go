@@ -0,0 +1,232 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"os"
+	"time"
+)
+
+const (
+	DenySourceRevert     = "revert"
+	DenySourceSimulation = "simulation"
+	DenySourceManual     = "manual"
+
+	manualReason = "listed in config"
+)
+
+// DenyEntry is the failure history of a pool or a token. It is denied from
+// the moment Denied is set until ExpiresAt. After that it is traded again,
+// and the next failure denies it right away.
+type DenyEntry struct {
+	Reason           string    `json:"reason"`
+	Source           string    `json:"source"`
+	FirstSeenBlock   uint64    `json:"firstSeenBlock"`
+	LastFailureBlock uint64    `json:"lastFailureBlock"`
+	Failures         int       `json:"failures"`
+	Denied           bool      `json:"denied"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
+
+// Active reports whether the entry denies trading now. Entries without an
+// expiry never run out.
+func (e DenyEntry) Active() bool {
+	return e.Denied && (e.ExpiresAt.IsZero() || time.Now().Before(e.ExpiresAt))
+}
+
+type Denylist struct {
+	Pools  map[common.Address]DenyEntry `json:"pools"`
+	Tokens map[common.Address]DenyEntry `json:"tokens"`
+}
+
+func NewDenylist() Denylist {
+	return Denylist{
+		Pools:  make(map[common.Address]DenyEntry),
+		Tokens: make(map[common.Address]DenyEntry),
+	}
+}
+
+// record adds a failure to the entry of address. Hard failures deny at once,
+// others only after maxFailures of them. Manual denials are kept as they are.
+func record(entries map[common.Address]DenyEntry, address common.Address, reason string, source string, block uint64, hard bool, maxFailures int, expiry time.Duration) DenyEntry {
+	entry, ok := entries[address]
+	if !ok {
+		entry.FirstSeenBlock = block
+	}
+	entry.LastFailureBlock = block
+	if entry.Source == DenySourceManual && entry.Denied {
+		entry.Failures++
+		entries[address] = entry
+		return entry
+	}
+	retest := entry.Denied
+	entry.Reason = reason
+	entry.Source = source
+	entry.Failures++
+	if hard || retest || entry.Failures >= maxFailures {
+		entry.Denied = true
+		entry.ExpiresAt = time.Now().Add(expiry)
+	}
+	entries[address] = entry
+	return entry
+}
+
+func (c *UniswapClient) denyPool(address common.Address, reason string, source string, hard bool) {
+	cfg := c.config.Denylist
+	entry := record(c.denylist.Pools, address, reason, source, c.LastSeenBlock, hard, cfg.MaxFailures, cfg.Expiry)
+	log.Info().Str("pool", address.String()).Str("reason", reason).Str("source", source).Int("failures", entry.Failures).Bool("denied", entry.Denied).Msg("pool failure")
+	c.saveDenyEntry(denyKindPool, address, entry)
+}
+
+func (c *UniswapClient) denyToken(address common.Address, reason string, source string, hard bool) {
+	cfg := c.config.Denylist
+	entry := record(c.denylist.Tokens, address, reason, source, c.LastSeenBlock, hard, cfg.MaxFailures, cfg.Expiry)
+	log.Info().Str("token", address.String()).Str("reason", reason).Str("source", source).Int("failures", entry.Failures).Bool("denied", entry.Denied).Msg("token failure")
+	c.saveDenyEntry(denyKindToken, address, entry)
+}
+
+func (c *UniswapClient) tokenDenied(token common.Address) bool {
+	entry, ok := c.denylist.Tokens[token]
+	return ok && entry.Active()
+}
+
+// poolDenied reports whether the pool or one of its coins is denied.
+func (c *UniswapClient) poolDenied(pool Pool) bool {
+	entry, ok := c.denylist.Pools[pool.Address]
+	if ok && entry.Active() {
+		return true
+	}
+	for _, coin := range pool.coins() {
+		if c.tokenDenied(coin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *UniswapClient) pathDenied(path Path) bool {
+	for _, address := range path.Pools {
+		if c.poolDenied(c.Pools[address]) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordTradeFailure denies the pool a malicious pool revert names, and
+// counts other reverts of the trade against the pools of the path. Anything
+// else is no fault of the pools, like a moved price, running out of gas, a
+// panic of the contract or a relay or RPC that is down, and is not counted.
+func (c *UniswapClient) recordTradeFailure(tx ArbitrageTx, err error) {
+	var malicious *MaliciousPoolError
+	var revert *RevertError
+	var custom *CustomError
+	switch {
+	case errors.As(err, &malicious):
+		c.denyPool(malicious.Pool, malicious.Reason, DenySourceRevert, true)
+	case errors.As(err, &revert), errors.As(err, &custom):
+		c.recordPathFailure(tx, err)
+	}
+}
+
+// recordPathFailure counts a failed trade against the pools of the path. Pools
+// between two base tokens are left out, since they are in most paths and are
+// rarely the cause.
+func (c *UniswapClient) recordPathFailure(tx ArbitrageTx, err error) {
+	for _, address := range tx.Pools {
+		pool, ok := c.Pools[address]
+		if !ok {
+			continue
+		}
+		bases := 0
+		coins := pool.coins()
+		for _, coin := range coins {
+			_, ok := c.baseToken(coin)
+			if ok {
+				bases++
+			}
+		}
+		if bases == len(coins) {
+			continue
+		}
+		c.denyPool(address, err.Error(), DenySourceRevert, false)
+	}
+}
+
+// ReadDenylist loads the persisted denylist and adds the manual entries of
+// the config, which never expire.
+func (c *UniswapClient) ReadDenylist() error {
+	if c.store != nil {
+		denylist, err := c.store.LoadDenylist(c.chainId.Uint64())
+		if err != nil {
+			return err
+		}
+		c.denylist = denylist
+	}
+	if len(c.denylist.Pools) == 0 && len(c.denylist.Tokens) == 0 {
+		file, err := os.ReadFile(c.denylistPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err == nil {
+			err = json.Unmarshal(file, &c.denylist)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if c.denylist.Pools == nil {
+		c.denylist.Pools = make(map[common.Address]DenyEntry)
+	}
+	if c.denylist.Tokens == nil {
+		c.denylist.Tokens = make(map[common.Address]DenyEntry)
+	}
+	// Entries of addresses that were taken out of the config are dropped.
+	for address, entry := range c.denylist.Pools {
+		if entry.Source == DenySourceManual && entry.Reason == manualReason {
+			delete(c.denylist.Pools, address)
+		}
+	}
+	for address, entry := range c.denylist.Tokens {
+		if entry.Source == DenySourceManual && entry.Reason == manualReason {
+			delete(c.denylist.Tokens, address)
+		}
+	}
+	for _, address := range c.config.Denylist.Pools {
+		c.denylist.Pools[common.HexToAddress(address)] = DenyEntry{Reason: manualReason, Source: DenySourceManual, Denied: true}
+	}
+	for _, address := range c.config.Denylist.Tokens {
+		c.denylist.Tokens[common.HexToAddress(address)] = DenyEntry{Reason: manualReason, Source: DenySourceManual, Denied: true}
+	}
+	if c.store != nil {
+		return c.SaveDenylist()
+	}
+	return nil
+}
+
+func (c *UniswapClient) SaveDenylist() error {
+	if c.store != nil {
+		return c.store.SaveDenylist(c.chainId.Uint64(), c.denylist)
+	}
+	file, err := json.MarshalIndent(c.denylist, "", " ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.denylistPath, file)
+}
+
+// saveDenyEntry writes the entry that changed. Without a store the whole
+// denylist file is written.
+func (c *UniswapClient) saveDenyEntry(kind string, address common.Address, entry DenyEntry) {
+	var err error
+	if c.store != nil {
+		err = c.store.SaveDenyEntry(c.chainId.Uint64(), kind, address, entry)
+	} else {
+		err = c.SaveDenylist()
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("can not save denylist")
+	}
+}
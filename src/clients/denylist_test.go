@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/config"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordTradeFailureOnlyCountsReverts(t *testing.T) {
+	weth := common.HexToAddress("0x01")
+	token := common.HexToAddress("0x02")
+	pool := common.HexToAddress("0x10")
+	tests := []struct {
+		name    string
+		err     error
+		counted bool
+	}{
+		{"revert", &RevertError{Reason: "TransferHelper: TRANSFER_FROM_FAILED"}, true},
+		{"custom error", &CustomError{Name: "SwapFailed"}, true},
+		{"out of gas", &OutOfGasError{Err: errors.New("out of gas")}, false},
+		{"panic", &PanicError{Code: big.NewInt(0x11)}, false},
+		{"insufficient output", &InsufficientOutputError{Reason: "UniswapV2: INSUFFICIENT_OUTPUT_AMOUNT"}, false},
+		{"transport", errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), false},
+		{"nonce", errors.New("nonce too low"), false},
+		{"simulation", fmt.Errorf("%w: %v", ErrSimulationFailed, errors.New("timeout")), false},
+		{"relay", ErrBundleRejected, false},
+	}
+	for _, test := range tests {
+		cfg := config.Default()
+		c := &UniswapClient{
+			config:       cfg,
+			profile:      ChainProfile{BaseTokens: []BaseToken{{Address: weth}}},
+			Pools:        map[common.Address]Pool{pool: {Address: pool, Token0: weth, Token1: token}},
+			denylist:     NewDenylist(),
+			denylistPath: filepath.Join(t.TempDir(), "denylist.json"),
+		}
+		c.recordTradeFailure(ArbitrageTx{Pools: []common.Address{pool}}, test.err)
+		entry, ok := c.denylist.Pools[pool]
+		if ok != test.counted {
+			t.Errorf("%s: counted is %v, want %v", test.name, ok, test.counted)
+		}
+		if ok && (entry.Failures != 1 || entry.Denied) {
+			t.Errorf("%s: %d failures, denied %v after one revert", test.name, entry.Failures, entry.Denied)
+		}
+	}
+}
+
+func TestRecordTradeFailureDeniesMaliciousPools(t *testing.T) {
+	pool := common.HexToAddress("0x10")
+	c := &UniswapClient{
+		config:       config.Default(),
+		Pools:        map[common.Address]Pool{},
+		denylist:     NewDenylist(),
+		denylistPath: filepath.Join(t.TempDir(), "denylist.json"),
+	}
+	c.recordTradeFailure(ArbitrageTx{}, &MaliciousPoolError{Pool: pool, Reason: "malicious pool " + pool.String()})
+	if !c.denylist.Pools[pool].Active() {
+		t.Fatal("malicious pool is not denied")
+	}
+}
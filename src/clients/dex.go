@@ -0,0 +1,135 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"mev_bot/amm"
+)
+
+const (
+	PoolTypeV2       = "v2"
+	PoolTypeV3       = "v3"
+	PoolTypeCurve    = "curve"
+	PoolTypeBalancer = "balancer"
+)
+
+type Dex struct {
+	Name     string
+	Factory  common.Address
+	Protocol string
+	// Fee is the fixed swap fee of v2 style pools in hundredths of a bip.
+	// v3 style pools report their fee tier in PoolCreated.
+	Fee             *big.Int
+	InitCodeHash    common.Hash
+	DeploymentBlock uint64
+}
+
+func dexByFactory(dexes []Dex) map[common.Address]Dex {
+	factories := make(map[common.Address]Dex)
+	for _, dex := range dexes {
+		factories[dex.Factory] = dex
+	}
+	return factories
+}
+
+// PoolAddress derives the CREATE2 address of a pool. It returns false when the
+// init code hash of the DEX is not known.
+func (d Dex) PoolAddress(token0 common.Address, token1 common.Address, fee *big.Int) (common.Address, bool) {
+	if d.InitCodeHash == (common.Hash{}) {
+		return common.Address{}, false
+	}
+	var salt []byte
+	if d.Protocol == PoolTypeV3 {
+		addressType, _ := abi.NewType("address", "", nil)
+		feeType, _ := abi.NewType("uint24", "", nil)
+		encoded, err := abi.Arguments{{Type: addressType}, {Type: addressType}, {Type: feeType}}.Pack(token0, token1, fee)
+		if err != nil {
+			return common.Address{}, false
+		}
+		salt = crypto.Keccak256(encoded)
+	} else {
+		salt = crypto.Keccak256(token0.Bytes(), token1.Bytes())
+	}
+	return crypto.CreateAddress2(d.Factory, common.BytesToHash(salt), d.InitCodeHash.Bytes()), true
+}
+
+// isDexPool checks a pool creation event against the CREATE2 address, so a
+// misconfigured factory can not inject pools of another protocol.
+func (d Dex) isDexPool(pool Pool) bool {
+	address, ok := d.PoolAddress(pool.Token0, pool.Token1, pool.Fee)
+	if !ok {
+		return true
+	}
+	return address == pool.Address
+}
+
+func (c *UniswapClient) dexOf(pool Pool) (Dex, error) {
+	for _, dex := range c.factories {
+		if dex.Name == pool.Dex {
+			return dex, nil
+		}
+	}
+	return Dex{}, fmt.Errorf("unknown dex %s", pool.Dex)
+}
+
+func (d Dex) fee() *big.Int {
+	if d.Fee == nil {
+		return amm.V2DefaultFee
+	}
+	return d.Fee
+}
+
+func initialDeploymentBlock(dexes []Dex) uint64 {
+	var block uint64
+	for i, dex := range dexes {
+		if i == 0 || dex.DeploymentBlock < block {
+			block = dex.DeploymentBlock
+		}
+	}
+	return block
+}
+
+func factoryAddresses(dexes []Dex) []common.Address {
+	addresses := []common.Address{}
+	for _, dex := range dexes {
+		addresses = append(addresses, dex.Factory)
+	}
+	return addresses
+}
+
+// legacyFactoryCursors covers state files written before the registry, which
+// only tracked the Uniswap factories.
+func legacyFactoryCursors(dexes []Dex, lastSeenBlock uint64) map[common.Address]uint64 {
+	cursors := make(map[common.Address]uint64)
+	for _, dex := range dexes {
+		if dex.Name == "uniswap-v2" || dex.Name == "uniswap-v3" {
+			cursors[dex.Factory] = lastSeenBlock
+		}
+	}
+	return cursors
+}
+
+func legacyDexName(poolType string) string {
+	if poolType == PoolTypeV3 {
+		return "uniswap-v3"
+	}
+	return "uniswap-v2"
+}
+
+func (c *UniswapClient) syncStart(dex Dex) uint64 {
+	cursor, ok := c.FactoryCursors[dex.Factory]
+	if !ok || cursor < dex.DeploymentBlock {
+		return dex.DeploymentBlock
+	}
+	return cursor
+}
+
+func (c *UniswapClient) setLastSeenBlock(blockNumber uint64) {
+	c.LastSeenBlock = blockNumber
+	for factory := range c.factories {
+		c.FactoryCursors[factory] = blockNumber
+	}
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+)
+
+func TestIsDexPool(t *testing.T) {
+	dexes := make(map[string]Dex)
+	for _, dex := range ChainProfiles[0].Dexes {
+		dexes[dex.Name] = dex
+	}
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	sushiswap := dexes["sushiswap"]
+	sushiswap.InitCodeHash = common.HexToHash("0xe18a34eb0e04b04f7a0ac29a6e80748dca96319b42c54d679cb821dca90c6303")
+	// A fork configured with the init code hash of Uniswap derives other
+	// addresses than its factory deploys.
+	misconfigured := dexes["sushiswap"]
+	misconfigured.InitCodeHash = uniswapV2InitCodeHash
+	tests := []struct {
+		name    string
+		dex     Dex
+		address string
+		fee     int64
+		isPool  bool
+	}{
+		{"uniswap v2 pair", dexes["uniswap-v2"], "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc", 3000, true},
+		{"uniswap v3 pool", dexes["uniswap-v3"], "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640", 500, true},
+		{"uniswap v3 pool of another fee", dexes["uniswap-v3"], "0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8", 3000, true},
+		{"uniswap v3 pool with the wrong fee", dexes["uniswap-v3"], "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640", 3000, false},
+		{"uniswap v2 pair of the v3 factory", dexes["uniswap-v3"], "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc", 3000, false},
+		{"sushiswap pair", sushiswap, "0x397FF1542f962076d0BFE58eA045FfA2d347ACa0", 3000, true},
+		{"sushiswap pair with the uniswap hash", misconfigured, "0x397FF1542f962076d0BFE58eA045FfA2d347ACa0", 3000, false},
+		{"uniswap pair of the sushiswap factory", sushiswap, "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc", 3000, false},
+		// Without an init code hash the pool can not be checked.
+		{"unknown init code hash", dexes["sushiswap"], "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc", 3000, true},
+	}
+	for _, test := range tests {
+		pool := Pool{Address: common.HexToAddress(test.address), Token0: usdc, Token1: weth, Fee: big.NewInt(test.fee)}
+		if test.dex.isDexPool(pool) != test.isPool {
+			t.Errorf("%s: isDexPool is %v, want %v", test.name, !test.isPool, test.isPool)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"mev_bot/config"
+)
+
+var ErrFeeCapExceeded = errors.New("fees are above the fee cap")
+
+// BaseFeeParams are the EIP-1559 parameters of a chain. Chains whose base fee
+// does not follow the gas used, like Arbitrum, leave them zero. Their next
+// base fee is taken to be the parent's, and it is doubled for headroom like
+// go-ethereum does.
+type BaseFeeParams struct {
+	ChangeDenominator    int64
+	ElasticityMultiplier int64
+}
+
+var opStackBaseFee = BaseFeeParams{ChangeDenominator: 250, ElasticityMultiplier: 6}
+
+func (p BaseFeeParams) predicts() bool {
+	return p.ChangeDenominator > 0 && p.ElasticityMultiplier > 0
+}
+
+// NextBaseFee predicts the base fee of the block after parent from the gas it
+// used, rounding like go-ethereum.
+func (p BaseFeeParams) NextBaseFee(parent *types.Header) *big.Int {
+	baseFee := new(big.Int).Set(parent.BaseFee)
+	if !p.predicts() {
+		return baseFee
+	}
+	target := parent.GasLimit / uint64(p.ElasticityMultiplier)
+	if target == 0 || parent.GasUsed == target {
+		return baseFee
+	}
+	if parent.GasUsed > target {
+		delta := new(big.Int).Mul(parent.BaseFee, new(big.Int).SetUint64(parent.GasUsed-target))
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, big.NewInt(p.ChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return baseFee.Add(baseFee, delta)
+	}
+	delta := new(big.Int).Mul(parent.BaseFee, new(big.Int).SetUint64(target-parent.GasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(p.ChangeDenominator))
+	baseFee.Sub(baseFee, delta)
+	if baseFee.Sign() == -1 {
+		baseFee.SetInt64(0)
+	}
+	return baseFee
+}
+
+// maxBaseFee is the base fee after the given number of full blocks.
+func (p BaseFeeParams) maxBaseFee(baseFee *big.Int, blocks int) *big.Int {
+	fee := new(big.Int).Set(baseFee)
+	if blocks == 0 {
+		return fee
+	}
+	if !p.predicts() {
+		return fee.Mul(fee, big.NewInt(2))
+	}
+	for i := 0; i < blocks; i++ {
+		delta := new(big.Int).Mul(fee, big.NewInt(p.ElasticityMultiplier-1))
+		delta.Div(delta, big.NewInt(p.ChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		fee.Add(fee, delta)
+	}
+	return fee
+}
+
+// FeeEstimate prices a transaction for its target block.
+type FeeEstimate struct {
+	// BaseFee is the predicted base fee of the target block.
+	BaseFee              *big.Int
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+}
+
+// Cost is what the transaction pays for its gas when it lands in the target
+// block.
+func (f FeeEstimate) Cost(gas uint64) *big.Int {
+	cost := new(big.Int).Add(f.BaseFee, f.MaxPriorityFeePerGas)
+	return cost.Mul(cost, new(big.Int).SetUint64(gas))
+}
+
+// MaxCost is the most the transaction can pay for its gas.
+func (f FeeEstimate) MaxCost(gas uint64) *big.Int {
+	return new(big.Int).Mul(f.MaxFeePerGas, new(big.Int).SetUint64(gas))
+}
+
+// latestHeader is the parent of the next block, the last processed one when
+// there is one.
+func (c *UniswapClient) latestHeader() (*types.Header, error) {
+	if c.head != nil {
+		return c.head, nil
+	}
+	return c.client.HeaderByNumber(c.ctx, nil)
+}
+
+// estimateFees prices a transaction for the block. maxFeePerGas covers
+// headroom more blocks of the steepest base fee rise after it, and is capped
+// at Fees.MaxFeePerGas.
+func (c *UniswapClient) estimateFees(block uint64, headroom int) (FeeEstimate, error) {
+	parent, err := c.latestHeader()
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	if parent.BaseFee == nil {
+		return FeeEstimate{}, errors.New("chain has no base fee")
+	}
+	params := c.profile.BaseFee
+	baseFee := params.NextBaseFee(parent)
+	next := parent.Number.Uint64() + 1
+	if block > next {
+		baseFee = params.maxBaseFee(baseFee, int(block-next))
+	}
+	tip := big.NewInt(c.config.Fees.PriorityFee)
+	if c.config.Fees.Strategy == config.FeeSuggested {
+		suggested, err := c.client.SuggestGasTipCap(c.ctx)
+		if err != nil {
+			return FeeEstimate{}, err
+		}
+		if suggested.Cmp(tip) == 1 {
+			tip = suggested
+		}
+	}
+	fees := FeeEstimate{
+		BaseFee:              baseFee,
+		MaxPriorityFeePerGas: tip,
+		MaxFeePerGas:         new(big.Int).Add(params.maxBaseFee(baseFee, headroom), tip),
+	}
+	feeCap := big.NewInt(c.config.Fees.MaxFeePerGas)
+	if new(big.Int).Add(baseFee, tip).Cmp(feeCap) == 1 {
+		return fees, fmt.Errorf("%w: base fee %s and priority fee %s", ErrFeeCapExceeded, baseFee, tip)
+	}
+	if fees.MaxFeePerGas.Cmp(feeCap) == 1 {
+		fees.MaxFeePerGas = feeCap
+	}
+	return fees, nil
+}
@@ -0,0 +1,158 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"mev_bot/config"
+	"testing"
+)
+
+var mainnetBaseFee = BaseFeeParams{ChangeDenominator: 8, ElasticityMultiplier: 2}
+
+func feeHeader(number int64, baseFee int64, gasLimit uint64, gasUsed uint64) *types.Header {
+	return &types.Header{Number: big.NewInt(number), BaseFee: big.NewInt(baseFee), GasLimit: gasLimit, GasUsed: gasUsed}
+}
+
+func TestNextBaseFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   BaseFeeParams
+		parent   *types.Header
+		expected int64
+	}{
+		// The vectors of go-ethereum's TestCalcBaseFee.
+		{"at target", mainnetBaseFee, feeHeader(32, 1000000000, 20000000, 10000000), 1000000000},
+		{"below target", mainnetBaseFee, feeHeader(32, 1000000000, 20000000, 9000000), 987500000},
+		{"above target", mainnetBaseFee, feeHeader(32, 1000000000, 20000000, 11000000), 1012500000},
+		{"empty block", mainnetBaseFee, feeHeader(32, 1000000000, 30000000, 0), 875000000},
+		{"full block", mainnetBaseFee, feeHeader(32, 1000000000, 30000000, 30000000), 1125000000},
+		// A rise rounding to zero still raises the base fee by one wei.
+		{"smallest rise", mainnetBaseFee, feeHeader(32, 7, 30000000, 15000001), 8},
+		// The OP stack targets a sixth of the gas limit and moves slower.
+		{"op stack at target", opStackBaseFee, feeHeader(32, 1000000000, 30000000, 5000000), 1000000000},
+		{"op stack empty block", opStackBaseFee, feeHeader(32, 1000000000, 30000000, 0), 996000000},
+		{"op stack full block", opStackBaseFee, feeHeader(32, 1000000000, 30000000, 30000000), 1020000000},
+		{"op stack above target", opStackBaseFee, feeHeader(32, 1000000000, 30000000, 6000000), 1000800000},
+		{"without params", BaseFeeParams{}, feeHeader(32, 1000000000, 30000000, 30000000), 1000000000},
+	}
+	for _, test := range tests {
+		baseFee := test.params.NextBaseFee(test.parent)
+		if baseFee.Cmp(big.NewInt(test.expected)) != 0 {
+			t.Errorf("%s: got %s, want %d", test.name, baseFee, test.expected)
+		}
+	}
+}
+
+func TestMaxBaseFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   BaseFeeParams
+		baseFee  int64
+		blocks   int
+		expected int64
+	}{
+		{"no blocks", mainnetBaseFee, 1000000000, 0, 1000000000},
+		{"one block", mainnetBaseFee, 1000000000, 1, 1125000000},
+		// Every block rounds down like go-ethereum does.
+		{"three blocks", mainnetBaseFee, 1000000000, 3, 1423828125},
+		{"four blocks", mainnetBaseFee, 1000000000, 4, 1601806640},
+		{"one wei", mainnetBaseFee, 1, 2, 3},
+		{"op stack", opStackBaseFee, 1000000000, 2, 1040400000},
+		{"without params", BaseFeeParams{}, 1000000000, 3, 2000000000},
+		{"without params or blocks", BaseFeeParams{}, 1000000000, 0, 1000000000},
+	}
+	for _, test := range tests {
+		baseFee := test.params.maxBaseFee(big.NewInt(test.baseFee), test.blocks)
+		if baseFee.Cmp(big.NewInt(test.expected)) != 0 {
+			t.Errorf("%s: got %s, want %d", test.name, baseFee, test.expected)
+		}
+	}
+	// The steepest rise is the one of a chain of full blocks.
+	fee := big.NewInt(1000000000)
+	for i := 0; i < 4; i++ {
+		fee = mainnetBaseFee.NextBaseFee(&types.Header{BaseFee: fee, GasLimit: 30000000, GasUsed: 30000000})
+	}
+	if fee.Cmp(mainnetBaseFee.maxBaseFee(big.NewInt(1000000000), 4)) != 0 {
+		t.Fatalf("four full blocks raise the base fee to %s", fee)
+	}
+}
+
+// tipService answers eth_maxPriorityFeePerGas.
+type tipService struct {
+	tip *big.Int
+}
+
+func (s *tipService) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(s.tip)
+}
+
+func TestEstimateFees(t *testing.T) {
+	server := rpc.NewServer()
+	service := &tipService{}
+	err := server.RegisterName("eth", service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	// The head at 100 was full, so block 101 pays 1.125 gwei.
+	head := feeHeader(100, 1000000000, 30000000, 30000000)
+	tests := []struct {
+		name         string
+		strategy     string
+		suggested    int64
+		maxFeePerGas int64
+		block        uint64
+		headroom     int
+		baseFee      int64
+		tip          int64
+		maxFee       int64
+		err          error
+	}{
+		{"next block", config.FeeFixed, 0, 500000000000, 101, 0, 1125000000, 1000000000, 2125000000, nil},
+		{"later block", config.FeeFixed, 0, 500000000000, 102, 0, 1265625000, 1000000000, 2265625000, nil},
+		{"headroom", config.FeeFixed, 0, 500000000000, 101, 2, 1125000000, 1000000000, 2423828125, nil},
+		{"headroom above the cap", config.FeeFixed, 0, 2500000000, 101, 3, 1125000000, 1000000000, 2500000000, nil},
+		{"above the cap", config.FeeFixed, 0, 2000000000, 101, 0, 1125000000, 1000000000, 2125000000, ErrFeeCapExceeded},
+		{"suggested tip", config.FeeSuggested, 3000000000, 500000000000, 101, 0, 1125000000, 3000000000, 4125000000, nil},
+		{"suggested tip below the priority fee", config.FeeSuggested, 100, 500000000000, 101, 0, 1125000000, 1000000000, 2125000000, nil},
+		{"suggested tip above the cap", config.FeeSuggested, 3000000000, 4000000000, 101, 0, 1125000000, 3000000000, 4125000000, ErrFeeCapExceeded},
+	}
+	for _, test := range tests {
+		service.tip = big.NewInt(test.suggested)
+		c := &UniswapClient{
+			ctx:     context.Background(),
+			client:  ethclient.NewClient(rpc.DialInProc(server)),
+			config:  config.Default(),
+			profile: ChainProfile{BaseFee: mainnetBaseFee},
+			head:    head,
+		}
+		c.config.Fees.Strategy = test.strategy
+		c.config.Fees.PriorityFee = 1000000000
+		c.config.Fees.MaxFeePerGas = test.maxFeePerGas
+		fees, err := c.estimateFees(test.block, test.headroom)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if test.err != nil {
+			continue
+		}
+		if fees.BaseFee.Int64() != test.baseFee || fees.MaxPriorityFeePerGas.Int64() != test.tip || fees.MaxFeePerGas.Int64() != test.maxFee {
+			t.Errorf("%s: got %s + %s up to %s, want %d + %d up to %d", test.name, fees.BaseFee, fees.MaxPriorityFeePerGas, fees.MaxFeePerGas, test.baseFee, test.tip, test.maxFee)
+		}
+		if fees.Cost(100).Int64() != (test.baseFee+test.tip)*100 || fees.MaxCost(100).Int64() != test.maxFee*100 {
+			t.Errorf("%s: costs %s and %s for 100 gas", test.name, fees.Cost(100), fees.MaxCost(100))
+		}
+	}
+
+	c := &UniswapClient{config: config.Default(), head: &types.Header{Number: big.NewInt(100)}}
+	_, err = c.estimateFees(101, 0)
+	if err == nil {
+		t.Fatal("priced a block of a chain without a base fee")
+	}
+}
@@ -0,0 +1,259 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math"
+	"math/big"
+	"mev_bot/amm"
+	"strings"
+	"time"
+)
+
+type Path struct {
+	Pools  []common.Address
+	Tokens []common.Address
+}
+
+func (p Path) String() string {
+	addresses := []string{}
+	for _, address := range p.Pools {
+		addresses = append(addresses, address.String())
+	}
+	return strings.Join(addresses, "->")
+}
+
+type pairKey struct {
+	tokenA common.Address
+	tokenB common.Address
+}
+
+func newPairKey(tokenA common.Address, tokenB common.Address) pairKey {
+	if strings.Compare(tokenA.Hex(), tokenB.Hex()) == 1 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	return pairKey{tokenA: tokenA, tokenB: tokenB}
+}
+
+type poolEdge struct {
+	Address common.Address
+	Token0  common.Address
+	Token1  common.Address
+}
+
+func (e poolEdge) otherToken(token common.Address) common.Address {
+	if e.Token0 == token {
+		return e.Token1
+	}
+	return e.Token0
+}
+
+// poolEdges returns an edge for every pair of tokens the pool trades, which is
+// one edge for Uniswap pools and one per coin pair for Curve pools.
+func poolEdges(pool Pool) []poolEdge {
+	coins := pool.coins()
+	edges := []poolEdge{}
+	for i := 0; i < len(coins); i++ {
+		for j := i + 1; j < len(coins); j++ {
+			edges = append(edges, poolEdge{Address: pool.Address, Token0: coins[i], Token1: coins[j]})
+		}
+	}
+	return edges
+}
+
+type TokenGraph struct {
+	adjacency map[common.Address]map[poolEdge]bool
+	pairs     map[pairKey]map[poolEdge]bool
+}
+
+func NewTokenGraph(pools []Pool) *TokenGraph {
+	graph := &TokenGraph{
+		adjacency: make(map[common.Address]map[poolEdge]bool),
+		pairs:     make(map[pairKey]map[poolEdge]bool),
+	}
+	for _, pool := range pools {
+		graph.add(pool)
+	}
+	return graph
+}
+
+func (g *TokenGraph) add(pool Pool) {
+	for _, edge := range poolEdges(pool) {
+		for _, token := range []common.Address{edge.Token0, edge.Token1} {
+			_, ok := g.adjacency[token]
+			if !ok {
+				g.adjacency[token] = make(map[poolEdge]bool)
+			}
+			g.adjacency[token][edge] = true
+		}
+		key := newPairKey(edge.Token0, edge.Token1)
+		_, ok := g.pairs[key]
+		if !ok {
+			g.pairs[key] = make(map[poolEdge]bool)
+		}
+		g.pairs[key][edge] = true
+	}
+}
+
+func (g *TokenGraph) remove(pool Pool) {
+	for _, edge := range poolEdges(pool) {
+		for _, token := range []common.Address{edge.Token0, edge.Token1} {
+			delete(g.adjacency[token], edge)
+			if len(g.adjacency[token]) == 0 {
+				delete(g.adjacency, token)
+			}
+		}
+		key := newPairKey(edge.Token0, edge.Token1)
+		delete(g.pairs[key], edge)
+		if len(g.pairs[key]) == 0 {
+			delete(g.pairs, key)
+		}
+	}
+}
+
+// logRate is the log of the marginal exchange rate after fees, so a cycle is
+// profitable at the margin when the rates of its hops sum to more than zero.
+func (p Pool) logRate(tokenIn common.Address, tokenOut common.Address) (float64, bool) {
+	var price *big.Float
+	var fee *big.Int
+	if p.Type == PoolTypeV3 && p.V3 != nil && p.V3.SqrtPriceX96 != nil {
+		sqrtPrice := new(big.Float).Quo(new(big.Float).SetInt(p.V3.SqrtPriceX96), new(big.Float).SetInt(amm.Q96))
+		price = new(big.Float).Mul(sqrtPrice, sqrtPrice)
+		if p.Token1 == tokenIn {
+			price = new(big.Float).Quo(big.NewFloat(1), price)
+		}
+		fee = p.Fee
+	} else if p.Type == PoolTypeV2 && p.Reserve0 != nil && p.Reserve1 != nil {
+		reserveIn, reserveOut, _ := p.reservesFor(tokenIn)
+		if reserveIn.Sign() != 1 || reserveOut.Sign() != 1 {
+			return 0, false
+		}
+		price = new(big.Float).Quo(new(big.Float).SetInt(reserveOut), new(big.Float).SetInt(reserveIn))
+		fee = p.v2Fee()
+	} else if (p.Type == PoolTypeCurve || p.Type == PoolTypeBalancer) && canQuoteLocally([]Pool{p}) {
+		// Curve and Balancer pools have no closed form price, so the rate of
+		// a trade of a millionth of the balance stands in for the margin.
+		amountIn := new(big.Int).Div(p.reserveOf(tokenIn), big.NewInt(1000000))
+		if amountIn.Sign() != 1 {
+			return 0, false
+		}
+		amountOut := p.quoteHop(tokenIn, tokenOut, amountIn)
+		if amountOut.Sign() != 1 {
+			return 0, false
+		}
+		price = new(big.Float).Quo(new(big.Float).SetInt(amountOut), new(big.Float).SetInt(amountIn))
+		fee = big.NewInt(0)
+	} else {
+		return 0, false
+	}
+	if price.Sign() != 1 || fee == nil {
+		return 0, false
+	}
+	mantissa := new(big.Float)
+	exponent := price.MantExp(mantissa)
+	value, _ := mantissa.Float64()
+	feeMultiplier := 1 - float64(fee.Int64())/float64(amm.FeeDenominator.Int64())
+	return math.Log(value) + float64(exponent)*math.Ln2 + math.Log(feeMultiplier), true
+}
+
+type pathSearch struct {
+	graph    *TokenGraph
+	deadline time.Time
+	expired  bool
+}
+
+func (s *pathSearch) walk(current common.Address, target common.Address, remaining int, used map[common.Address]bool, visited map[common.Address]bool, hops []poolEdge, tokens []common.Address, emit func([]poolEdge, []common.Address)) {
+	if s.expired || time.Now().After(s.deadline) {
+		s.expired = true
+		return
+	}
+	if current == target {
+		emit(hops, tokens)
+		return
+	}
+	if remaining == 0 {
+		return
+	}
+	for edge := range s.graph.pairs[newPairKey(current, target)] {
+		if used[edge.Address] {
+			continue
+		}
+		emit(append(hops[:len(hops):len(hops)], edge), append(tokens[:len(tokens):len(tokens)], target))
+	}
+	if remaining == 1 {
+		return
+	}
+	for edge := range s.graph.adjacency[current] {
+		next := edge.otherToken(current)
+		if used[edge.Address] || visited[next] || next == target {
+			continue
+		}
+		used[edge.Address] = true
+		visited[next] = true
+		s.walk(next, target, remaining-1, used, visited, append(hops[:len(hops):len(hops)], edge), append(tokens[:len(tokens):len(tokens)], next), emit)
+		delete(used, edge.Address)
+		delete(visited, next)
+		if s.expired {
+			return
+		}
+	}
+}
+
+// cyclesThrough finds the cycles from base back to base, at most maxDepth hops
+// long, that trade through pool in either direction.
+func (s *pathSearch) cyclesThrough(pool poolEdge, base common.Address, maxDepth int, emit func(Path)) {
+	for _, tokenIn := range []common.Address{pool.Token0, pool.Token1} {
+		tokenOut := pool.otherToken(tokenIn)
+		used := map[common.Address]bool{pool.Address: true}
+		visited := map[common.Address]bool{tokenIn: true, tokenOut: true, base: true}
+		s.walk(base, tokenIn, maxDepth-1, used, visited, []poolEdge{}, []common.Address{base}, func(prefix []poolEdge, prefixTokens []common.Address) {
+			addedPools := []common.Address{}
+			for _, hop := range prefix {
+				if !used[hop.Address] {
+					used[hop.Address] = true
+					addedPools = append(addedPools, hop.Address)
+				}
+			}
+			addedTokens := []common.Address{}
+			for _, token := range prefixTokens {
+				if !visited[token] {
+					visited[token] = true
+					addedTokens = append(addedTokens, token)
+				}
+			}
+			s.walk(tokenOut, base, maxDepth-1-len(prefix), used, visited, []poolEdge{}, []common.Address{tokenOut}, func(suffix []poolEdge, suffixTokens []common.Address) {
+				if len(prefix)+len(suffix) == 0 {
+					return
+				}
+				path := Path{
+					Tokens: append(append([]common.Address{}, prefixTokens...), suffixTokens...),
+				}
+				for _, hop := range prefix {
+					path.Pools = append(path.Pools, hop.Address)
+				}
+				path.Pools = append(path.Pools, pool.Address)
+				for _, hop := range suffix {
+					path.Pools = append(path.Pools, hop.Address)
+				}
+				emit(path)
+			})
+			for _, address := range addedPools {
+				delete(used, address)
+			}
+			for _, token := range addedTokens {
+				delete(visited, token)
+			}
+		})
+	}
+}
+
+func (c *UniswapClient) isProfitableAtMargin(path Path, view *poolView) bool {
+	total := 0.0
+	for i, address := range path.Pools {
+		rate, ok := view.pool(address).logRate(path.Tokens[i], path.Tokens[i+1])
+		if !ok {
+			return true
+		}
+		total += rate
+	}
+	return total > 0
+}
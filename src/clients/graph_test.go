@@ -0,0 +1,142 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// searchGraph has the base token a and tokens b, c and d, with two pools on
+// a-b:
+//
+//	a -p1,p2- b -p3- c -p4- a
+//	a -p6- d -p7- b, c -p5- d
+func searchGraph() (*TokenGraph, map[string]poolEdge, map[common.Address]string, common.Address) {
+	a := common.HexToAddress("0x0a")
+	b := common.HexToAddress("0x0b")
+	c := common.HexToAddress("0x0c")
+	d := common.HexToAddress("0x0d")
+	pairs := map[string][2]common.Address{
+		"p1": {a, b},
+		"p2": {a, b},
+		"p3": {b, c},
+		"p4": {a, c},
+		"p5": {c, d},
+		"p6": {a, d},
+		"p7": {b, d},
+	}
+	edges := make(map[string]poolEdge)
+	names := make(map[common.Address]string)
+	pools := []Pool{}
+	for name, pair := range pairs {
+		address := common.BigToAddress(new(big.Int).SetBytes([]byte(name)))
+		pool := Pool{Address: address, Token0: pair[0], Token1: pair[1], Type: PoolTypeV2}
+		pools = append(pools, pool)
+		edges[name] = poolEdges(pool)[0]
+		names[address] = name
+	}
+	return NewTokenGraph(pools), edges, names, a
+}
+
+func cyclesNamed(t *testing.T, graph *TokenGraph, edge poolEdge, names map[common.Address]string, base common.Address, maxDepth int) []string {
+	t.Helper()
+	search := pathSearch{graph: graph, deadline: time.Now().Add(time.Minute)}
+	cycles := []string{}
+	search.cyclesThrough(edge, base, maxDepth, func(path Path) {
+		if len(path.Tokens) != len(path.Pools)+1 || path.Tokens[0] != base || path.Tokens[len(path.Tokens)-1] != base {
+			t.Fatalf("%v does not go from the base back to it", path.Tokens)
+		}
+		hops := []string{}
+		for k, address := range path.Pools {
+			if !tradesPair(graph.pairs[newPairKey(path.Tokens[k], path.Tokens[k+1])], address) {
+				t.Fatalf("%s does not trade %s to %s", names[address], path.Tokens[k], path.Tokens[k+1])
+			}
+			hops = append(hops, names[address])
+		}
+		cycles = append(cycles, strings.Join(hops, " "))
+	})
+	if search.expired {
+		t.Fatal("search ran out of time")
+	}
+	sort.Strings(cycles)
+	return cycles
+}
+
+func tradesPair(edges map[poolEdge]bool, address common.Address) bool {
+	for edge := range edges {
+		if edge.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCyclesThrough(t *testing.T) {
+	graph, edges, names, base := searchGraph()
+	tests := []struct {
+		pool     string
+		maxDepth int
+		cycles   []string
+	}{
+		{"p1", 1, []string{}},
+		{"p1", 2, []string{"p1 p2", "p2 p1"}},
+		{"p1", 3, []string{"p1 p2", "p1 p3 p4", "p1 p7 p6", "p2 p1", "p4 p3 p1", "p6 p7 p1"}},
+		{"p1", 4, []string{
+			"p1 p2", "p1 p3 p4", "p1 p3 p5 p6", "p1 p7 p5 p4", "p1 p7 p6",
+			"p2 p1", "p4 p3 p1", "p4 p5 p7 p1", "p6 p5 p3 p1", "p6 p7 p1",
+		}},
+		// A pool away from the base needs a hop on each side.
+		{"p3", 2, []string{}},
+		{"p3", 3, []string{"p1 p3 p4", "p2 p3 p4", "p4 p3 p1", "p4 p3 p2"}},
+		{"p3", 4, []string{
+			"p1 p3 p4", "p1 p3 p5 p6", "p2 p3 p4", "p2 p3 p5 p6",
+			"p4 p3 p1", "p4 p3 p2", "p4 p3 p7 p6", "p6 p5 p3 p1", "p6 p5 p3 p2", "p6 p7 p3 p4",
+		}},
+	}
+	for _, test := range tests {
+		cycles := cyclesNamed(t, graph, edges[test.pool], names, base, test.maxDepth)
+		if strings.Join(cycles, ", ") != strings.Join(test.cycles, ", ") {
+			t.Errorf("%s at depth %d: got %v, want %v", test.pool, test.maxDepth, cycles, test.cycles)
+		}
+	}
+}
+
+// Every cycle is found once in each direction, whichever side of the pool
+// the search starts from.
+func TestCyclesThroughFindsEachDirectionOnce(t *testing.T) {
+	graph, edges, names, base := searchGraph()
+	for name, edge := range edges {
+		cycles := cyclesNamed(t, graph, edge, names, base, 4)
+		seen := make(map[string]bool)
+		for _, cycle := range cycles {
+			if seen[cycle] {
+				t.Fatalf("%s: %s found twice", name, cycle)
+			}
+			seen[cycle] = true
+		}
+		for _, cycle := range cycles {
+			hops := strings.Split(cycle, " ")
+			for i, j := 0, len(hops)-1; i < j; i, j = i+1, j-1 {
+				hops[i], hops[j] = hops[j], hops[i]
+			}
+			if !seen[strings.Join(hops, " ")] {
+				t.Errorf("%s: %s is only found in one direction", name, cycle)
+			}
+		}
+	}
+}
+
+func TestCyclesThroughStopsAtTheDeadline(t *testing.T) {
+	graph, edges, _, base := searchGraph()
+	search := pathSearch{graph: graph, deadline: time.Now().Add(-time.Second)}
+	found := 0
+	search.cyclesThrough(edges["p1"], base, 4, func(Path) {
+		found++
+	})
+	if !search.expired || found != 0 {
+		t.Fatalf("expired %v after %d cycles", search.expired, found)
+	}
+}
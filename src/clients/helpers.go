@@ -1,32 +1,33 @@
 package clients
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rs/zerolog/log"
 	"math/big"
+	"mev_bot/amm"
 	"mev_bot/contracts"
-	"net/http"
-	"os"
-	"strings"
-	"time"
+	"sort"
 )
 
-func GetV2Pools(res chan<- []Pool, contract *contracts.IEvents, currentBlockNumber uint64, latestSyncBlock uint64) {
-	log.Info().Msg("Started getting pools for v2")
+func GetV2Pools(res chan<- []Pool, contract *contracts.IEvents, dex Dex, currentBlockNumber uint64, latestSyncBlock uint64, blockRange uint64) {
+	log.Info().Str("dex", dex.Name).Msg("Started getting pools for v2")
 	pools := []Pool{}
 	ch := make(chan []Pool)
 	callCount := 0
-	for i := latestSyncBlock; i < currentBlockNumber; i += 10000 {
+	for i := latestSyncBlock; i < currentBlockNumber; i += blockRange {
 		callCount += 1
 		i := i
 		go func(ch chan<- []Pool) {
-			if i+10000 < currentBlockNumber {
-				getV2Pool(contract, i, i+9999, ch)
+			if i+blockRange < currentBlockNumber {
+				getV2Pool(contract, dex, i, i+blockRange-1, ch)
 			} else {
-				getV2Pool(contract, i, currentBlockNumber, ch)
+				getV2Pool(contract, dex, i, currentBlockNumber, ch)
 			}
 		}(ch)
 	}
@@ -36,25 +37,25 @@ func GetV2Pools(res chan<- []Pool, contract *contracts.IEvents, currentBlockNumb
 			pools = append(pools, foundPairs...)
 		}
 	}
-	log.Info().Msg("Finished getting pools for v2")
+	log.Info().Str("dex", dex.Name).Msg("Finished getting pools for v2")
 	res <- pools
 }
 
-func GetV3Pools(res chan<- []Pool, contract *contracts.IEvents, currentBlockNumber uint64, latestSyncBlock uint64) {
-	log.Info().Msg("Started getting pools for v3")
+func GetV3Pools(res chan<- []Pool, contract *contracts.IEvents, dex Dex, currentBlockNumber uint64, latestSyncBlock uint64, blockRange uint64) {
+	log.Info().Str("dex", dex.Name).Msg("Started getting pools for v3")
 
 	pairs := []Pool{}
 	ch := make(chan []Pool)
 	callCount := 0
 
-	for i := latestSyncBlock; i < currentBlockNumber; i += 10000 {
+	for i := latestSyncBlock; i < currentBlockNumber; i += blockRange {
 		callCount += 1
 		i := i
 		go func(ch chan<- []Pool) {
-			if i+10000 < currentBlockNumber {
-				getV3Pool(contract, i, i+9999, ch)
+			if i+blockRange < currentBlockNumber {
+				getV3Pool(contract, dex, i, i+blockRange-1, ch)
 			} else {
-				getV3Pool(contract, i, currentBlockNumber, ch)
+				getV3Pool(contract, dex, i, currentBlockNumber, ch)
 			}
 		}(ch)
 	}
@@ -64,11 +65,60 @@ func GetV3Pools(res chan<- []Pool, contract *contracts.IEvents, currentBlockNumb
 			pairs = append(pairs, foundPairs...)
 		}
 	}
-	log.Info().Msg("Finished getting pools for v3")
+	log.Info().Str("dex", dex.Name).Msg("Finished getting pools for v3")
 	res <- pairs
 }
 
-func getV3Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64, ch chan<- []Pool) {
+func GetV3Ticks(res chan<- []types.Log, client *ethclient.Client, currentBlockNumber uint64, latestSyncBlock uint64, blockRange uint64) {
+	log.Info().Msg("Started getting ticks for v3")
+	eventsAbi, err := contracts.IEventsMetaData.GetAbi()
+	if err != nil {
+		log.Fatal().Err(err).Msg("can not parse events abi")
+	}
+	topics := [][]common.Hash{{eventsAbi.Events["Mint"].ID, eventsAbi.Events["Burn"].ID}}
+	logs := []types.Log{}
+	ch := make(chan []types.Log)
+	callCount := 0
+	for i := latestSyncBlock; i < currentBlockNumber; i += blockRange {
+		callCount += 1
+		i := i
+		go func(ch chan<- []types.Log) {
+			if i+blockRange < currentBlockNumber {
+				getV3Tick(client, topics, i, i+blockRange-1, ch)
+			} else {
+				getV3Tick(client, topics, i, currentBlockNumber, ch)
+			}
+		}(ch)
+	}
+	for i := 0; i < callCount; i++ {
+		foundLogs := <-ch
+		if len(foundLogs) > 0 {
+			logs = append(logs, foundLogs...)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	log.Info().Int("totalLogs", len(logs)).Msg("Finished getting ticks for v3")
+	res <- logs
+}
+
+func getV3Tick(client *ethclient.Client, topics [][]common.Hash, startIndex uint64, endIndex uint64, ch chan<- []types.Log) {
+	logs, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startIndex),
+		ToBlock:   new(big.Int).SetUint64(endIndex),
+		Topics:    topics,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("can not get v3 ticks")
+	}
+	ch <- logs
+}
+
+func getV3Pool(contract *contracts.IEvents, dex Dex, startIndex uint64, endIndex uint64, ch chan<- []Pool) {
 	pools := []Pool{}
 	filter := bind.FilterOpts{
 		Start: startIndex,
@@ -85,10 +135,15 @@ func getV3Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64,
 				Token1:  logs.Event.Token1,
 				Address: logs.Event.Pool,
 				Fee:     logs.Event.Fee,
-				Type:    "v3",
+				Type:    PoolTypeV3,
+				Dex:     dex.Name,
 				Enabled: true,
+				V3:      amm.NewV3State(int(logs.Event.TickSpacing.Int64())),
+			}
+			pool.V3.Synced = true
+			if dex.isDexPool(pool) {
+				pools = append(pools, pool)
 			}
-			pools = append(pools, pool)
 		}
 		ok := logs.Next()
 		if !ok {
@@ -98,7 +153,7 @@ func getV3Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64,
 	ch <- pools
 }
 
-func getV2Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64, ch chan<- []Pool) {
+func getV2Pool(contract *contracts.IEvents, dex Dex, startIndex uint64, endIndex uint64, ch chan<- []Pool) {
 	pools := []Pool{}
 	filter := bind.FilterOpts{
 		Start: startIndex,
@@ -114,10 +169,14 @@ func getV2Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64,
 				Token0:  logs.Event.Token0,
 				Token1:  logs.Event.Token1,
 				Address: logs.Event.Pair,
-				Type:    "v2",
+				Fee:     dex.fee(),
+				Type:    PoolTypeV2,
+				Dex:     dex.Name,
 				Enabled: true,
 			}
-			pools = append(pools, pool)
+			if dex.isDexPool(pool) {
+				pools = append(pools, pool)
+			}
 		}
 		ok := logs.Next()
 		if !ok {
@@ -127,113 +186,136 @@ func getV2Pool(contract *contracts.IEvents, startIndex uint64, endIndex uint64,
 	ch <- pools
 }
 
-type ArbitrageTx struct {
-	Path               string
-	BorrowTokenAddress common.Address
-	BorrowAmount       *big.Int
-	Pools              []common.Address
-	Types              []*big.Int
-	AmountOut          *big.Int
-	Ratio              *big.Float
-	Profit             *big.Int
-	Valid              bool
-}
-
-type Token struct {
-	Symbol          string `json:"symbol"`
-	ContractAddress string `json:"contract_address"`
-	Rank            int    `json:"rank"`
-}
-
-type TokenPreview struct {
-	ID     int    `json:"id"`
-	Symbol string `json:"symbol"`
-	Rank   int    `json:"cmc_rank"`
-}
-
-type TokenContractPlatform struct {
-	Name string `json:"name"`
-}
-
-type TokenContractDetail struct {
-	ContractAddress string                `json:"contract_address"`
-	Platform        TokenContractPlatform `json:"platform"`
-}
-
-type TokenDetail struct {
-	ID                int                   `json:"id"`
-	ContractAddresses []TokenContractDetail `json:"contract_address"`
-}
-
-type TokenListRes struct {
-	Status map[string]interface{} `json:"status"`
-	Data   []TokenPreview         `json:"data"`
-}
-
-type TokenDetailRes struct {
-	Status map[string]interface{}   `json:"status"`
-	Data   map[string][]TokenDetail `json:"data"`
+func GetBalancerPools(res chan<- []Pool, vault *contracts.IBalancerVault, dex Dex, currentBlockNumber uint64, latestSyncBlock uint64, blockRange uint64) {
+	log.Info().Str("dex", dex.Name).Msg("Started getting pools for balancer")
+	pools := []Pool{}
+	ch := make(chan []Pool)
+	callCount := 0
+	for i := latestSyncBlock; i < currentBlockNumber; i += blockRange {
+		callCount += 1
+		i := i
+		go func(ch chan<- []Pool) {
+			if i+blockRange < currentBlockNumber {
+				getBalancerPool(vault, dex, i, i+blockRange-1, ch)
+			} else {
+				getBalancerPool(vault, dex, i, currentBlockNumber, ch)
+			}
+		}(ch)
+	}
+	for i := 0; i < callCount; i++ {
+		foundPools := <-ch
+		if len(foundPools) > 0 {
+			pools = append(pools, foundPools...)
+		}
+	}
+	log.Info().Str("dex", dex.Name).Msg("Finished getting pools for balancer")
+	res <- pools
 }
 
-func RetrieveTopTokens() error {
-	tokens := []Token{}
-	wd, err := os.Getwd()
+func getBalancerPool(vault *contracts.IBalancerVault, dex Dex, startIndex uint64, endIndex uint64, ch chan<- []Pool) {
+	pools := []Pool{}
+	filter := bind.FilterOpts{
+		Start: startIndex,
+		End:   &endIndex,
+	}
+	logs, err := vault.FilterPoolRegistered(&filter, nil, nil)
 	if err != nil {
-		return err
+		log.Fatal().Err(err).Msg("can not get balancer pools")
+	}
+	for {
+		if logs.Event != nil {
+			pools = append(pools, newBalancerPool(dex, logs.Event.PoolId, logs.Event.PoolAddress))
+		}
+		ok := logs.Next()
+		if !ok {
+			break
+		}
 	}
-	filePath := wd + "/data/tokens.json"
-	url := "https://pro-api.coinmarketcap.com/v1/cryptocurrency/listings/latest?limit=500&sort=volume_24h&sort_dir=desc&aux=cmc_rank&CMC_PRO_API_KEY=bcb382b5-95b2-4efd-87a3-1e6fc80f2d13"
-	resp, err := http.Get(url)
-	var tokenListRes TokenListRes
-	err = json.NewDecoder(resp.Body).Decode(&tokenListRes)
+	ch <- pools
+}
+
+func GetCurvePools(res chan<- []Pool, registry *contracts.ICurveRegistry, dex Dex, knownPools map[common.Address]bool) {
+	log.Info().Str("dex", dex.Name).Msg("Started getting pools for curve")
+	pools := []Pool{}
+	poolCount, err := registry.PoolCount(nil)
 	if err != nil {
-		return err
+		log.Fatal().Err(err).Msg("can not get curve pool count")
 	}
-	for index, preview := range tokenListRes.Data {
-		if strings.Contains(preview.Symbol, ".") {
-			continue
+	for i := int64(0); i < poolCount.Int64(); i++ {
+		address, err := registry.PoolList(nil, big.NewInt(i))
+		if err != nil {
+			log.Fatal().Err(err).Msg("can not get curve pools")
 		}
-		if preview.Symbol == "ETH" {
+		if knownPools[address] {
 			continue
 		}
-		log.Info().Interface("preview", preview).Int("index", index).Msg("preview info")
-		secondUrl := fmt.Sprintf("https://pro-api.coinmarketcap.com/v2/cryptocurrency/info?symbol=%s&CMC_PRO_API_KEY=bcb382b5-95b2-4efd-87a3-1e6fc80f2d13", preview.Symbol)
-		var tokenDetailRes TokenDetailRes
-		for {
-			resp, err := http.Get(secondUrl)
-			err = json.NewDecoder(resp.Body).Decode(&tokenDetailRes)
-			if err != nil {
-				return err
-			}
-			if tokenDetailRes.Status["error_code"].(float64) == float64(0) {
-				break
-			} else {
-				time.Sleep(1 * time.Minute)
-			}
+		pool, err := getCurvePool(registry, dex, address)
+		if err != nil {
+			log.Info().Err(err).Str("pool", address.String()).Msg("can not get curve pool")
+			continue
 		}
-		info := tokenDetailRes.Data[preview.Symbol]
-		log.Info().Interface("detail", tokenDetailRes).Msg("detail info")
-		for _, detail := range info {
-			if detail.ID != preview.ID {
-				continue
-			}
-			for _, address := range detail.ContractAddresses {
-				log.Info().Interface("address", address).Msg("address info")
-				if address.Platform.Name != "Ethereum" {
-					continue
-				}
-				tokens = append(tokens, Token{
-					Symbol:          preview.Symbol,
-					Rank:            preview.Rank,
-					ContractAddress: address.ContractAddress,
-				})
-			}
+		pools = append(pools, pool)
+	}
+	log.Info().Str("dex", dex.Name).Msg("Finished getting pools for curve")
+	res <- pools
+}
+
+func getCurvePool(registry *contracts.ICurveRegistry, dex Dex, address common.Address) (Pool, error) {
+	coins, err := registry.GetCoins(nil, address)
+	if err != nil {
+		return Pool{}, err
+	}
+	decimals, err := registry.GetDecimals(nil, address)
+	if err != nil {
+		return Pool{}, err
+	}
+	pool := Pool{
+		Address: address,
+		Type:    PoolTypeCurve,
+		Dex:     dex.Name,
+		Enabled: true,
+		Curve:   &amm.StableSwapState{},
+	}
+	for i, coin := range coins {
+		if coin == AddressZero {
+			break
 		}
+		pool.Coins = append(pool.Coins, coin)
+		pool.Curve.Rates = append(pool.Curve.Rates, amm.StableSwapRate(decimals[i].Int64()))
+	}
+	if len(pool.Coins) < 2 {
+		return Pool{}, errors.New("curve pool has less than two coins")
 	}
-	file, err := json.MarshalIndent(tokens, "", " ")
+	pool.Token0 = pool.Coins[0]
+	pool.Token1 = pool.Coins[1]
+	balances, err := registry.GetBalances(nil, address)
 	if err != nil {
-		return err
+		return Pool{}, err
 	}
-	err = os.WriteFile(filePath, file, 0644)
-	return nil
+	amp, err := registry.GetA(nil, address)
+	if err != nil {
+		return Pool{}, err
+	}
+	fees, err := registry.GetFees(nil, address)
+	if err != nil {
+		return Pool{}, err
+	}
+	pool.setCurveState(balances[:len(pool.Coins)], amp, fees[0])
+	return pool, nil
+}
+
+type ArbitrageTx struct {
+	Path               string
+	BorrowTokenAddress common.Address
+	BorrowAmount       *big.Int
+	Pools              []common.Address
+	Types              []*big.Int
+	AmountOut          *big.Int
+	Ratio              *big.Float
+	Profit             *big.Int
+	ProfitETH          *big.Int
+	Valid              bool
+	Optimizer          string
+	GridBorrowAmount   *big.Int
+	GridProfit         *big.Int
 }
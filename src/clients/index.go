@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type cycleKey struct {
+	pool common.Address
+	base common.Address
+}
+
+// PoolIndex keeps the token graph of enabled pools and the cycles each pool
+// takes part in up to date as pools are added, enabled or disabled, so path
+// finding only has to look up the pools a block touched.
+type PoolIndex struct {
+	graph   *TokenGraph
+	edges   map[common.Address][]poolEdge
+	cycles  map[cycleKey][]Path
+	members map[common.Address]map[cycleKey]bool
+	bases   map[common.Address]bool
+	// pending holds the pools added since the last lookup, whose cycles are
+	// not in the cached cycles of the other pools yet.
+	pending  []common.Address
+	maxDepth int
+}
+
+func NewPoolIndex(pools map[common.Address]Pool, maxDepth int) *PoolIndex {
+	index := &PoolIndex{
+		graph:    NewTokenGraph(nil),
+		edges:    make(map[common.Address][]poolEdge),
+		cycles:   make(map[cycleKey][]Path),
+		members:  make(map[common.Address]map[cycleKey]bool),
+		bases:    make(map[common.Address]bool),
+		maxDepth: maxDepth,
+	}
+	for _, pool := range pools {
+		index.Update(pool)
+	}
+	return index
+}
+
+// Update adds the pool to the graph when it is enabled and removes it when
+// it is not. Pools the contract can not swap on are never added, since paths
+// through them can not be traded.
+func (i *PoolIndex) Update(pool Pool) {
+	_, indexed := i.edges[pool.Address]
+	tradable := pool.Enabled && contractCanSwap(pool)
+	if tradable && !indexed {
+		edges := poolEdges(pool)
+		i.edges[pool.Address] = edges
+		i.graph.add(pool)
+		if len(i.cycles) != 0 {
+			i.pending = append(i.pending, pool.Address)
+		}
+	}
+	if !tradable && indexed {
+		delete(i.edges, pool.Address)
+		i.graph.remove(pool)
+		i.removeCyclesThrough(pool.Address)
+		for k, address := range i.pending {
+			if address == pool.Address {
+				i.pending = append(i.pending[:k], i.pending[k+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (i *PoolIndex) store(key cycleKey, path Path) {
+	i.cycles[key] = append(i.cycles[key], path)
+	for _, address := range path.Pools {
+		_, ok := i.members[address]
+		if !ok {
+			i.members[address] = make(map[cycleKey]bool)
+		}
+		i.members[address][key] = true
+	}
+}
+
+// addPendingCycles adds the cycles through the pools added since the last
+// lookup to the cached cycles of the pools they go through. The pools are
+// searched one after the other, so a cycle through two new pools is added by
+// the search of the first. The cycles of a pool whose search runs out of time
+// are dropped: the pool finds them when it is looked up itself, but the pools
+// cached before go without them.
+func (i *PoolIndex) addPendingCycles(deadline time.Time) {
+	searched := make(map[common.Address]bool)
+	for _, address := range i.pending {
+		search := pathSearch{graph: i.graph, deadline: deadline}
+		found := []cycleKey{}
+		paths := []Path{}
+		for base := range i.bases {
+			for _, edge := range i.edges[address] {
+				search.cyclesThrough(edge, base, i.maxDepth, func(path Path) {
+					for _, pool := range path.Pools {
+						if searched[pool] {
+							return
+						}
+					}
+					for _, pool := range path.Pools {
+						key := cycleKey{pool: pool, base: base}
+						_, ok := i.cycles[key]
+						if ok {
+							found = append(found, key)
+							paths = append(paths, path)
+						}
+					}
+				})
+			}
+		}
+		if search.expired {
+			log.Info().Str("pool", address.String()).Msg("cycle search expired")
+			continue
+		}
+		for k, key := range found {
+			i.store(key, paths[k])
+		}
+		searched[address] = true
+	}
+	i.pending = nil
+}
+
+func (i *PoolIndex) removeCyclesThrough(address common.Address) {
+	for key := range i.members[address] {
+		cached, ok := i.cycles[key]
+		if !ok {
+			continue
+		}
+		paths := []Path{}
+		for _, path := range cached {
+			if !path.contains(address) {
+				paths = append(paths, path)
+			}
+		}
+		if key.pool == address {
+			delete(i.cycles, key)
+			continue
+		}
+		i.cycles[key] = paths
+	}
+	delete(i.members, address)
+}
+
+// CyclesOf returns the cycles from base through the pool, searching and
+// caching them on first use. Searches that run out of budget are not cached.
+func (i *PoolIndex) CyclesOf(address common.Address, base common.Address, deadline time.Time) ([]Path, bool) {
+	i.addPendingCycles(deadline)
+	key := cycleKey{pool: address, base: base}
+	paths, ok := i.cycles[key]
+	if ok {
+		return paths, true
+	}
+	edges, ok := i.edges[address]
+	if !ok {
+		return nil, true
+	}
+	search := pathSearch{graph: i.graph, deadline: deadline}
+	paths = []Path{}
+	for _, edge := range edges {
+		search.cyclesThrough(edge, base, i.maxDepth, func(path Path) {
+			paths = append(paths, path)
+		})
+	}
+	if search.expired {
+		return paths, false
+	}
+	i.bases[base] = true
+	i.cycles[key] = []Path{}
+	for _, path := range paths {
+		i.store(key, path)
+	}
+	return paths, true
+}
+
+func (p Path) contains(address common.Address) bool {
+	for _, pool := range p.Pools {
+		if pool == address {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The contract can only swap on Uniswap style pools, so other pools are kept
+// out of the graph and no cycle goes through them.
+func TestIndexLeavesOutPoolsTheContractCanNotSwap(t *testing.T) {
+	weth := common.HexToAddress("0x01")
+	usdc := common.HexToAddress("0x02")
+	v2 := Pool{Address: common.HexToAddress("0x10"), Token0: weth, Token1: usdc, Type: PoolTypeV2, Enabled: true, Reserve0: big.NewInt(1e18), Reserve1: big.NewInt(2e18)}
+	for _, poolType := range []string{PoolTypeCurve, PoolTypeBalancer} {
+		other := Pool{Address: common.HexToAddress("0x11"), Token0: weth, Token1: usdc, Coins: []common.Address{weth, usdc}, Type: poolType, Enabled: true}
+		index := NewPoolIndex(map[common.Address]Pool{v2.Address: v2, other.Address: other}, 3)
+		if _, ok := index.edges[other.Address]; ok {
+			t.Fatalf("%s pool was indexed", poolType)
+		}
+		if len(index.graph.pairs[newPairKey(weth, usdc)]) != 1 {
+			t.Fatalf("%s pool is in the graph", poolType)
+		}
+		cycles, _ := index.CyclesOf(v2.Address, weth, time.Now().Add(time.Second))
+		if len(cycles) != 0 {
+			t.Fatalf("found %v through the %s pool", cycles, poolType)
+		}
+	}
+}
+
+func cycleSet(paths []Path) map[string]bool {
+	set := make(map[string]bool)
+	for _, path := range paths {
+		set[fmt.Sprint(path.Pools, path.Tokens)] = true
+	}
+	return set
+}
+
+// indexPools returns v2 pools between five tokens, several of them on the
+// same pair.
+func indexPools() ([]common.Address, []Pool) {
+	tokens := []common.Address{}
+	for k := 1; k <= 5; k++ {
+		tokens = append(tokens, common.BigToAddress(big.NewInt(int64(k))))
+	}
+	pairs := [][2]int{{0, 1}, {0, 1}, {0, 2}, {1, 2}, {1, 3}, {2, 3}, {0, 3}, {3, 4}, {0, 4}, {2, 4}, {1, 4}, {0, 2}}
+	pools := []Pool{}
+	for k, pair := range pairs {
+		pools = append(pools, Pool{
+			Address:  common.BigToAddress(big.NewInt(int64(0x100 + k))),
+			Token0:   tokens[pair[0]],
+			Token1:   tokens[pair[1]],
+			Type:     PoolTypeV2,
+			Enabled:  true,
+			Reserve0: big.NewInt(1e18),
+			Reserve1: big.NewInt(1e18),
+		})
+	}
+	return tokens, pools
+}
+
+func TestIndexUpdatesMatchAFreshIndex(t *testing.T) {
+	tokens, pools := indexPools()
+	bases := tokens[:2]
+	deadline := time.Now().Add(time.Minute)
+	initial := make(map[common.Address]Pool)
+	for _, pool := range pools[:6] {
+		initial[pool.Address] = pool
+	}
+	index := NewPoolIndex(initial, 3)
+	lookUp := func(index *PoolIndex) {
+		for _, pool := range pools {
+			for _, base := range bases {
+				_, complete := index.CyclesOf(pool.Address, base, deadline)
+				if !complete {
+					t.Fatal("search ran out of time")
+				}
+			}
+		}
+	}
+	// Cache every pool, then enable the rest and disable two, one of them
+	// while it is still pending.
+	lookUp(index)
+	final := make(map[common.Address]Pool)
+	for k, pool := range pools {
+		if k >= 6 {
+			index.Update(pool)
+		}
+		if k == 2 || k == 9 {
+			pool.Enabled = false
+			index.Update(pool)
+		}
+		final[pool.Address] = pool
+	}
+	fresh := NewPoolIndex(final, 3)
+	for _, pool := range pools {
+		for _, base := range bases {
+			got, _ := index.CyclesOf(pool.Address, base, deadline)
+			want, _ := fresh.CyclesOf(pool.Address, base, deadline)
+			if !reflect.DeepEqual(cycleSet(got), cycleSet(want)) {
+				t.Errorf("pool %s base %s: got %v, want %v", pool.Address, base, got, want)
+			}
+			if len(got) != len(cycleSet(got)) {
+				t.Errorf("pool %s base %s: duplicate cycles in %v", pool.Address, base, got)
+			}
+		}
+	}
+	// Enabling a pool again after the lookups extends the cached cycles.
+	index.Update(pools[2])
+	final[pools[2].Address] = pools[2]
+	fresh = NewPoolIndex(final, 3)
+	for _, pool := range pools {
+		for _, base := range bases {
+			got, _ := index.CyclesOf(pool.Address, base, deadline)
+			want, _ := fresh.CyclesOf(pool.Address, base, deadline)
+			if !reflect.DeepEqual(cycleSet(got), cycleSet(want)) {
+				t.Errorf("pool %s base %s after enabling: got %v, want %v", pool.Address, base, got, want)
+			}
+		}
+	}
+}
+
+// A pool whose search runs out of time loses its own cycles only, the cycles
+// cached for the other pools stay.
+func TestIndexExpiredSearchKeepsTheOtherCycles(t *testing.T) {
+	tokens, pools := indexPools()
+	base := tokens[0]
+	index := NewPoolIndex(map[common.Address]Pool{pools[0].Address: pools[0], pools[2].Address: pools[2], pools[3].Address: pools[3]}, 3)
+	cached, _ := index.CyclesOf(pools[0].Address, base, time.Now().Add(time.Minute))
+	if len(cached) == 0 {
+		t.Fatal("no cycles through the first pool")
+	}
+	index.Update(pools[1])
+	got, complete := index.CyclesOf(pools[0].Address, base, time.Now().Add(-time.Second))
+	if !complete || !reflect.DeepEqual(cycleSet(got), cycleSet(cached)) {
+		t.Fatalf("cached cycles changed to %v", got)
+	}
+	if len(index.pending) != 0 {
+		t.Fatal("the expired pool is still pending")
+	}
+	cycles, complete := index.CyclesOf(pools[1].Address, base, time.Now().Add(time.Minute))
+	if !complete || len(cycles) == 0 {
+		t.Fatal("the new pool did not find its cycles on its own lookup")
+	}
+}
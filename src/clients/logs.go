@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"mev_bot/contracts"
+)
+
+var (
+	blockLogTopics = eventTopics()
+	// factoryEvents only parses logs, so it needs no backend.
+	factoryEvents, _ = contracts.NewIEventsFilterer(common.Address{}, nil)
+)
+
+// eventTopics lists the events ResolveLogs handles: the pool events of every
+// protocol and the events of the factories, registries and the Vault.
+func eventTopics() []common.Hash {
+	topics := []common.Hash{}
+	seen := make(map[common.Hash]bool)
+	add := func(topic common.Hash) {
+		if !seen[topic] {
+			seen[topic] = true
+			topics = append(topics, topic)
+		}
+	}
+	for _, metaData := range []*bind.MetaData{contracts.IEventsMetaData, contracts.ICurveRegistryMetaData, contracts.IBalancerVaultMetaData} {
+		contractAbi, err := metaData.GetAbi()
+		if err != nil {
+			panic(err)
+		}
+		for _, event := range contractAbi.Events {
+			add(event.ID)
+		}
+	}
+	for topic := range curveEventTopics {
+		add(topic)
+	}
+	for topic := range balancerEventTopics {
+		add(topic)
+	}
+	return topics
+}
+
+// fetchLogs returns the logs of the blocks after LastSeenBlock up to the
+// header, filtered by topic. A single block is fetched by its hash, so its
+// logs can not come from a block that was reorged away.
+func (c *UniswapClient) fetchLogs(header *types.Header) ([]types.Log, error) {
+	from := c.LastSeenBlock + 1
+	to := header.Number.Uint64()
+	if from > to {
+		return nil, nil
+	}
+	if from == to {
+		hash := header.Hash()
+		logs, err := c.client.FilterLogs(c.ctx, ethereum.FilterQuery{
+			BlockHash: &hash,
+			Topics:    [][]common.Hash{blockLogTopics},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackedLogs(logs, make(map[common.Address]bool)), nil
+	}
+	logs := []types.Log{}
+	created := make(map[common.Address]bool)
+	step := c.config.Batches.LogBlocks
+	for start := from; start <= to; start += step {
+		end := start + step - 1
+		if end > to {
+			end = to
+		}
+		partLogs, err := c.client.FilterLogs(c.ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Topics:    [][]common.Hash{blockLogTopics},
+		})
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, c.trackedLogs(partLogs, created)...)
+	}
+	return logs, nil
+}
+
+// trackedLogs drops the logs of pools the bot does not trade. Logs of the
+// factories and the Vault are kept, they add pools or name the pool. A pool
+// created earlier in the logs, or in an earlier batch of the same range,
+// keeps its logs too: a v3 pool is often created, initialized and
+// minted in one transaction, and its Mint logs are all of its liquidity.
+func (c *UniswapClient) trackedLogs(logs []types.Log, created map[common.Address]bool) []types.Log {
+	tracked := []types.Log{}
+	for _, blockLog := range logs {
+		_, ok := c.factories[blockLog.Address]
+		if ok {
+			tracked = append(tracked, blockLog)
+			pool, ok := createdPool(blockLog)
+			if ok {
+				created[pool] = true
+			}
+			continue
+		}
+		pool, ok := c.Pools[blockLog.Address]
+		if (ok && pool.Enabled) || created[blockLog.Address] {
+			tracked = append(tracked, blockLog)
+		}
+	}
+	return tracked
+}
+
+// createdPool returns the pool a PoolCreated or PairCreated log adds.
+func createdPool(blockLog types.Log) (common.Address, bool) {
+	poolCreated, err := factoryEvents.ParsePoolCreated(blockLog)
+	if err == nil {
+		return poolCreated.Pool, true
+	}
+	pairCreated, err := factoryEvents.ParsePairCreated(blockLog)
+	if err == nil {
+		return pairCreated.Pair, true
+	}
+	return common.Address{}, false
+}
@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestTrackedLogsKeepThePoolsCreatedInTheBatch(t *testing.T) {
+	v3Factory := common.HexToAddress("0xf1")
+	v2Factory := common.HexToAddress("0xf2")
+	tracked := common.HexToAddress("0x10")
+	disabled := common.HexToAddress("0x11")
+	created := common.HexToAddress("0x12")
+	pair := common.HexToAddress("0x13")
+	unknown := common.HexToAddress("0x14")
+	token0 := common.HexToAddress("0x01")
+	token1 := common.HexToAddress("0x02")
+	owner := common.HexToAddress("0xaa")
+	c := &UniswapClient{
+		factories: map[common.Address]Dex{
+			v3Factory: {Name: "v3", Protocol: PoolTypeV3, Factory: v3Factory},
+			v2Factory: {Name: "v2", Protocol: PoolTypeV2, Factory: v2Factory},
+		},
+		Pools: map[common.Address]Pool{
+			tracked:  {Address: tracked, Type: PoolTypeV2, Enabled: true},
+			disabled: {Address: disabled, Type: PoolTypeV2},
+		},
+	}
+	mint := func(pool common.Address, index uint) types.Log {
+		return eventLog(t, pool, index, "Mint", []interface{}{owner, big.NewInt(-60), big.NewInt(60)}, owner, tokens(1), tokens(1), tokens(1))
+	}
+	sync := func(pool common.Address, index uint) types.Log {
+		return eventLog(t, pool, index, "Sync", nil, tokens(1), tokens(1))
+	}
+	// The Mint of the new pool before its PoolCreated can not happen, but
+	// shows that only later logs are kept.
+	first := []types.Log{
+		mint(created, 0),
+		eventLog(t, v3Factory, 1, "PoolCreated", []interface{}{token0, token1, big.NewInt(3000)}, big.NewInt(60), created),
+		mint(created, 2),
+		sync(tracked, 3),
+		sync(disabled, 4),
+		mint(unknown, 5),
+		eventLog(t, v2Factory, 6, "PairCreated", []interface{}{token0, token1}, pair, big.NewInt(1)),
+	}
+	second := []types.Log{
+		sync(pair, 0),
+		mint(created, 1),
+		mint(unknown, 2),
+	}
+	createdPools := make(map[common.Address]bool)
+	kept := c.trackedLogs(first, createdPools)
+	kept = append(kept, c.trackedLogs(second, createdPools)...)
+	want := []types.Log{first[1], first[2], first[3], first[6], second[0], second[1]}
+	if len(kept) != len(want) {
+		t.Fatalf("kept %d logs, want %d", len(kept), len(want))
+	}
+	for i := range want {
+		if kept[i].Address != want[i].Address || kept[i].Index != want[i].Index {
+			t.Errorf("log %d is %s #%d, want %s #%d", i, kept[i].Address, kept[i].Index, want[i].Address, want[i].Index)
+		}
+	}
+}
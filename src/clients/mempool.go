@@ -0,0 +1,470 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/contracts"
+	"reflect"
+)
+
+const pendingChannelSize = 4096
+
+// Universal Router commands, which take the lower six bits of a command byte.
+const (
+	universalCommandMask    = 0x3f
+	universalV3SwapExactIn  = 0x00
+	universalV3SwapExactOut = 0x01
+	universalV2SwapExactIn  = 0x08
+	universalV2SwapExactOut = 0x09
+	universalSwapArgsCount  = 5
+)
+
+// A v3 path is the token in followed by the fee and token out of every hop.
+const (
+	v3PathTokenLength = 20
+	v3PathFeeLength   = 3
+	v3PathHopLength   = v3PathTokenLength + v3PathFeeLength
+	minV3PathLength   = v3PathTokenLength + v3PathHopLength
+)
+
+var (
+	routerAbis = loadAbis(contracts.IUniswapV2Router02MetaData, contracts.ISwapRouterMetaData, contracts.ISwapRouter02MetaData, contracts.IUniversalRouterMetaData)
+	// The Universal Router swaps the whole balance it holds for this amount,
+	// SwapRouter02 for an amount of zero.
+	universalContractBalance = new(big.Int).Lsh(big.NewInt(1), 255)
+	universalV3SwapArgs      = newArguments("address", "uint256", "uint256", "bytes", "bool")
+	universalV2SwapArgs      = newArguments("address", "uint256", "uint256", "address[]", "bool")
+)
+
+// Router is a swap router whose pending transactions are decoded. V2Dex and
+// V3Dex name the dexes its v2 and v3 swaps go through, empty when it has none.
+type Router struct {
+	Address common.Address
+	V2Dex   string
+	V3Dex   string
+}
+
+// routerSwap is a swap through a router along Tokens. Fees holds the fee tier
+// of every hop of a v3 swap and is nil for v2 swaps.
+type routerSwap struct {
+	Dex      string
+	Tokens   []common.Address
+	Fees     []*big.Int
+	Amount   *big.Int
+	ExactOut bool
+}
+
+// BackrunCandidate is an arbitrage that is profitable once Target is mined,
+// to be placed right behind it in Block.
+type BackrunCandidate struct {
+	Target *types.Transaction
+	Tx     ArbitrageTx
+	Block  uint64
+}
+
+// poolView is a copy-on-write view of the pools. Reads fall through to the
+// pools of the client and writes only change the view.
+type poolView struct {
+	pools   map[common.Address]Pool
+	changed map[common.Address]Pool
+}
+
+func (c *UniswapClient) newPoolView() *poolView {
+	return &poolView{pools: c.Pools, changed: make(map[common.Address]Pool)}
+}
+
+func (v *poolView) pool(address common.Address) Pool {
+	pool, ok := v.changed[address]
+	if ok {
+		return pool
+	}
+	return v.pools[address]
+}
+
+func (v *poolView) set(pool Pool) {
+	v.changed[pool.Address] = pool
+}
+
+// pending reports whether the view holds swaps the chain does not have yet.
+func (v *poolView) pending() bool {
+	return len(v.changed) > 0
+}
+
+func loadAbis(metaData ...*bind.MetaData) []*abi.ABI {
+	abis := []*abi.ABI{}
+	for _, data := range metaData {
+		contractAbi, err := data.GetAbi()
+		if err != nil {
+			panic(err)
+		}
+		abis = append(abis, contractAbi)
+	}
+	return abis
+}
+
+func newArguments(kinds ...string) abi.Arguments {
+	arguments := abi.Arguments{}
+	for _, kind := range kinds {
+		argumentType, err := abi.NewType(kind, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		arguments = append(arguments, abi.Argument{Type: argumentType})
+	}
+	return arguments
+}
+
+// tupleField reads a field of a decoded struct argument.
+func tupleField(tuple interface{}, name string) interface{} {
+	value := reflect.ValueOf(tuple)
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.FieldByName(name)
+	if !field.IsValid() {
+		return nil
+	}
+	return field.Interface()
+}
+
+// decodeV3Path splits an encoded v3 path into its tokens and the fee of each
+// hop.
+func decodeV3Path(path []byte) ([]common.Address, []*big.Int, bool) {
+	if len(path) < minV3PathLength || (len(path)-v3PathTokenLength)%v3PathHopLength != 0 {
+		return nil, nil, false
+	}
+	tokens := []common.Address{common.BytesToAddress(path[:v3PathTokenLength])}
+	fees := []*big.Int{}
+	for i := v3PathTokenLength; i < len(path); i += v3PathHopLength {
+		fees = append(fees, new(big.Int).SetBytes(path[i:i+v3PathFeeLength]))
+		tokens = append(tokens, common.BytesToAddress(path[i+v3PathFeeLength:i+v3PathHopLength]))
+	}
+	return tokens, fees, true
+}
+
+// newRouterSwap builds the swap of a decoded call. Exact out v3 paths are
+// encoded from the token out, so they are reversed into swap order. Amounts
+// taken from the balance of the router are assumed to be the ETH sent along.
+func newRouterSwap(dex string, tokens []common.Address, fees []*big.Int, amount *big.Int, exactOut bool, value *big.Int, reversed bool) []routerSwap {
+	if dex == "" || len(tokens) < 2 || amount == nil {
+		return nil
+	}
+	if !exactOut && (amount.Sign() == 0 || amount.Cmp(universalContractBalance) == 0) {
+		amount = value
+	}
+	if amount.Sign() != 1 {
+		return nil
+	}
+	if reversed {
+		swapTokens := []common.Address{}
+		for i := len(tokens) - 1; i >= 0; i-- {
+			swapTokens = append(swapTokens, tokens[i])
+		}
+		swapFees := []*big.Int{}
+		for i := len(fees) - 1; i >= 0; i-- {
+			swapFees = append(swapFees, fees[i])
+		}
+		tokens, fees = swapTokens, swapFees
+	}
+	return []routerSwap{{Dex: dex, Tokens: tokens, Fees: fees, Amount: amount, ExactOut: exactOut}}
+}
+
+// decodeRouterCall returns the swaps of a call to one of the routers, looking
+// into multicalls and Universal Router commands.
+func decodeRouterCall(router Router, data []byte, value *big.Int) []routerSwap {
+	if len(data) < 4 {
+		return nil
+	}
+	for _, routerAbi := range routerAbis {
+		method, err := routerAbi.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		args := make(map[string]interface{})
+		err = method.Inputs.UnpackIntoMap(args, data[4:])
+		if err != nil {
+			return nil
+		}
+		return decodeRouterMethod(router, method.RawName, args, value)
+	}
+	return nil
+}
+
+func decodeRouterMethod(router Router, name string, args map[string]interface{}, value *big.Int) []routerSwap {
+	switch name {
+	case "multicall":
+		calls, _ := args["data"].([][]byte)
+		swaps := []routerSwap{}
+		for _, call := range calls {
+			swaps = append(swaps, decodeRouterCall(router, call, value)...)
+		}
+		return swaps
+	case "execute":
+		commands, _ := args["commands"].([]byte)
+		inputs, _ := args["inputs"].([][]byte)
+		return decodeUniversalCommands(router, commands, inputs, value)
+	case "exactInputSingle", "exactOutputSingle":
+		params := args["params"]
+		tokenIn, _ := tupleField(params, "TokenIn").(common.Address)
+		tokenOut, _ := tupleField(params, "TokenOut").(common.Address)
+		fee, _ := tupleField(params, "Fee").(*big.Int)
+		tokens := []common.Address{tokenIn, tokenOut}
+		if name == "exactInputSingle" {
+			amountIn, _ := tupleField(params, "AmountIn").(*big.Int)
+			return newRouterSwap(router.V3Dex, tokens, []*big.Int{fee}, amountIn, false, value, false)
+		}
+		amountOut, _ := tupleField(params, "AmountOut").(*big.Int)
+		return newRouterSwap(router.V3Dex, tokens, []*big.Int{fee}, amountOut, true, value, false)
+	case "exactInput", "exactOutput":
+		params := args["params"]
+		path, _ := tupleField(params, "Path").([]byte)
+		tokens, fees, ok := decodeV3Path(path)
+		if !ok {
+			return nil
+		}
+		if name == "exactInput" {
+			amountIn, _ := tupleField(params, "AmountIn").(*big.Int)
+			return newRouterSwap(router.V3Dex, tokens, fees, amountIn, false, value, false)
+		}
+		amountOut, _ := tupleField(params, "AmountOut").(*big.Int)
+		return newRouterSwap(router.V3Dex, tokens, fees, amountOut, true, value, true)
+	}
+	// What is left are the v2 swaps, which take the ETH sent when they have
+	// neither amountIn nor amountOut.
+	path, ok := args["path"].([]common.Address)
+	if !ok {
+		return nil
+	}
+	amountIn, ok := args["amountIn"].(*big.Int)
+	if ok {
+		return newRouterSwap(router.V2Dex, path, nil, amountIn, false, value, false)
+	}
+	amountOut, ok := args["amountOut"].(*big.Int)
+	if ok {
+		return newRouterSwap(router.V2Dex, path, nil, amountOut, true, value, false)
+	}
+	return newRouterSwap(router.V2Dex, path, nil, value, false, value, false)
+}
+
+func decodeUniversalCommands(router Router, commands []byte, inputs [][]byte, value *big.Int) []routerSwap {
+	swaps := []routerSwap{}
+	for i, command := range commands {
+		if i >= len(inputs) {
+			break
+		}
+		command &= universalCommandMask
+		switch command {
+		case universalV3SwapExactIn, universalV3SwapExactOut:
+			args, err := universalV3SwapArgs.Unpack(inputs[i])
+			if err != nil || len(args) != universalSwapArgsCount {
+				continue
+			}
+			amount, _ := args[1].(*big.Int)
+			path, _ := args[3].([]byte)
+			tokens, fees, ok := decodeV3Path(path)
+			if !ok {
+				continue
+			}
+			exactOut := command == universalV3SwapExactOut
+			swaps = append(swaps, newRouterSwap(router.V3Dex, tokens, fees, amount, exactOut, value, exactOut)...)
+		case universalV2SwapExactIn, universalV2SwapExactOut:
+			args, err := universalV2SwapArgs.Unpack(inputs[i])
+			if err != nil || len(args) != universalSwapArgsCount {
+				continue
+			}
+			amount, _ := args[1].(*big.Int)
+			path, _ := args[3].([]common.Address)
+			swaps = append(swaps, newRouterSwap(router.V2Dex, path, nil, amount, command == universalV2SwapExactOut, value, false)...)
+		}
+	}
+	return swaps
+}
+
+// routedPool returns the pool of the dex a router swaps tokenIn for tokenOut
+// through. v3 hops also have to match the fee tier.
+func (c *UniswapClient) routedPool(view *poolView, dex string, tokenIn common.Address, tokenOut common.Address, fee *big.Int) (Pool, bool) {
+	for edge := range c.index.graph.pairs[newPairKey(tokenIn, tokenOut)] {
+		pool := view.pool(edge.Address)
+		if pool.Dex != dex || !pool.Enabled {
+			continue
+		}
+		if pool.Type != PoolTypeV2 && pool.Type != PoolTypeV3 {
+			continue
+		}
+		if fee != nil && (pool.Fee == nil || pool.Fee.Cmp(fee) != 0) {
+			continue
+		}
+		return pool, true
+	}
+	return Pool{}, false
+}
+
+// swapped returns the pool after a swap of amountIn and the amount out.
+func (p Pool) swapped(tokenIn common.Address, amountIn *big.Int) (Pool, *big.Int, bool) {
+	if p.Type == PoolTypeV3 {
+		if p.V3 == nil || p.Reserve0 == nil || p.Reserve1 == nil {
+			return p, nil, false
+		}
+		zeroForOne := p.Token0 == tokenIn
+		amount0, amount1, state, err := p.V3.Swap(zeroForOne, amountIn, p.Fee)
+		if err != nil {
+			return p, nil, false
+		}
+		p.V3 = state
+		p.Reserve0 = new(big.Int).Add(p.Reserve0, amount0)
+		p.Reserve1 = new(big.Int).Add(p.Reserve1, amount1)
+		if zeroForOne {
+			return p, new(big.Int).Neg(amount1), true
+		}
+		return p, new(big.Int).Neg(amount0), true
+	}
+	reserveIn, reserveOut, _ := p.reservesFor(tokenIn)
+	if reserveIn == nil || reserveOut == nil {
+		return p, nil, false
+	}
+	amountOut := amm.GetAmountOut(amountIn, reserveIn, reserveOut, p.v2Fee())
+	if amountOut.Sign() != 1 {
+		return p, nil, false
+	}
+	reserveIn = new(big.Int).Add(reserveIn, amountIn)
+	reserveOut = new(big.Int).Sub(reserveOut, amountOut)
+	if p.Token0 == tokenIn {
+		p.Reserve0, p.Reserve1 = reserveIn, reserveOut
+	} else {
+		p.Reserve0, p.Reserve1 = reserveOut, reserveIn
+	}
+	return p, amountOut, true
+}
+
+// amountInFor returns how much of tokenIn the pool takes for amountOut.
+func (p Pool) amountInFor(tokenIn common.Address, amountOut *big.Int) (*big.Int, bool) {
+	if p.Type == PoolTypeV3 {
+		if p.V3 == nil {
+			return nil, false
+		}
+		zeroForOne := p.Token0 == tokenIn
+		amount0, amount1, _, err := p.V3.Swap(zeroForOne, new(big.Int).Neg(amountOut), p.Fee)
+		if err != nil {
+			return nil, false
+		}
+		if zeroForOne {
+			return amount0, amount0.Sign() == 1
+		}
+		return amount1, amount1.Sign() == 1
+	}
+	reserveIn, reserveOut, _ := p.reservesFor(tokenIn)
+	if reserveIn == nil || reserveOut == nil {
+		return nil, false
+	}
+	amountIn := amm.GetAmountIn(amountOut, reserveIn, reserveOut, p.v2Fee())
+	return amountIn, amountIn != nil
+}
+
+// applySwap applies the swap to the view. An exact in swap is applied up to the first hop through a pool the bot does
+// not track. An exact out swap needs every pool to work out its amount in.
+func (c *UniswapClient) applySwap(view *poolView, swap routerSwap) {
+	fee := func(hop int) *big.Int {
+		if swap.Fees == nil {
+			return nil
+		}
+		return swap.Fees[hop]
+	}
+	amount := swap.Amount
+	if swap.ExactOut {
+		for i := len(swap.Tokens) - 2; i >= 0; i-- {
+			pool, ok := c.routedPool(view, swap.Dex, swap.Tokens[i], swap.Tokens[i+1], fee(i))
+			if !ok {
+				return
+			}
+			amount, ok = pool.amountInFor(swap.Tokens[i], amount)
+			if !ok {
+				return
+			}
+		}
+	}
+	for i := 0; i < len(swap.Tokens)-1; i++ {
+		pool, ok := c.routedPool(view, swap.Dex, swap.Tokens[i], swap.Tokens[i+1], fee(i))
+		if !ok {
+			break
+		}
+		pool, amount, ok = pool.swapped(swap.Tokens[i], amount)
+		if !ok {
+			break
+		}
+		view.set(pool)
+	}
+}
+
+func (c *UniswapClient) router(address common.Address) (Router, bool) {
+	for _, router := range c.profile.Routers {
+		if router.Address == address {
+			return router, true
+		}
+	}
+	return Router{}, false
+}
+
+// watchMempool subscribes to the full pending transactions of the websocket
+// node. When the mempool is not watched the subscription stays idle.
+func (c *UniswapClient) watchMempool(txCh chan *types.Transaction) (ethereum.Subscription, error) {
+	if !c.config.Mempool.Enabled || len(c.profile.Routers) == 0 {
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			<-quit
+			return nil
+		}), nil
+	}
+	sub, err := gethclient.New(c.wsClient.Client()).SubscribeFullPendingTransactions(c.ctx, txCh)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// handlePendingTx applies the router swaps of a pending transaction to a view
+// of the pools and looks for arbitrage on the pools they moved. It runs on
+// the loop that processes blocks, so its path search has a budget of its own.
+func (c *UniswapClient) handlePendingTx(tx *types.Transaction) {
+	if tx.To() == nil {
+		return
+	}
+	router, ok := c.router(*tx.To())
+	if !ok {
+		return
+	}
+	view := c.newPoolView()
+	for _, swap := range decodeRouterCall(router, tx.Data(), tx.Value()) {
+		c.applySwap(view, swap)
+	}
+	if !view.pending() {
+		return
+	}
+	touched := []Pool{}
+	for _, pool := range view.changed {
+		touched = append(touched, pool)
+	}
+	paths := c.FindPaths(touched, view, c.config.Mempool.PathSearchBudget)
+	for _, arbitrageTx := range c.calculateOutcomes(paths, view) {
+		if !arbitrageTx.Valid {
+			continue
+		}
+		c.handleBackrun(BackrunCandidate{Target: tx, Tx: arbitrageTx, Block: c.LastSeenBlock + 1})
+	}
+}
+
+// handleBackrun bundles the candidate behind its target. Without builders
+// the candidate can not be placed and is only logged.
+func (c *UniswapClient) handleBackrun(candidate BackrunCandidate) {
+	log.Info().Str("target", candidate.Target.Hash().String()).Uint64("block", candidate.Block).Str("path", candidate.Tx.Path).Str("profitETH", candidate.Tx.ProfitETH.String()).Msg("backrun candidate")
+	if c.relay == nil {
+		return
+	}
+	c.trade(candidate.Tx, candidate.Target, candidate.Block)
+}
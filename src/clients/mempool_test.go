@@ -0,0 +1,246 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/contracts"
+	"testing"
+)
+
+var (
+	testWeth   = common.HexToAddress("0x01")
+	testUsdc   = common.HexToAddress("0x02")
+	testDai    = common.HexToAddress("0x03")
+	testRouter = Router{Address: common.HexToAddress("0x0e"), V2Dex: "v2", V3Dex: "v3"}
+)
+
+// calldata packs a router call. Overloaded methods are told apart by the
+// arguments they can pack.
+func calldata(t *testing.T, routerAbi *abi.ABI, name string, args ...interface{}) []byte {
+	t.Helper()
+	for _, method := range routerAbi.Methods {
+		if method.RawName != name || len(method.Inputs) != len(args) {
+			continue
+		}
+		packed, err := method.Inputs.Pack(args...)
+		if err != nil {
+			continue
+		}
+		return append(append([]byte{}, method.ID...), packed...)
+	}
+	t.Fatalf("no %s takes %v", name, args)
+	return nil
+}
+
+// encodeV3Path encodes the tokens and fees of a v3 path in the given order.
+func encodeV3Path(tokens []common.Address, fees []int64) []byte {
+	path := append([]byte{}, tokens[0].Bytes()...)
+	for i, fee := range fees {
+		path = append(path, big.NewInt(fee).FillBytes(make([]byte, v3PathFeeLength))...)
+		path = append(path, tokens[i+1].Bytes()...)
+	}
+	return path
+}
+
+func TestDecodeV3Path(t *testing.T) {
+	tokens, fees, ok := decodeV3Path(encodeV3Path([]common.Address{testWeth, testUsdc, testDai}, []int64{500, 100}))
+	if !ok || fmt.Sprint(tokens, fees) != fmt.Sprint([]common.Address{testWeth, testUsdc, testDai}, []*big.Int{big.NewInt(500), big.NewInt(100)}) {
+		t.Fatalf("got %v %v", tokens, fees)
+	}
+	path := encodeV3Path([]common.Address{testWeth, testUsdc}, []int64{3000})
+	for _, invalid := range [][]byte{nil, path[:20], path[:len(path)-1], append(path, 0)} {
+		_, _, ok := decodeV3Path(invalid)
+		if ok {
+			t.Errorf("decoded a path of %d bytes", len(invalid))
+		}
+	}
+}
+
+func TestDecodeRouterCall(t *testing.T) {
+	v2Router, swapRouter, swapRouter02, universalRouter := routerAbis[0], routerAbis[1], routerAbis[2], routerAbis[3]
+	recipient := common.HexToAddress("0xbeef")
+	deadline := big.NewInt(1700000000)
+	zero := big.NewInt(0)
+	eth := big.NewInt(1e18)
+	universalV3 := func(amount *big.Int, path []byte) []byte {
+		input, err := universalV3SwapArgs.Pack(recipient, amount, zero, path, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return input
+	}
+	universalV2, err := universalV2SwapArgs.Pack(recipient, tokens(7), zero, []common.Address{testDai, testUsdc}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		data  []byte
+		value *big.Int
+		swaps []routerSwap
+	}{
+		{
+			"v2 exact in",
+			calldata(t, v2Router, "swapExactTokensForTokens", eth, zero, []common.Address{testWeth, testUsdc, testDai}, recipient, deadline),
+			zero,
+			[]routerSwap{{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc, testDai}, Amount: eth}},
+		},
+		{
+			"v2 paid in ETH",
+			calldata(t, v2Router, "swapExactETHForTokens", zero, []common.Address{testWeth, testUsdc}, recipient, deadline),
+			tokens(2),
+			[]routerSwap{{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc}, Amount: tokens(2)}},
+		},
+		{
+			"v2 exact out",
+			calldata(t, v2Router, "swapTokensForExactTokens", tokens(5), tokens(100), []common.Address{testUsdc, testWeth}, recipient, deadline),
+			zero,
+			[]routerSwap{{Dex: "v2", Tokens: []common.Address{testUsdc, testWeth}, Amount: tokens(5), ExactOut: true}},
+		},
+		{
+			"SwapRouter exact in single",
+			calldata(t, swapRouter, "exactInputSingle", contracts.ISwapRouterExactInputSingleParams{
+				TokenIn: testWeth, TokenOut: testUsdc, Fee: big.NewInt(500), Recipient: recipient, Deadline: deadline,
+				AmountIn: eth, AmountOutMinimum: zero, SqrtPriceLimitX96: zero,
+			}),
+			zero,
+			[]routerSwap{{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc}, Fees: []*big.Int{big.NewInt(500)}, Amount: eth}},
+		},
+		{
+			// Exact out paths start at the token out.
+			"SwapRouter exact out",
+			calldata(t, swapRouter, "exactOutput", contracts.ISwapRouterExactOutputParams{
+				Path: encodeV3Path([]common.Address{testDai, testUsdc, testWeth}, []int64{100, 500}), Recipient: recipient, Deadline: deadline,
+				AmountOut: tokens(1000), AmountInMaximum: tokens(1),
+			}),
+			zero,
+			[]routerSwap{{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc, testDai}, Fees: []*big.Int{big.NewInt(500), big.NewInt(100)}, Amount: tokens(1000), ExactOut: true}},
+		},
+		{
+			// An amount in of zero swaps the balance of the router, which
+			// is the ETH sent along.
+			"SwapRouter02 multicall",
+			calldata(t, swapRouter02, "multicall", deadline, [][]byte{
+				calldata(t, swapRouter02, "exactInputSingle", contracts.IV3SwapRouterExactInputSingleParams{
+					TokenIn: testWeth, TokenOut: testUsdc, Fee: big.NewInt(3000), Recipient: recipient,
+					AmountIn: zero, AmountOutMinimum: zero, SqrtPriceLimitX96: zero,
+				}),
+				calldata(t, swapRouter02, "swapExactTokensForTokens", tokens(4), zero, []common.Address{testUsdc, testDai}, recipient),
+			}),
+			tokens(3),
+			[]routerSwap{
+				{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc}, Fees: []*big.Int{big.NewInt(3000)}, Amount: tokens(3)},
+				{Dex: "v2", Tokens: []common.Address{testUsdc, testDai}, Amount: tokens(4)},
+			},
+		},
+		{
+			// WRAP_ETH is skipped and the allow revert flag is masked off.
+			"Universal Router execute",
+			calldata(t, universalRouter, "execute",
+				[]byte{0x0b, universalV3SwapExactIn, 0x80 | universalV3SwapExactOut, universalV2SwapExactIn},
+				[][]byte{
+					common.LeftPadBytes(recipient.Bytes(), 32),
+					universalV3(universalContractBalance, encodeV3Path([]common.Address{testWeth, testUsdc}, []int64{500})),
+					universalV3(tokens(9), encodeV3Path([]common.Address{testDai, testUsdc, testWeth}, []int64{100, 3000})),
+					universalV2,
+				},
+				deadline,
+			),
+			tokens(6),
+			[]routerSwap{
+				{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc}, Fees: []*big.Int{big.NewInt(500)}, Amount: tokens(6)},
+				{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc, testDai}, Fees: []*big.Int{big.NewInt(3000), big.NewInt(100)}, Amount: tokens(9), ExactOut: true},
+				{Dex: "v2", Tokens: []common.Address{testDai, testUsdc}, Amount: tokens(7)},
+			},
+		},
+		{"not a swap", []byte{0x12, 0x34, 0x56, 0x78}, zero, nil},
+		{"too short", []byte{0x12}, zero, nil},
+	}
+	for _, test := range tests {
+		swaps := decodeRouterCall(testRouter, test.data, test.value)
+		if fmt.Sprintf("%+v", swaps) != fmt.Sprintf("%+v", test.swaps) {
+			t.Errorf("%s: got %+v, want %+v", test.name, swaps, test.swaps)
+		}
+	}
+}
+
+// applySwapClient has v2 pools on weth/usdc and usdc/dai.
+func applySwapClient() *UniswapClient {
+	pools := map[common.Address]Pool{}
+	for _, pool := range []Pool{
+		{Address: common.HexToAddress("0x10"), Token0: testWeth, Token1: testUsdc, Reserve0: tokens(100), Reserve1: tokens(200000)},
+		{Address: common.HexToAddress("0x11"), Token0: testUsdc, Token1: testDai, Reserve0: tokens(1000000), Reserve1: tokens(1000000)},
+	} {
+		pool.Type = PoolTypeV2
+		pool.Dex = "v2"
+		pool.Enabled = true
+		pools[pool.Address] = pool
+	}
+	return &UniswapClient{Pools: pools, index: NewPoolIndex(pools, 3)}
+}
+
+func TestApplySwap(t *testing.T) {
+	wethUsdc := common.HexToAddress("0x10")
+	usdcDai := common.HexToAddress("0x11")
+	tests := []struct {
+		name     string
+		swap     routerSwap
+		reserves map[common.Address][2]string
+	}{
+		{
+			"exact in",
+			routerSwap{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc, testDai}, Amount: tokens(1)},
+			map[common.Address][2]string{
+				wethUsdc: {"101000000000000000000", "198025683931205877402300"},
+				usdcDai:  {"1001974316068794122597700", "998035473839192408078733"},
+			},
+		},
+		{
+			// The amount in is worked out from the last hop back, so the
+			// swap pays out at least the amount asked for.
+			"exact out",
+			routerSwap{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc, testDai}, Amount: tokens(1000), ExactOut: true},
+			map[common.Address][2]string{
+				wethUsdc: {"100506057512984947438", "198995986959878634903106"},
+				usdcDai:  {"1001004013040121365096894", "998999999999999999999399"},
+			},
+		},
+		{
+			"exact in up to an untracked hop",
+			routerSwap{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc, common.HexToAddress("0x04")}, Amount: tokens(1)},
+			map[common.Address][2]string{
+				wethUsdc: {"101000000000000000000", "198025683931205877402300"},
+			},
+		},
+		{
+			"exact out through an untracked hop",
+			routerSwap{Dex: "v2", Tokens: []common.Address{testWeth, testUsdc, common.HexToAddress("0x04")}, Amount: tokens(1), ExactOut: true},
+			map[common.Address][2]string{},
+		},
+		{
+			"other dex",
+			routerSwap{Dex: "v3", Tokens: []common.Address{testWeth, testUsdc}, Fees: []*big.Int{big.NewInt(500)}, Amount: tokens(1)},
+			map[common.Address][2]string{},
+		},
+	}
+	for _, test := range tests {
+		c := applySwapClient()
+		view := c.newPoolView()
+		c.applySwap(view, test.swap)
+		if len(view.changed) != len(test.reserves) {
+			t.Errorf("%s: changed %d pools, want %d", test.name, len(view.changed), len(test.reserves))
+		}
+		for address, reserves := range test.reserves {
+			pool := view.pool(address)
+			if pool.Reserve0.String() != reserves[0] || pool.Reserve1.String() != reserves[1] {
+				t.Errorf("%s: pool %s has %s/%s, want %s/%s", test.name, address, pool.Reserve0, pool.Reserve1, reserves[0], reserves[1])
+			}
+		}
+		// The pools of the client are left alone.
+		if c.Pools[wethUsdc].Reserve0.Cmp(tokens(100)) != 0 {
+			t.Fatalf("%s: changed the pools of the client", test.name)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/amm"
+)
+
+const (
+	OptimizerGrid          = "grid"
+	OptimizerClosedForm    = "closed-form"
+	OptimizerGoldenSection = "golden-section"
+)
+
+func v2Hops(pools []Pool, tokens []common.Address) ([]amm.V2Hop, bool) {
+	hops := []amm.V2Hop{}
+	for i, pool := range pools {
+		if pool.Type != PoolTypeV2 {
+			return nil, false
+		}
+		reserveIn, reserveOut, _ := pool.reservesFor(tokens[i])
+		hops = append(hops, amm.V2Hop{
+			ReserveIn:  reserveIn,
+			ReserveOut: reserveOut,
+			Fee:        pool.v2Fee(),
+		})
+	}
+	return hops, true
+}
+
+func (c *UniswapClient) optimizeBorrowAmount(pools []Pool, tokens []common.Address, reserve *big.Int) (string, *big.Int) {
+	maxBorrow := new(big.Int).Mul(reserve, big.NewInt(c.config.Strategy.OptimizerMaxBorrowPercent))
+	maxBorrow.Div(maxBorrow, big.NewInt(100))
+	hops, ok := v2Hops(pools, tokens)
+	if ok {
+		amount := amm.OptimalV2Input(hops)
+		if amount.Cmp(maxBorrow) == 1 {
+			amount = maxBorrow
+		}
+		return OptimizerClosedForm, amount
+	}
+	tolerance := new(big.Int).Div(maxBorrow, big.NewInt(c.config.Strategy.OptimizerPrecision))
+	amount := amm.GoldenSectionSearch(func(amount *big.Int) *big.Int {
+		outcome := quoteLocally(pools, tokens, amount)
+		return new(big.Int).Sub(outcome[len(outcome)-1], amount)
+	}, big.NewInt(0), maxBorrow, tolerance)
+	return OptimizerGoldenSection, amount
+}
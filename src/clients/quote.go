@@ -0,0 +1,169 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"mev_bot/amm"
+)
+
+func (p Pool) reservesFor(tokenIn common.Address) (*big.Int, *big.Int, common.Address) {
+	if p.Token0 == tokenIn {
+		return p.Reserve0, p.Reserve1, p.Token1
+	}
+	return p.Reserve1, p.Reserve0, p.Token0
+}
+
+func (p Pool) coins() []common.Address {
+	if len(p.Coins) > 0 {
+		return p.Coins
+	}
+	return []common.Address{p.Token0, p.Token1}
+}
+
+func (p Pool) balances() []*big.Int {
+	if p.Type == PoolTypeCurve && p.Curve != nil {
+		return p.Curve.Balances
+	}
+	if p.Type == PoolTypeBalancer && p.Balancer != nil {
+		return p.Balancer.Balances
+	}
+	return nil
+}
+
+func (p Pool) reserveOf(token common.Address) *big.Int {
+	if len(p.Coins) > 0 {
+		balances := p.balances()
+		index := p.coinIndex(token)
+		if index == -1 || index >= len(balances) {
+			return nil
+		}
+		return balances[index]
+	}
+	if p.Token0 == token {
+		return p.Reserve0
+	}
+	if p.Token1 == token {
+		return p.Reserve1
+	}
+	return nil
+}
+
+func (p Pool) v2Fee() *big.Int {
+	if p.Fee == nil {
+		return amm.V2DefaultFee
+	}
+	return p.Fee
+}
+
+// hopType encodes a hop for startArbitrage: 0 for v2 and 1 for v3 pools.
+func hopType(pool Pool) *big.Int {
+	if pool.Type == PoolTypeV3 {
+		return big.NewInt(1)
+	}
+	return big.NewInt(0)
+}
+
+// contractCanSwap reports whether startArbitrage can swap on the pool. The
+// contract only has hop types for Uniswap pools, so Curve and Balancer pools
+// are tracked and quoted but kept out of the search graph.
+func contractCanSwap(pool Pool) bool {
+	return pool.Type == PoolTypeV2 || pool.Type == PoolTypeV3
+}
+
+func canQuoteLocally(pools []Pool) bool {
+	for _, pool := range pools {
+		switch pool.Type {
+		case PoolTypeV2:
+			if pool.Reserve0 == nil || pool.Reserve1 == nil {
+				return false
+			}
+		case PoolTypeV3:
+			if pool.V3 == nil || !pool.V3.Initialized() || pool.Fee == nil {
+				return false
+			}
+		case PoolTypeCurve:
+			if !pool.canQuoteCurve() {
+				return false
+			}
+		case PoolTypeBalancer:
+			if !pool.canQuoteBalancer() {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (p Pool) quoteV3(tokenIn common.Address, amountIn *big.Int) (*big.Int, common.Address) {
+	zeroForOne := p.Token0 == tokenIn
+	tokenOut := p.Token0
+	if zeroForOne {
+		tokenOut = p.Token1
+	}
+	if amountIn.Sign() != 1 {
+		return big.NewInt(0), tokenOut
+	}
+	amount0, amount1, _, err := p.V3.Swap(zeroForOne, amountIn, p.Fee)
+	if err != nil {
+		return big.NewInt(0), tokenOut
+	}
+	if zeroForOne {
+		return new(big.Int).Neg(amount1), tokenOut
+	}
+	return new(big.Int).Neg(amount0), tokenOut
+}
+
+func (p Pool) quoteHop(tokenIn common.Address, tokenOut common.Address, amountIn *big.Int) *big.Int {
+	switch p.Type {
+	case PoolTypeV3:
+		amountOut, _ := p.quoteV3(tokenIn, amountIn)
+		return amountOut
+	case PoolTypeCurve:
+		return p.quoteCurve(tokenIn, tokenOut, amountIn)
+	case PoolTypeBalancer:
+		return p.quoteBalancer(tokenIn, tokenOut, amountIn)
+	default:
+		reserveIn, reserveOut, _ := p.reservesFor(tokenIn)
+		return amm.GetAmountOut(amountIn, reserveIn, reserveOut, p.v2Fee())
+	}
+}
+
+// quoteLocally returns the amount out of every hop. tokens holds the token
+// going into each hop followed by the token coming out of the last one.
+func quoteLocally(pools []Pool, tokens []common.Address, amount *big.Int) []*big.Int {
+	outcome := []*big.Int{}
+	amountIn := amount
+	for i, pool := range pools {
+		amountOut := pool.quoteHop(tokens[i], tokens[i+1], amountIn)
+		outcome = append(outcome, amountOut)
+		amountIn = amountOut
+	}
+	return outcome
+}
+
+// withinTolerance reports whether local is at most tolerance millionths away
+// from remote.
+func withinTolerance(local *big.Int, remote *big.Int, tolerance *big.Int) bool {
+	difference := new(big.Int).Sub(local, remote)
+	difference.Abs(difference)
+	difference.Mul(difference, big.NewInt(1000000))
+	return difference.Cmp(new(big.Int).Mul(remote, tolerance)) != 1
+}
+
+func (tx *ArbitrageTx) consider(amount *big.Int, amountOut *big.Int) bool {
+	profit := new(big.Int).Sub(amountOut, amount)
+	if profit.Cmp(tx.Profit) != 1 {
+		return false
+	}
+	tx.Profit = profit
+	tx.AmountOut = amountOut
+	tx.BorrowAmount = amount
+	tx.Valid = true
+	profitFloat := new(big.Float).SetInt(tx.Profit)
+	amountFloat := new(big.Float).SetInt(tx.BorrowAmount)
+	ratio1 := new(big.Float).Mul(profitFloat, big.NewFloat(100))
+	tx.Ratio = new(big.Float).Quo(ratio1, amountFloat)
+	return true
+}
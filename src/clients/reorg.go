@@ -0,0 +1,193 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+	"math/big"
+)
+
+// blockJournal holds the pools a block changed as they were before it, so
+// the block can be undone when it leaves the canonical chain. A nil pool was
+// created by the block.
+type blockJournal struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Pools      map[common.Address]*Pool
+}
+
+func (p Pool) clone() Pool {
+	if p.V3 != nil {
+		p.V3 = p.V3.Clone()
+	}
+	if p.Curve != nil {
+		p.Curve = p.Curve.Clone()
+	}
+	if p.Balancer != nil {
+		p.Balancer = p.Balancer.Clone()
+	}
+	return p
+}
+
+// beginBlock starts the journal of a block. Changes made outside of a block,
+// like the initial sync, are not journaled.
+func (c *UniswapClient) beginBlock(number uint64, hash common.Hash, parentHash common.Hash) {
+	c.currentBlock = &blockJournal{
+		Number:     number,
+		Hash:       hash,
+		ParentHash: parentHash,
+		Pools:      make(map[common.Address]*Pool),
+	}
+}
+
+func (c *UniswapClient) endBlock() {
+	if c.currentBlock == nil {
+		return
+	}
+	c.journal = append(c.journal, c.currentBlock)
+	c.currentBlock = nil
+	depth := c.config.ReorgDepth
+	if len(c.journal) > depth {
+		c.journal = c.journal[len(c.journal)-depth:]
+	}
+}
+
+// journalPool keeps the pool as it is before the current block first changes
+// it. The event handlers change some pool states in place, so this runs
+// before a log is applied and not only in setPool.
+func (c *UniswapClient) journalPool(address common.Address) {
+	if c.currentBlock == nil {
+		return
+	}
+	_, ok := c.currentBlock.Pools[address]
+	if ok {
+		return
+	}
+	pool, ok := c.Pools[address]
+	if !ok {
+		c.currentBlock.Pools[address] = nil
+		return
+	}
+	pool = pool.clone()
+	c.currentBlock.Pools[address] = &pool
+}
+
+// journalLog journals the pools a log may change.
+func (c *UniswapClient) journalLog(blockLog types.Log) {
+	c.journalPool(blockLog.Address)
+	dex, ok := c.factories[blockLog.Address]
+	if ok && dex.Protocol == PoolTypeBalancer && len(blockLog.Topics) > 1 {
+		c.journalPool(balancerPoolAddress(blockLog.Topics[1]))
+	}
+}
+
+// reorgAncestor returns the newest journaled block that is still canonical
+// when the header does not extend the journaled chain.
+func (c *UniswapClient) reorgAncestor(header *types.Header) (uint64, bool, error) {
+	if len(c.journal) == 0 {
+		return 0, false, nil
+	}
+	latest := c.journal[len(c.journal)-1]
+	if latest.Number+1 == header.Number.Uint64() && latest.Hash == header.ParentHash {
+		return 0, false, nil
+	}
+	if latest.Number == header.Number.Uint64() && latest.Hash == header.Hash() {
+		return 0, false, nil
+	}
+	for i := len(c.journal) - 1; i >= 0; i-- {
+		entry := c.journal[i]
+		canonical, err := c.client.HeaderByNumber(c.ctx, new(big.Int).SetUint64(entry.Number))
+		if err != nil {
+			return 0, false, err
+		}
+		if canonical.Hash() == entry.Hash {
+			return entry.Number, i != len(c.journal)-1, nil
+		}
+	}
+	// The reorg is deeper than the journal, which is undone completely.
+	log.Warn().Uint64("from", c.journal[0].Number).Msg("reorg deeper than the journal")
+	return c.journal[0].Number - 1, true, nil
+}
+
+// rollback undoes the journaled blocks after ancestor, newest first, and moves
+// the cursors back, so the logs of the blocks that replace them are fetched.
+func (c *UniswapClient) rollback(ancestor uint64) {
+	undone := 0
+	for len(c.journal) > 0 {
+		entry := c.journal[len(c.journal)-1]
+		if entry.Number <= ancestor {
+			break
+		}
+		for address, pool := range entry.Pools {
+			c.dirtyPools[address] = true
+			current, ok := c.Pools[address]
+			if pool == nil {
+				if ok {
+					current.Enabled = false
+					c.index.Update(current)
+					delete(c.Pools, address)
+				}
+				continue
+			}
+			if ok && current.Enabled && !pool.Enabled {
+				current.Enabled = false
+				c.index.Update(current)
+			}
+			c.Pools[address] = *pool
+			c.index.Update(*pool)
+		}
+		c.journal = c.journal[:len(c.journal)-1]
+		undone++
+	}
+	c.setLastSeenBlock(ancestor)
+	log.Warn().Uint64("ancestor", ancestor).Int("blocks", undone).Msg("reorg rolled back")
+}
+
+// handleReorg rolls the pools back to the last canonical block before the
+// header is processed.
+func (c *UniswapClient) handleReorg(header *types.Header) (bool, error) {
+	ancestor, reorged, err := c.reorgAncestor(header)
+	if err != nil || !reorged {
+		return false, err
+	}
+	c.rollback(ancestor)
+	return true, nil
+}
+
+// removedLogsBlock returns the oldest block of logs the node marked as
+// removed, which left the canonical chain.
+func removedLogsBlock(logs []types.Log) (uint64, bool) {
+	oldest := uint64(0)
+	found := false
+	for _, blockLog := range logs {
+		if !blockLog.Removed {
+			continue
+		}
+		if !found || blockLog.BlockNumber < oldest {
+			oldest = blockLog.BlockNumber
+			found = true
+		}
+	}
+	return oldest, found
+}
+
+func hasValidTx(txs []ArbitrageTx) bool {
+	for _, tx := range txs {
+		if tx.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// orphaned reports whether the block the opportunities were computed on left
+// the canonical chain in the meantime.
+func (c *UniswapClient) orphaned(header *types.Header) bool {
+	canonical, err := c.client.HeaderByNumber(c.ctx, header.Number)
+	if err != nil {
+		log.Info().Err(err).Msg("can not check the block")
+		return false
+	}
+	return canonical.Hash() != header.Hash()
+}
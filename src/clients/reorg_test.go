@@ -0,0 +1,200 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/config"
+	"mev_bot/contracts"
+	"testing"
+	"time"
+)
+
+var (
+	reorgWeth    = common.HexToAddress("0x01")
+	reorgUsdc    = common.HexToAddress("0x02")
+	reorgFactory = common.HexToAddress("0xf1")
+	reorgV2      = common.HexToAddress("0x10")
+	reorgV3      = common.HexToAddress("0x11")
+	reorgCreated = common.HexToAddress("0x12")
+)
+
+// reorgClient tracks a v2 and a v3 pool on weth/usdc as of block 100.
+func reorgClient() *UniswapClient {
+	v3 := Pool{Address: reorgV3, Token0: reorgWeth, Token1: reorgUsdc, Fee: big.NewInt(3000), Type: PoolTypeV3, Enabled: true, Reserve0: tokens(10), Reserve1: tokens(10), V3: amm.NewV3State(60)}
+	v3.V3.Synced = true
+	v3.V3.Seed(amm.Q96, big.NewInt(0), 0)
+	v3.V3.UpdatePosition(-600, 600, tokens(5))
+	pools := map[common.Address]Pool{
+		reorgV2: {Address: reorgV2, Token0: reorgWeth, Token1: reorgUsdc, Type: PoolTypeV2, Enabled: true, Reserve0: tokens(10), Reserve1: tokens(20)},
+		reorgV3: v3,
+	}
+	c := &UniswapClient{
+		config:         config.Default(),
+		ctx:            context.Background(),
+		Pools:          pools,
+		factories:      map[common.Address]Dex{reorgFactory: {Name: "v2", Protocol: PoolTypeV2, Factory: reorgFactory}},
+		FactoryCursors: map[common.Address]uint64{reorgFactory: 100},
+		LastSeenBlock:  100,
+		dirtyPools:     make(map[common.Address]bool),
+		pendingTokens:  make(map[common.Address]bool),
+		index:          NewPoolIndex(pools, 3),
+	}
+	return c
+}
+
+func poolsJSON(t *testing.T, pools map[common.Address]Pool) string {
+	t.Helper()
+	encoded, err := json.Marshal(pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(encoded)
+}
+
+// processTestBlock changes the pools the way a block does: the v2 pool gets
+// new reserves, the v3 pool a Mint applied to its state in place, and with
+// create a new pool is added.
+func processTestBlock(t *testing.T, c *UniswapClient, number uint64, reserve int64, create bool) {
+	t.Helper()
+	c.beginBlock(number, common.BigToHash(big.NewInt(int64(number))), common.BigToHash(big.NewInt(int64(number-1))))
+	v2 := c.Pools[reorgV2]
+	c.journalPool(reorgV2)
+	v2.Reserve0 = tokens(reserve)
+	c.setPool(v2)
+	contract, err := contracts.NewIEvents(reorgV3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := common.HexToAddress("0xaa")
+	mint := eventLog(t, reorgV3, 0, "Mint", []interface{}{owner, big.NewInt(-60), big.NewInt(60)}, owner, tokens(reserve), tokens(1), tokens(1))
+	mint.BlockNumber = number
+	c.journalLog(mint)
+	if !c.applyV3Log(contract, c.Pools[reorgV3], mint) {
+		t.Fatal("mint not applied")
+	}
+	if create {
+		c.setPool(Pool{Address: reorgCreated, Token0: reorgWeth, Token1: reorgUsdc, Type: PoolTypeV2, Enabled: true, Reserve0: tokens(1), Reserve1: tokens(2)})
+	}
+	c.endBlock()
+	c.setLastSeenBlock(number)
+}
+
+func TestRollbackRestoresThePools(t *testing.T) {
+	c := reorgClient()
+	deadline := time.Now().Add(time.Minute)
+	before := poolsJSON(t, c.Pools)
+	cyclesBefore, _ := c.index.CyclesOf(reorgV2, reorgWeth, deadline)
+	processTestBlock(t, c, 101, 11, false)
+	after101 := poolsJSON(t, c.Pools)
+	processTestBlock(t, c, 102, 12, true)
+	if _, ok := c.index.edges[reorgCreated]; !ok {
+		t.Fatal("the created pool is not indexed")
+	}
+	if cycles, _ := c.index.CyclesOf(reorgV2, reorgWeth, deadline); len(cycles) <= len(cyclesBefore) {
+		t.Fatal("the created pool adds no cycles")
+	}
+	c.dirtyPools = make(map[common.Address]bool)
+
+	c.rollback(101)
+	if got := poolsJSON(t, c.Pools); got != after101 {
+		t.Fatalf("pools after rolling back 102:\n%s\nwant\n%s", got, after101)
+	}
+	if _, ok := c.index.edges[reorgCreated]; ok {
+		t.Fatal("the created pool is still indexed")
+	}
+	cycles, _ := c.index.CyclesOf(reorgV2, reorgWeth, deadline)
+	if len(cycles) != len(cyclesBefore) {
+		t.Fatalf("%d cycles through the v2 pool, want %d", len(cycles), len(cyclesBefore))
+	}
+	for _, address := range []common.Address{reorgV2, reorgV3, reorgCreated} {
+		if !c.dirtyPools[address] {
+			t.Errorf("pool %s is not dirty", address)
+		}
+	}
+	if c.LastSeenBlock != 101 || c.FactoryCursors[reorgFactory] != 101 || len(c.journal) != 1 {
+		t.Fatalf("cursors at %d and %d with %d journaled blocks", c.LastSeenBlock, c.FactoryCursors[reorgFactory], len(c.journal))
+	}
+
+	// The in place change of the v3 state is undone too.
+	c.rollback(100)
+	if got := poolsJSON(t, c.Pools); got != before {
+		t.Fatalf("pools after rolling back 101:\n%s\nwant\n%s", got, before)
+	}
+	if c.LastSeenBlock != 100 || c.FactoryCursors[reorgFactory] != 100 || len(c.journal) != 0 {
+		t.Fatalf("cursors at %d and %d with %d journaled blocks", c.LastSeenBlock, c.FactoryCursors[reorgFactory], len(c.journal))
+	}
+}
+
+// headerService serves the canonical headers by number over eth_getBlockByNumber.
+type headerService struct {
+	headers map[uint64]*types.Header
+}
+
+func (s *headerService) GetBlockByNumber(number rpc.BlockNumber, full bool) (*types.Header, error) {
+	return s.headers[uint64(number)], nil
+}
+
+func testHeader(number uint64, parent common.Hash, extra string) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Difficulty: big.NewInt(0), Extra: []byte(extra)}
+}
+
+func TestReorgAncestor(t *testing.T) {
+	service := &headerService{headers: make(map[uint64]*types.Header)}
+	server := rpc.NewServer()
+	err := server.RegisterName("eth", service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	c := reorgClient()
+	c.client = ethclient.NewClient(rpc.DialInProc(server))
+	// Blocks 100 to 102 are journaled and canonical, the fork replaces 102.
+	parent := common.Hash{}
+	for number := uint64(100); number <= 102; number++ {
+		header := testHeader(number, parent, "a")
+		service.headers[number] = header
+		c.beginBlock(number, header.Hash(), header.ParentHash)
+		c.endBlock()
+		parent = header.Hash()
+	}
+	fork := testHeader(102, service.headers[101].Hash(), "b")
+	tests := []struct {
+		name     string
+		header   *types.Header
+		canon    map[uint64]*types.Header
+		ancestor uint64
+		reorged  bool
+	}{
+		{"next block", testHeader(103, service.headers[102].Hash(), "a"), nil, 0, false},
+		{"same block", service.headers[102], nil, 0, false},
+		{"fork of the latest block", testHeader(103, fork.Hash(), "b"), map[uint64]*types.Header{102: fork}, 101, true},
+		{"fork deeper than the journal", testHeader(103, common.Hash{}, "c"), map[uint64]*types.Header{
+			100: testHeader(100, common.Hash{}, "c"),
+			101: testHeader(101, common.Hash{}, "c"),
+			102: testHeader(102, common.Hash{}, "c"),
+		}, 99, true},
+	}
+	canonical := service.headers
+	for _, test := range tests {
+		service.headers = make(map[uint64]*types.Header)
+		for number, header := range canonical {
+			service.headers[number] = header
+		}
+		for number, header := range test.canon {
+			service.headers[number] = header
+		}
+		ancestor, reorged, err := c.reorgAncestor(test.header)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if ancestor != test.ancestor || reorged != test.reorged {
+			t.Errorf("%s: got %d %v, want %d %v", test.name, ancestor, reorged, test.ancestor, test.reorged)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package clients
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"mev_bot/contracts"
+	"strings"
+)
+
+var (
+	errorSelector  = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector  = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	// botErrors declares the custom errors the contract reverts with.
+	botErrors = loadAbis(contracts.UniswapBotV2MetaData)[0]
+
+	maliciousPoolPrefix = "Malicious Pool: "
+	// Reasons the pools give when the trade would not return enough. They
+	// mean the price moved, not that a pool is broken.
+	insufficientOutputReasons = []string{
+		"Insufficient",
+		"INSUFFICIENT_OUTPUT_AMOUNT",
+		"Too little received",
+		"UniswapV2: K",
+	}
+	// Balancer reports its errors as BAL# codes, and the Vault is where the
+	// flash loan comes from.
+	flashLoanReasonPrefix = "BAL#"
+	outOfGasMessages      = []string{
+		"out of gas",
+		"gas required exceeds allowance",
+		"intrinsic gas too low",
+	}
+)
+
+// RevertError is a revert with a reason no other error type matches.
+type RevertError struct {
+	Reason string
+	Data   []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted: " + hexutil.Encode(e.Data)
+	}
+	return "execution reverted: " + e.Reason
+}
+
+type PanicError struct {
+	Code *big.Int
+}
+
+func (e *PanicError) Error() string {
+	return "execution panicked: " + panicReason(e.Code)
+}
+
+type MaliciousPoolError struct {
+	Pool   common.Address
+	Reason string
+}
+
+func (e *MaliciousPoolError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+type InsufficientOutputError struct {
+	Reason string
+}
+
+func (e *InsufficientOutputError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+type FlashLoanError struct {
+	Reason string
+}
+
+func (e *FlashLoanError) Error() string {
+	return "flash loan failed: " + e.Reason
+}
+
+type OutOfGasError struct {
+	Err error
+}
+
+func (e *OutOfGasError) Error() string {
+	return "out of gas: " + e.Err.Error()
+}
+
+func (e *OutOfGasError) Unwrap() error {
+	return e.Err
+}
+
+// CustomError is a custom error of the UniswapBotV2 ABI.
+type CustomError struct {
+	Name string
+	Args []interface{}
+}
+
+func (e *CustomError) Error() string {
+	return fmt.Sprintf("execution reverted: %s%v", e.Name, e.Args)
+}
+
+func panicReason(code *big.Int) string {
+	switch code.Uint64() {
+	case 0x01:
+		return "assert(false)"
+	case 0x11:
+		return "arithmetic underflow or overflow"
+	case 0x12:
+		return "division or modulo by zero"
+	case 0x21:
+		return "enum overflow"
+	case 0x22:
+		return "invalid encoded storage byte array"
+	case 0x31:
+		return "out-of-bounds array access; popping on an empty array"
+	case 0x32:
+		return "out-of-bounds access of an array or bytesN"
+	case 0x41:
+		return "out of memory"
+	case 0x51:
+		return "uninitialized function"
+	}
+	return "code " + code.String()
+}
+
+// decodeRevert turns the revert data of a failed eth_call or gas estimation
+// into one of the error types above. Errors without revert data are returned
+// as they are, unless the node reports running out of gas.
+func decodeRevert(err error) error {
+	if err == nil {
+		return nil
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		encoded, ok := dataErr.ErrorData().(string)
+		if ok {
+			data, decodeErr := hexutil.Decode(encoded)
+			if decodeErr == nil && len(data) > 0 {
+				return parseRevertData(data)
+			}
+		}
+	}
+	for _, message := range outOfGasMessages {
+		if strings.Contains(err.Error(), message) {
+			return &OutOfGasError{Err: err}
+		}
+	}
+	return err
+}
+
+func parseRevertData(data []byte) error {
+	if len(data) < 4 {
+		return &RevertError{Data: data}
+	}
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return &RevertError{Data: data}
+		}
+		return classifyReason(reason)
+	case bytes.Equal(data[:4], panicSelector):
+		values, err := abi.Arguments{{Type: uint256Type}}.Unpack(data[4:])
+		if err != nil {
+			return &RevertError{Data: data}
+		}
+		return &PanicError{Code: values[0].(*big.Int)}
+	}
+	customError, err := botErrors.ErrorByID([4]byte(data[:4]))
+	if err != nil {
+		return &RevertError{Data: data}
+	}
+	args, err := customError.Inputs.Unpack(data[4:])
+	if err != nil {
+		return &RevertError{Data: data}
+	}
+	return classifyCustomError(customError.Name, args)
+}
+
+// classifyCustomError maps the custom errors the contract may declare to the
+// same types as their Error(string) counterparts.
+func classifyCustomError(name string, args []interface{}) error {
+	switch name {
+	case "MaliciousPool":
+		if len(args) == 1 {
+			pool, ok := args[0].(common.Address)
+			if ok {
+				return &MaliciousPoolError{Pool: pool, Reason: maliciousPoolPrefix + pool.String()}
+			}
+		}
+	case "InsufficientOutput", "InsufficientOutputAmount":
+		return &InsufficientOutputError{Reason: fmt.Sprintf("%s%v", name, args)}
+	case "FlashLoanFailed":
+		return &FlashLoanError{Reason: fmt.Sprintf("%s%v", name, args)}
+	}
+	return &CustomError{Name: name, Args: args}
+}
+
+// classifyReason maps the reason of an Error(string) revert to its type.
+func classifyReason(reason string) error {
+	index := strings.Index(reason, maliciousPoolPrefix)
+	if index != -1 {
+		address := strings.TrimSpace(reason[index+len(maliciousPoolPrefix):])
+		if common.IsHexAddress(address) {
+			return &MaliciousPoolError{Pool: common.HexToAddress(address), Reason: reason}
+		}
+	}
+	if strings.HasPrefix(reason, flashLoanReasonPrefix) {
+		return &FlashLoanError{Reason: reason}
+	}
+	for _, insufficient := range insufficientOutputReasons {
+		if strings.Contains(reason, insufficient) {
+			return &InsufficientOutputError{Reason: reason}
+		}
+	}
+	return &RevertError{Reason: reason}
+}
+
+// decodeSimulationRevert turns the error of a simulated transaction into one
+// of the error types above. Relays report the revert data either hex encoded,
+// raw or already decoded into the reason.
+func decodeSimulationRevert(message string, revert string) error {
+	if revert == "" {
+		return &RevertError{Reason: message}
+	}
+	data, err := hexutil.Decode(revert)
+	if err == nil {
+		return parseRevertData(data)
+	}
+	if len(revert) >= 4 && (strings.HasPrefix(revert, string(errorSelector)) || strings.HasPrefix(revert, string(panicSelector))) {
+		return parseRevertData([]byte(revert))
+	}
+	return classifyReason(revert)
+}
@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// The bot contract declares no custom errors yet, these are the ones
+// classifyCustomError knows and one it does not.
+const testBotErrors = `[
+	{"type": "error", "name": "MaliciousPool", "inputs": [{"name": "pool", "type": "address"}]},
+	{"type": "error", "name": "InsufficientOutput", "inputs": [{"name": "amountOut", "type": "uint256"}, {"name": "minimum", "type": "uint256"}]},
+	{"type": "error", "name": "FlashLoanFailed", "inputs": []},
+	{"type": "error", "name": "Unauthorized", "inputs": [{"name": "caller", "type": "address"}]}
+]`
+
+func errorData(t *testing.T, reason string) []byte {
+	t.Helper()
+	stringType, _ := abi.NewType("string", "", nil)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, errorSelector...), packed...)
+}
+
+func panicData(t *testing.T, code int64) []byte {
+	t.Helper()
+	packed, err := abi.Arguments{{Type: uint256Type}}.Pack(big.NewInt(code))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, panicSelector...), packed...)
+}
+
+func customErrorData(t *testing.T, errorsAbi *abi.ABI, name string, args ...interface{}) []byte {
+	t.Helper()
+	customError := errorsAbi.Errors[name]
+	packed, err := customError.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, customError.ID[:4]...), packed...)
+}
+
+func describeError(err error) string {
+	return fmt.Sprintf("%T %v", err, err)
+}
+
+func TestParseRevertData(t *testing.T) {
+	errorsAbi, err := abi.JSON(strings.NewReader(testBotErrors))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(declared *abi.ABI) {
+		botErrors = declared
+	}(botErrors)
+	botErrors = &errorsAbi
+	pool := common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	reason := errorData(t, "Malicious Pool: "+pool.String())
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"malicious pool reason", reason, &MaliciousPoolError{Pool: pool, Reason: "Malicious Pool: " + pool.String()}},
+		{"insufficient output reason", errorData(t, "UniswapV2: INSUFFICIENT_OUTPUT_AMOUNT"), &InsufficientOutputError{Reason: "UniswapV2: INSUFFICIENT_OUTPUT_AMOUNT"}},
+		{"balancer code", errorData(t, "BAL#528"), &FlashLoanError{Reason: "BAL#528"}},
+		{"other reason", errorData(t, "Ownable: caller is not the owner"), &RevertError{Reason: "Ownable: caller is not the owner"}},
+		{"malicious pool without an address", errorData(t, "Malicious Pool: none"), &RevertError{Reason: "Malicious Pool: none"}},
+		{"known panic", panicData(t, 0x11), &PanicError{Code: big.NewInt(0x11)}},
+		{"unknown panic", panicData(t, 0x99), &PanicError{Code: big.NewInt(0x99)}},
+		{"custom malicious pool", customErrorData(t, &errorsAbi, "MaliciousPool", pool), &MaliciousPoolError{Pool: pool, Reason: "Malicious Pool: " + pool.String()}},
+		{"custom insufficient output", customErrorData(t, &errorsAbi, "InsufficientOutput", big.NewInt(1), big.NewInt(2)), &InsufficientOutputError{Reason: "InsufficientOutput[1 2]"}},
+		{"custom flash loan", customErrorData(t, &errorsAbi, "FlashLoanFailed"), &FlashLoanError{Reason: "FlashLoanFailed[]"}},
+		{"other custom error", customErrorData(t, &errorsAbi, "Unauthorized", pool), &CustomError{Name: "Unauthorized", Args: []interface{}{pool}}},
+		{"shorter than a selector", []byte{0x08, 0xc3}, &RevertError{Data: []byte{0x08, 0xc3}}},
+		{"truncated reason", reason[:40], &RevertError{Data: reason[:40]}},
+		{"truncated panic", panicData(t, 1)[:7], &RevertError{Data: panicData(t, 1)[:7]}},
+		{"truncated custom error", customErrorData(t, &errorsAbi, "MaliciousPool", pool)[:20], &RevertError{Data: customErrorData(t, &errorsAbi, "MaliciousPool", pool)[:20]}},
+		{"unknown selector", []byte{0xde, 0xad, 0xbe, 0xef}, &RevertError{Data: []byte{0xde, 0xad, 0xbe, 0xef}}},
+	}
+	for _, test := range tests {
+		got := parseRevertData(test.data)
+		if describeError(got) != describeError(test.want) {
+			t.Errorf("%s: got %s, want %s", test.name, describeError(got), describeError(test.want))
+		}
+	}
+	var maliciousPool *MaliciousPoolError
+	if !errors.As(parseRevertData(customErrorData(t, &errorsAbi, "MaliciousPool", pool)), &maliciousPool) || maliciousPool.Pool != pool {
+		t.Fatal("the custom error does not name the pool")
+	}
+}
+
+func TestDecodeSimulationRevert(t *testing.T) {
+	reason := errorData(t, "Too little received")
+	tests := []struct {
+		name    string
+		message string
+		revert  string
+		want    error
+	}{
+		{"no revert data", "execution reverted", "", &RevertError{Reason: "execution reverted"}},
+		{"hex encoded", "execution reverted", hexutil.Encode(reason), &InsufficientOutputError{Reason: "Too little received"}},
+		{"raw reason bytes", "execution reverted", string(reason), &InsufficientOutputError{Reason: "Too little received"}},
+		{"raw panic bytes", "execution reverted", string(panicData(t, 0x12)), &PanicError{Code: big.NewInt(0x12)}},
+		{"decoded reason", "execution reverted", "BAL#001", &FlashLoanError{Reason: "BAL#001"}},
+		{"not hex", "execution reverted", "0xnothex", &RevertError{Reason: "0xnothex"}},
+	}
+	for _, test := range tests {
+		got := decodeSimulationRevert(test.message, test.revert)
+		if describeError(got) != describeError(test.want) {
+			t.Errorf("%s: got %s, want %s", test.name, describeError(got), describeError(test.want))
+		}
+	}
+}
+
+// rpcDataError is an error of the node that carries revert data.
+type rpcDataError struct {
+	data interface{}
+}
+
+func (e rpcDataError) Error() string {
+	return "execution reverted"
+}
+
+func (e rpcDataError) ErrorData() interface{} {
+	return e.data
+}
+
+func TestDecodeRevert(t *testing.T) {
+	plain := errors.New("connection refused")
+	outOfGas := errors.New("gas required exceeds allowance (30000000)")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"no error", nil, nil},
+		{"revert data", rpcDataError{hexutil.Encode(panicData(t, 0x01))}, &PanicError{Code: big.NewInt(1)}},
+		{"wrapped revert data", fmt.Errorf("quote: %w", rpcDataError{hexutil.Encode(errorData(t, "BAL#304"))}), &FlashLoanError{Reason: "BAL#304"}},
+		{"empty revert data", rpcDataError{"0x"}, rpcDataError{"0x"}},
+		{"revert data that is not a string", rpcDataError{42}, rpcDataError{42}},
+		{"out of gas", outOfGas, &OutOfGasError{Err: outOfGas}},
+		{"other error", plain, plain},
+	}
+	for _, test := range tests {
+		got := decodeRevert(test.err)
+		if describeError(got) != describeError(test.want) {
+			t.Errorf("%s: got %s, want %s", test.name, describeError(got), describeError(test.want))
+		}
+	}
+}
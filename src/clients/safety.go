@@ -0,0 +1,418 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/contracts"
+	"os"
+	"time"
+)
+
+const (
+	TokenSafe            = "safe"
+	TokenFeeOnTransfer   = "fee-on-transfer"
+	TokenHoneypot        = "honeypot"
+	TokenBalanceChanging = "balance-changing"
+	// TokenUnchecked tokens could not be simulated and are traded as before.
+	TokenUnchecked = "unchecked"
+)
+
+var (
+	ErrNoBalanceSlot = errors.New("balance slot not found")
+
+	// Multicall3 has the same address on every supported chain. The
+	// simulation runs from it, so it is the account holding the tokens.
+	multicall3 = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+	maxBalanceSlot   = int64(20)
+	balanceSlotProbe = new(big.Int).SetUint64(0x5afe5afe5afe)
+)
+
+type TokenVerdict struct {
+	Status string `json:"status"`
+	// Taxes are in basis points of the transferred amount.
+	BuyTax    int64          `json:"buyTax"`
+	SellTax   int64          `json:"sellTax"`
+	Reason    string         `json:"reason,omitempty"`
+	Pool      common.Address `json:"pool"`
+	Block     uint64         `json:"block"`
+	CheckedAt time.Time      `json:"checkedAt"`
+}
+
+func (v TokenVerdict) Flagged() bool {
+	return v.Status != TokenSafe && v.Status != TokenUnchecked
+}
+
+// balanceSlot locates the balances mapping of a token, so a balance can be
+// set with a state override.
+type balanceSlot struct {
+	slot  int64
+	vyper bool
+}
+
+func (s balanceSlot) key(holder common.Address) common.Hash {
+	slot := common.BigToHash(big.NewInt(s.slot))
+	if s.vyper {
+		return crypto.Keccak256Hash(slot.Bytes(), common.LeftPadBytes(holder.Bytes(), 32))
+	}
+	return crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), 32), slot.Bytes())
+}
+
+func (c *UniswapClient) callWithOverrides(to common.Address, data []byte, overrides map[common.Address]gethclient.OverrideAccount) ([]byte, error) {
+	msg := ethereum.CallMsg{From: c.address, To: &to, Data: data}
+	return gethclient.New(c.client.Client()).CallContract(c.ctx, msg, nil, &overrides)
+}
+
+// findBalanceSlot tries the first slots in both the Solidity and the Vyper
+// mapping layouts until an override shows up in balanceOf.
+func (c *UniswapClient) findBalanceSlot(token common.Address) (balanceSlot, error) {
+	slot, ok := c.balanceSlots[token]
+	if ok {
+		return slot, nil
+	}
+	erc20, err := contracts.IERC20MetadataMetaData.GetAbi()
+	if err != nil {
+		return slot, err
+	}
+	data, err := erc20.Pack("balanceOf", multicall3)
+	if err != nil {
+		return slot, err
+	}
+	for i := int64(0); i < maxBalanceSlot; i++ {
+		for _, vyper := range []bool{false, true} {
+			candidate := balanceSlot{slot: i, vyper: vyper}
+			out, err := c.callWithOverrides(token, data, map[common.Address]gethclient.OverrideAccount{
+				token: {StateDiff: map[common.Hash]common.Hash{candidate.key(multicall3): common.BigToHash(balanceSlotProbe)}},
+			})
+			if err != nil {
+				return slot, err
+			}
+			if new(big.Int).SetBytes(out).Cmp(balanceSlotProbe) == 0 {
+				c.balanceSlots[token] = candidate
+				return candidate, nil
+			}
+		}
+	}
+	return slot, ErrNoBalanceSlot
+}
+
+// safetyPool picks the v2 pool of token with the deepest base token side,
+// measured against the minimum reserve of that base token.
+func (c *UniswapClient) safetyPool(token common.Address) (Pool, common.Address, bool) {
+	var best Pool
+	var bestBase common.Address
+	var bestDepth *big.Int
+	for _, base := range c.profile.BaseTokens {
+		for edge := range c.index.graph.pairs[newPairKey(token, base.Address)] {
+			pool := c.Pools[edge.Address]
+			if pool.Type != PoolTypeV2 || !pool.Enabled {
+				continue
+			}
+			reserve := pool.reserveOf(base.Address)
+			if reserve == nil || base.MinReserve.Sign() != 1 {
+				continue
+			}
+			depth := new(big.Int).Div(reserve, base.MinReserve)
+			if bestDepth == nil || depth.Cmp(bestDepth) == 1 {
+				best = pool
+				bestBase = base.Address
+				bestDepth = depth
+			}
+		}
+	}
+	return best, bestBase, bestDepth != nil
+}
+
+// simulateToken buys token with a thousandth of the base reserve of its pool
+// and sends half of what it got back to the pool, all in one eth_call through
+// Multicall3. The base token balance comes from a state override.
+func (c *UniswapClient) simulateToken(token common.Address) TokenVerdict {
+	verdict := TokenVerdict{Status: TokenUnchecked, CheckedAt: time.Now(), Block: c.LastSeenBlock}
+	pool, base, ok := c.safetyPool(token)
+	if !ok {
+		verdict.Reason = "no v2 pool with a base token"
+		return verdict
+	}
+	verdict.Pool = pool.Address
+	slot, err := c.findBalanceSlot(base)
+	if err != nil {
+		verdict.Reason = err.Error()
+		return verdict
+	}
+	reserveIn, reserveOut, _ := pool.reservesFor(base)
+	amountIn := new(big.Int).Div(reserveIn, big.NewInt(1000))
+	if amountIn.Sign() != 1 {
+		verdict.Reason = "pool is empty"
+		return verdict
+	}
+	// A little less than the quote is bought, so slightly stale reserves do
+	// not fail the swap.
+	amountOut := amm.GetAmountOut(amountIn, reserveIn, reserveOut, pool.v2Fee())
+	amountOut.Mul(amountOut, big.NewInt(95)).Div(amountOut, big.NewInt(100))
+	if amountOut.Sign() != 1 {
+		verdict.Reason = "pool is empty"
+		return verdict
+	}
+	sellAmount := new(big.Int).Div(amountOut, big.NewInt(2))
+	amount0Out, amount1Out := big.NewInt(0), amountOut
+	if pool.Token0 == token {
+		amount0Out, amount1Out = amountOut, big.NewInt(0)
+	}
+	results, err := c.runSafetyCalls(base, token, pool.Address, amountIn, amount0Out, amount1Out, sellAmount, slot)
+	if err != nil {
+		verdict.Reason = err.Error()
+		return verdict
+	}
+	if !transferSucceeded(results[0]) {
+		verdict.Reason = "base token transfer failed"
+		return verdict
+	}
+	balanceBefore, ok1 := uintResult(results[1])
+	balanceAfterBuy, ok2 := uintResult(results[3])
+	pairBefore, ok3 := uintResult(results[4])
+	pairAfter, ok4 := uintResult(results[6])
+	balanceAfterSell, ok5 := uintResult(results[7])
+	if !results[2].Success {
+		verdict.Status = TokenHoneypot
+		verdict.Reason = "buy reverted"
+		return verdict
+	}
+	if !ok1 || !ok2 {
+		verdict.Reason = "balanceOf failed"
+		return verdict
+	}
+	received := new(big.Int).Sub(balanceAfterBuy, balanceBefore)
+	if received.Sign() != 1 {
+		verdict.Status = TokenHoneypot
+		verdict.Reason = "nothing received on buy"
+		return verdict
+	}
+	if received.Cmp(amountOut) == 1 {
+		verdict.Status = TokenBalanceChanging
+		verdict.Reason = fmt.Sprintf("received %s on a buy of %s", received, amountOut)
+		return verdict
+	}
+	verdict.BuyTax = taxBps(amountOut, received)
+	if received.Cmp(sellAmount) == -1 {
+		verdict.Status = TokenFeeOnTransfer
+		verdict.Reason = "buy tax above half"
+		return verdict
+	}
+	if !transferSucceeded(results[5]) {
+		verdict.Status = TokenHoneypot
+		verdict.Reason = "sell reverted"
+		return verdict
+	}
+	if !ok3 || !ok4 || !ok5 {
+		verdict.Reason = "balanceOf failed"
+		return verdict
+	}
+	pairReceived := new(big.Int).Sub(pairAfter, pairBefore)
+	spent := new(big.Int).Sub(balanceAfterBuy, balanceAfterSell)
+	if pairReceived.Cmp(sellAmount) == 1 || spent.Cmp(sellAmount) != 0 {
+		verdict.Status = TokenBalanceChanging
+		verdict.Reason = fmt.Sprintf("sent %s, pool received %s, balance dropped by %s", sellAmount, pairReceived, spent)
+		return verdict
+	}
+	verdict.SellTax = taxBps(sellAmount, pairReceived)
+	maxTax := c.config.Tokens.Safety.MaxTaxBps
+	if verdict.BuyTax > maxTax || verdict.SellTax > maxTax {
+		verdict.Status = TokenFeeOnTransfer
+		return verdict
+	}
+	verdict.Status = TokenSafe
+	return verdict
+}
+
+func (c *UniswapClient) runSafetyCalls(base common.Address, token common.Address, pair common.Address, amountIn *big.Int, amount0Out *big.Int, amount1Out *big.Int, sellAmount *big.Int, slot balanceSlot) ([]contracts.IMulticall3Result, error) {
+	erc20, err := contracts.IERC20MetadataMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	pairAbi, err := contracts.IUniswapV2PairMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	multicall, err := contracts.IMulticall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	type step struct {
+		target common.Address
+		abi    *abi.ABI
+		method string
+		args   []interface{}
+	}
+	steps := []step{
+		{base, erc20, "transfer", []interface{}{pair, amountIn}},
+		{token, erc20, "balanceOf", []interface{}{multicall3}},
+		{pair, pairAbi, "swap", []interface{}{amount0Out, amount1Out, multicall3, []byte{}}},
+		{token, erc20, "balanceOf", []interface{}{multicall3}},
+		{token, erc20, "balanceOf", []interface{}{pair}},
+		{token, erc20, "transfer", []interface{}{pair, sellAmount}},
+		{token, erc20, "balanceOf", []interface{}{pair}},
+		{token, erc20, "balanceOf", []interface{}{multicall3}},
+	}
+	calls := []contracts.IMulticall3Call3{}
+	for _, step := range steps {
+		data, err := step.abi.Pack(step.method, step.args...)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, contracts.IMulticall3Call3{Target: step.target, AllowFailure: true, CallData: data})
+	}
+	data, err := multicall.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.callWithOverrides(multicall3, data, map[common.Address]gethclient.OverrideAccount{
+		base: {StateDiff: map[common.Hash]common.Hash{slot.key(multicall3): common.BigToHash(amountIn)}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := multicall.Unpack("aggregate3", out)
+	if err != nil {
+		return nil, err
+	}
+	results := *abi.ConvertType(values[0], new([]contracts.IMulticall3Result)).(*[]contracts.IMulticall3Result)
+	if len(results) != len(steps) {
+		return nil, fmt.Errorf("got %d results for %d calls", len(results), len(steps))
+	}
+	return results, nil
+}
+
+func uintResult(result contracts.IMulticall3Result) (*big.Int, bool) {
+	if !result.Success || len(result.ReturnData) < 32 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(result.ReturnData[:32]), true
+}
+
+// transferSucceeded accepts tokens that return nothing from transfer, as the
+// pools do.
+func transferSucceeded(result contracts.IMulticall3Result) bool {
+	if !result.Success {
+		return false
+	}
+	if len(result.ReturnData) == 0 {
+		return true
+	}
+	value, ok := uintResult(result)
+	return ok && value.Sign() != 0
+}
+
+func taxBps(sent *big.Int, received *big.Int) int64 {
+	tax := new(big.Int).Sub(sent, received)
+	tax.Mul(tax, big.NewInt(10000))
+	return tax.Div(tax, sent).Int64()
+}
+
+// cachedVerdict returns the verdict of token and whether it is still current.
+// Safe verdicts run out after the recheck interval, unchecked ones after the
+// retry interval, and flagged ones when their denial does.
+func (c *UniswapClient) cachedVerdict(token common.Address) (TokenVerdict, bool) {
+	verdict, ok := c.tokenVerdicts[token]
+	if !ok {
+		return verdict, false
+	}
+	safety := c.config.Tokens.Safety
+	switch verdict.Status {
+	case TokenSafe:
+		return verdict, time.Since(verdict.CheckedAt) < safety.RecheckInterval
+	case TokenUnchecked:
+		return verdict, time.Since(verdict.CheckedAt) < safety.RetryInterval
+	}
+	return verdict, c.tokenDenied(token)
+}
+
+// queueTokenChecks queues the tokens of an enabled pool that are not base
+// tokens and have no current verdict.
+func (c *UniswapClient) queueTokenChecks(pool Pool) {
+	if !c.config.Tokens.Safety.Enabled || !pool.Enabled {
+		return
+	}
+	for _, token := range pool.coins() {
+		_, ok := c.baseToken(token)
+		if ok {
+			continue
+		}
+		_, current := c.cachedVerdict(token)
+		if !current {
+			c.pendingTokens[token] = true
+		}
+	}
+}
+
+// checkPendingTokens simulates the queued tokens and denies the flagged ones.
+// It runs when pools are added and after the trades of a block, so the
+// simulations stay out of the path search.
+func (c *UniswapClient) checkPendingTokens() {
+	if len(c.pendingTokens) == 0 {
+		return
+	}
+	for token := range c.pendingTokens {
+		delete(c.pendingTokens, token)
+		verdict := c.simulateToken(token)
+		c.tokenVerdicts[token] = verdict
+		log.Info().Str("token", token.String()).Str("status", verdict.Status).Int64("buyTax", verdict.BuyTax).Int64("sellTax", verdict.SellTax).Str("reason", verdict.Reason).Msg("token checked")
+		if verdict.Flagged() {
+			c.denyToken(token, verdict.Status+": "+verdict.Reason, DenySourceSimulation, true)
+		}
+	}
+	err := c.SaveTokenVerdicts()
+	if err != nil {
+		log.Error().Err(err).Msg("can not save token verdicts")
+	}
+}
+
+// pathTokensSafe reads the cached verdicts of the tokens of the path that
+// are not base tokens. Tokens that were never checked are not traded yet.
+// Verdicts that ran out still count until the token is simulated again.
+func (c *UniswapClient) pathTokensSafe(path Path) bool {
+	if !c.config.Tokens.Safety.Enabled {
+		return true
+	}
+	for _, token := range path.Tokens {
+		_, ok := c.baseToken(token)
+		if ok {
+			continue
+		}
+		verdict, current := c.cachedVerdict(token)
+		if !current {
+			c.pendingTokens[token] = true
+		}
+		if verdict.Status == "" || verdict.Flagged() {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *UniswapClient) ReadTokenVerdicts() error {
+	file, err := os.ReadFile(c.verdictsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(file, &c.tokenVerdicts)
+}
+
+func (c *UniswapClient) SaveTokenVerdicts() error {
+	file, err := json.MarshalIndent(c.tokenVerdicts, "", " ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.verdictsPath, file)
+}
@@ -0,0 +1,51 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"mev_bot/config"
+	"testing"
+	"time"
+)
+
+func TestPathTokensSafeReadsTheCache(t *testing.T) {
+	weth := common.HexToAddress("0x01")
+	fresh := common.HexToAddress("0x02")
+	expired := common.HexToAddress("0x03")
+	unknown := common.HexToAddress("0x04")
+	honeypot := common.HexToAddress("0x05")
+	cfg := config.Default()
+	cfg.Tokens.Safety.RecheckInterval = time.Hour
+	c := &UniswapClient{
+		config:  cfg,
+		profile: ChainProfile{BaseTokens: []BaseToken{{Address: weth}}},
+		tokenVerdicts: map[common.Address]TokenVerdict{
+			fresh:    {Status: TokenSafe, CheckedAt: time.Now()},
+			expired:  {Status: TokenSafe, CheckedAt: time.Now().Add(-2 * time.Hour)},
+			honeypot: {Status: TokenHoneypot, CheckedAt: time.Now()},
+		},
+		pendingTokens: make(map[common.Address]bool),
+		denylist:      NewDenylist(),
+	}
+	c.denylist.Tokens[honeypot] = DenyEntry{Denied: true}
+	tests := []struct {
+		token  common.Address
+		safe   bool
+		queued bool
+	}{
+		{fresh, true, false},
+		// A safe verdict that ran out is used until the token is simulated
+		// again.
+		{expired, true, true},
+		{unknown, false, true},
+		{honeypot, false, false},
+	}
+	for _, test := range tests {
+		path := Path{Tokens: []common.Address{weth, test.token, weth}}
+		if c.pathTokensSafe(path) != test.safe {
+			t.Errorf("token %s: safe is not %v", test.token, test.safe)
+		}
+		if c.pendingTokens[test.token] != test.queued {
+			t.Errorf("token %s: queued is not %v", test.token, test.queued)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// stateMigrations upgrade a state file one version at a time, the migration
+// at index i from version i to i+1. A change to Pool that old files can not
+// be read as is adds a migration here.
+var stateMigrations = []func(c *UniswapClient, summary *PoolSummaryFile){
+	// Version 1 added the dex registry.
+	func(c *UniswapClient, summary *PoolSummaryFile) {
+		if summary.FactoryCursors == nil {
+			summary.FactoryCursors = legacyFactoryCursors(c.profile.Dexes, summary.LastSeenBlock)
+		}
+		for address, pool := range summary.Pools {
+			if pool.Dex == "" {
+				pool.Dex = legacyDexName(pool.Type)
+			}
+			summary.Pools[address] = pool
+		}
+	},
+}
+
+var (
+	StateVersion = len(stateMigrations)
+
+	ErrChecksumMismatch = errors.New("state checksum mismatch")
+)
+
+// stateSnapshot is the layout of the state file. The checksum covers the
+// summary exactly as it is written.
+type stateSnapshot struct {
+	Checksum string          `json:"checksum"`
+	Summary  json.RawMessage `json:"summary"`
+}
+
+// writeFileAtomic writes the file next to its old version and renames it into
+// place, so a crash leaves either the old or the new file. The old file is
+// kept with a .bak suffix.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	_, err = temp.Write(data)
+	if err != nil {
+		temp.Close()
+		return err
+	}
+	err = temp.Sync()
+	if err != nil {
+		temp.Close()
+		return err
+	}
+	err = temp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(temp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	// A crash between the link and the rename leaves the link behind, and
+	// linking over it would fail every later write.
+	err = os.Remove(path + ".bak.new")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	err = os.Link(path, path+".bak.new")
+	if err == nil {
+		err = os.Rename(path+".bak.new", path+".bak")
+		if err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	err = os.Rename(temp.Name(), path)
+	if err != nil {
+		return err
+	}
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer dirFile.Close()
+	return dirFile.Sync()
+}
+
+func encodeState(summary PoolSummaryFile) ([]byte, error) {
+	summary.Version = StateVersion
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return json.Marshal(stateSnapshot{Checksum: hex.EncodeToString(sum[:]), Summary: data})
+}
+
+// decodeState reads a state file of any version. Files written before
+// snapshots are the bare summary.
+func decodeState(file []byte) (PoolSummaryFile, error) {
+	var summary PoolSummaryFile
+	var snapshot stateSnapshot
+	err := json.Unmarshal(file, &snapshot)
+	if err != nil {
+		return summary, err
+	}
+	if snapshot.Summary == nil {
+		err = json.Unmarshal(file, &summary)
+		return summary, err
+	}
+	sum := sha256.Sum256(snapshot.Summary)
+	if hex.EncodeToString(sum[:]) != snapshot.Checksum {
+		return summary, ErrChecksumMismatch
+	}
+	err = json.Unmarshal(snapshot.Summary, &summary)
+	return summary, err
+}
+
+// readStateFile reads the state file and falls back to the previous snapshot
+// when it is missing or corrupt. It returns false when neither exists.
+func (c *UniswapClient) readStateFile() (PoolSummaryFile, bool, error) {
+	paths := []string{c.statePath, c.statePath + ".bak"}
+	if c.profile.LegacyStatePath != "" {
+		paths = append(paths, c.profile.LegacyStatePath)
+	}
+	var firstErr error
+	for _, path := range paths {
+		file, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			var summary PoolSummaryFile
+			summary, err = decodeState(file)
+			if err == nil {
+				if path != c.statePath {
+					log.Warn().Str("path", path).Msg("state read from fallback file")
+				}
+				return summary, true, nil
+			}
+		}
+		log.Error().Err(err).Str("path", path).Msg("can not read state file")
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return PoolSummaryFile{}, false, firstErr
+}
+
+func (c *UniswapClient) migrateState(summary *PoolSummaryFile) error {
+	if summary.Version > StateVersion {
+		return fmt.Errorf("state version %d is newer than %d", summary.Version, StateVersion)
+	}
+	if summary.Pools == nil {
+		summary.Pools = make(map[common.Address]Pool)
+	}
+	for summary.Version < StateVersion {
+		stateMigrations[summary.Version](c, summary)
+		summary.Version++
+		log.Info().Str("chain", c.profile.Name).Int("version", summary.Version).Msg("state migrated")
+	}
+	return nil
+}
+
+func (c *UniswapClient) stateSummary() PoolSummaryFile {
+	return PoolSummaryFile{
+		Pools:          c.Pools,
+		LastSeenBlock:  c.LastSeenBlock,
+		FactoryCursors: c.FactoryCursors,
+	}
+}
+
+// snapshotState writes the state in the background once the snapshot
+// interval has passed. The state is encoded here, since the pools are only
+// safe to read from the run loop. A snapshot still being written is not
+// waited for.
+func (c *UniswapClient) snapshotState() {
+	interval := c.config.SnapshotInterval
+	if c.store != nil || interval == 0 || time.Since(c.lastSnapshot) < interval {
+		return
+	}
+	if !c.snapshotMu.TryLock() {
+		return
+	}
+	c.lastSnapshot = time.Now()
+	file, err := encodeState(c.stateSummary())
+	if err != nil {
+		c.snapshotMu.Unlock()
+		log.Error().Err(err).Msg("can not encode state")
+		return
+	}
+	go func() {
+		defer c.snapshotMu.Unlock()
+		now := time.Now()
+		err := writeFileAtomic(c.statePath, file)
+		if err != nil {
+			log.Error().Err(err).Msg("can not write state snapshot")
+			return
+		}
+		log.Info().Int("bytes", len(file)).Float64("seconds", time.Since(now).Seconds()).Msg("state snapshot written")
+	}()
+}
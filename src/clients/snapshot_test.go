@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWriteFileAtomicKeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	for _, content := range []string{"first", "second", "third"} {
+		err := writeFileAtomic(path, []byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if readTestFile(t, path) != "third" || readTestFile(t, path+".bak") != "second" {
+		t.Fatal("state and backup are not the last two writes")
+	}
+}
+
+// A crash after linking the old file to .bak.new and before renaming it to
+// .bak leaves .bak.new behind.
+func TestWriteFileAtomicAfterCrashBeforeBackupRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	err := writeFileAtomic(path, []byte("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Link(path, path+".bak.new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{"second", "third"} {
+		err = writeFileAtomic(path, []byte(content))
+		if err != nil {
+			t.Fatalf("write after crash: %v", err)
+		}
+	}
+	if readTestFile(t, path) != "third" || readTestFile(t, path+".bak") != "second" {
+		t.Fatal("state and backup are not the last two writes")
+	}
+	_, err = os.Stat(path + ".bak.new")
+	if !os.IsNotExist(err) {
+		t.Fatalf("stale link left behind: %v", err)
+	}
+}
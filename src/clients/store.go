@@ -0,0 +1,517 @@
+package clients
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/glebarez/sqlite"
+	"github.com/rs/zerolog/log"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"math/big"
+	"mev_bot/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	denyKindPool  = "pool"
+	denyKindToken = "token"
+
+	TradeSent   = "sent"
+	TradeFailed = "failed"
+	// Sent bundles are settled as landed or lost once their blocks passed.
+	TradeLanded = "landed"
+	TradeLost   = "lost"
+
+	storeBatchSize = 500
+)
+
+// StateStore keeps the state of every chain in a database, so a block only
+// writes the pools it changed.
+type StateStore interface {
+	// LoadState returns false when nothing was saved for the chain yet.
+	LoadState(chainId uint64) (PoolSummaryFile, bool, error)
+	// SaveBlock upserts the pools, deletes the removed ones and moves the
+	// cursors in one transaction.
+	SaveBlock(chainId uint64, pools []Pool, removed []common.Address, lastSeenBlock uint64, factoryCursors map[common.Address]uint64) error
+	LoadDenylist(chainId uint64) (Denylist, error)
+	// SaveDenylist replaces the denylist, SaveDenyEntry writes one entry.
+	SaveDenylist(chainId uint64, denylist Denylist) error
+	SaveDenyEntry(chainId uint64, kind string, address common.Address, entry DenyEntry) error
+	RecordTrade(trade TradeRecord) error
+	SettleTrade(chainId uint64, hash string, status string) error
+	// LoadSettledTrades returns up to limit of the latest landed and lost
+	// trades, oldest first.
+	LoadSettledTrades(chainId uint64, limit int) ([]TradeRecord, error)
+	Close() error
+}
+
+type TradeRecord struct {
+	ChainID            uint64
+	Block              uint64
+	Hash               string
+	Path               string
+	PathType           string
+	BorrowTokenAddress common.Address
+	BorrowAmount       *big.Int
+	Profit             *big.Int
+	ProfitETH          *big.Int
+	GasCost            *big.Int
+	Bribe              *big.Int
+	BribePercent       int64
+	BribeReason        string
+	Status             string
+	Error              string
+	CreatedAt          time.Time
+}
+
+type poolRow struct {
+	ChainID      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Address      string `gorm:"primaryKey;size:42"`
+	Dex          string `gorm:"index"`
+	Type         string
+	Token0       string `gorm:"size:42"`
+	Token1       string `gorm:"size:42"`
+	Enabled      bool
+	Reserve0     string
+	Reserve1     string
+	UpdatedBlock uint64
+	// Data holds the whole pool, including the v3, curve and balancer state.
+	Data []byte
+}
+
+type syncStateRow struct {
+	ChainID       uint64 `gorm:"primaryKey;autoIncrement:false"`
+	LastSeenBlock uint64
+	Version       int
+}
+
+type factoryCursorRow struct {
+	ChainID uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Factory string `gorm:"primaryKey;size:42"`
+	Block   uint64
+}
+
+type denyEntryRow struct {
+	ChainID          uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Kind             string `gorm:"primaryKey;size:8"`
+	Address          string `gorm:"primaryKey;size:42"`
+	Reason           string
+	Source           string
+	FirstSeenBlock   uint64
+	LastFailureBlock uint64
+	Failures         int
+	Denied           bool
+	ExpiresAt        time.Time
+}
+
+type tradeRow struct {
+	ID           uint64 `gorm:"primaryKey"`
+	ChainID      uint64 `gorm:"index"`
+	Block        uint64
+	Hash         string
+	Path         string
+	PathType     string
+	BorrowToken  string
+	BorrowAmount string
+	Profit       string
+	ProfitETH    string
+	GasCost      string
+	Bribe        string
+	BribePercent int64
+	BribeReason  string
+	Status       string
+	Error        string
+	CreatedAt    time.Time
+}
+
+func (poolRow) TableName() string          { return "pools" }
+func (syncStateRow) TableName() string     { return "sync_state" }
+func (factoryCursorRow) TableName() string { return "factory_cursors" }
+func (denyEntryRow) TableName() string     { return "deny_entries" }
+func (tradeRow) TableName() string         { return "trades" }
+
+type sqlStore struct {
+	db *gorm.DB
+}
+
+// OpenStateStore opens the database of the config. It returns nil when the
+// state is kept in JSON files.
+func OpenStateStore(cfg config.Config) (StateStore, error) {
+	switch cfg.Database.Driver {
+	case config.DatabaseSQLite:
+		return NewSQLiteStore(sqlitePath(cfg))
+	case config.DatabasePostgres:
+		return NewPostgresStore(cfg.Database.DSN)
+	}
+	return nil, nil
+}
+
+func sqlitePath(cfg config.Config) string {
+	if cfg.Database.DSN != "" {
+		return cfg.Database.DSN
+	}
+	return filepath.Join(cfg.StateDir, "state.db")
+}
+
+func NewSQLiteStore(path string) (StateStore, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	store, err := openStore(sqlite.Open(path + separator + "_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"))
+	if err != nil {
+		return nil, err
+	}
+	// SQLite has a single writer, and the chains share the store.
+	sqlDB, err := store.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return store, nil
+}
+
+func NewPostgresStore(dsn string) (StateStore, error) {
+	store, err := openStore(postgres.Open(dsn))
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func openStore(dialector gorm.Dialector) (*sqlStore, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&poolRow{}, &syncStateRow{}, &factoryCursorRow{}, &denyEntryRow{}, &tradeRow{})
+	if err != nil {
+		return nil, err
+	}
+	return &sqlStore{db: db}, nil
+}
+
+func (s *sqlStore) LoadState(chainId uint64) (PoolSummaryFile, bool, error) {
+	summary := PoolSummaryFile{
+		Pools:          make(map[common.Address]Pool),
+		FactoryCursors: make(map[common.Address]uint64),
+	}
+	var syncState syncStateRow
+	err := s.db.Where("chain_id = ?", chainId).Limit(1).Find(&syncState).Error
+	if err != nil {
+		return summary, false, err
+	}
+	if syncState.ChainID == 0 {
+		return summary, false, nil
+	}
+	summary.LastSeenBlock = syncState.LastSeenBlock
+	summary.Version = syncState.Version
+	var cursors []factoryCursorRow
+	err = s.db.Where("chain_id = ?", chainId).Find(&cursors).Error
+	if err != nil {
+		return summary, false, err
+	}
+	for _, cursor := range cursors {
+		summary.FactoryCursors[common.HexToAddress(cursor.Factory)] = cursor.Block
+	}
+	var rows []poolRow
+	err = s.db.Where("chain_id = ?", chainId).FindInBatches(&rows, storeBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, row := range rows {
+			var pool Pool
+			err := json.Unmarshal(row.Data, &pool)
+			if err != nil {
+				return err
+			}
+			summary.Pools[pool.Address] = pool
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return summary, false, err
+	}
+	return summary, true, nil
+}
+
+func (s *sqlStore) SaveBlock(chainId uint64, pools []Pool, removed []common.Address, lastSeenBlock uint64, factoryCursors map[common.Address]uint64) error {
+	rows := []poolRow{}
+	for _, pool := range pools {
+		data, err := json.Marshal(pool)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, poolRow{
+			ChainID:      chainId,
+			Address:      pool.Address.String(),
+			Dex:          pool.Dex,
+			Type:         pool.Type,
+			Token0:       pool.Token0.String(),
+			Token1:       pool.Token1.String(),
+			Enabled:      pool.Enabled,
+			Reserve0:     bigString(pool.Reserve0),
+			Reserve1:     bigString(pool.Reserve1),
+			UpdatedBlock: lastSeenBlock,
+			Data:         data,
+		})
+	}
+	removedAddresses := []string{}
+	for _, address := range removed {
+		removedAddresses = append(removedAddresses, address.String())
+	}
+	cursors := []factoryCursorRow{}
+	for factory, block := range factoryCursors {
+		cursors = append(cursors, factoryCursorRow{ChainID: chainId, Factory: factory.String(), Block: block})
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&rows, storeBatchSize).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(removedAddresses) > 0 {
+			err := tx.Where("chain_id = ? AND address IN ?", chainId, removedAddresses).Delete(&poolRow{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(cursors) > 0 {
+			err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&cursors).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&syncStateRow{ChainID: chainId, LastSeenBlock: lastSeenBlock, Version: StateVersion}).Error
+	})
+}
+
+func (s *sqlStore) LoadDenylist(chainId uint64) (Denylist, error) {
+	denylist := NewDenylist()
+	var rows []denyEntryRow
+	err := s.db.Where("chain_id = ?", chainId).Find(&rows).Error
+	if err != nil {
+		return denylist, err
+	}
+	for _, row := range rows {
+		entry := DenyEntry{
+			Reason:           row.Reason,
+			Source:           row.Source,
+			FirstSeenBlock:   row.FirstSeenBlock,
+			LastFailureBlock: row.LastFailureBlock,
+			Failures:         row.Failures,
+			Denied:           row.Denied,
+			ExpiresAt:        row.ExpiresAt,
+		}
+		if row.Kind == denyKindToken {
+			denylist.Tokens[common.HexToAddress(row.Address)] = entry
+		} else {
+			denylist.Pools[common.HexToAddress(row.Address)] = entry
+		}
+	}
+	return denylist, nil
+}
+
+func (s *sqlStore) SaveDenyEntry(chainId uint64, kind string, address common.Address, entry DenyEntry) error {
+	row := newDenyEntryRow(chainId, kind, address, entry)
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error
+}
+
+func newDenyEntryRow(chainId uint64, kind string, address common.Address, entry DenyEntry) denyEntryRow {
+	return denyEntryRow{
+		ChainID:          chainId,
+		Kind:             kind,
+		Address:          address.String(),
+		Reason:           entry.Reason,
+		Source:           entry.Source,
+		FirstSeenBlock:   entry.FirstSeenBlock,
+		LastFailureBlock: entry.LastFailureBlock,
+		Failures:         entry.Failures,
+		Denied:           entry.Denied,
+		ExpiresAt:        entry.ExpiresAt,
+	}
+}
+
+func (s *sqlStore) SaveDenylist(chainId uint64, denylist Denylist) error {
+	rows := []denyEntryRow{}
+	for address, entry := range denylist.Pools {
+		rows = append(rows, newDenyEntryRow(chainId, denyKindPool, address, entry))
+	}
+	for address, entry := range denylist.Tokens {
+		rows = append(rows, newDenyEntryRow(chainId, denyKindToken, address, entry))
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("chain_id = ?", chainId).Delete(&denyEntryRow{}).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&rows, storeBatchSize).Error
+	})
+}
+
+func (s *sqlStore) RecordTrade(trade TradeRecord) error {
+	createdAt := trade.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return s.db.Create(&tradeRow{
+		ChainID:      trade.ChainID,
+		Block:        trade.Block,
+		Hash:         trade.Hash,
+		Path:         trade.Path,
+		PathType:     trade.PathType,
+		BorrowToken:  trade.BorrowTokenAddress.String(),
+		BorrowAmount: bigString(trade.BorrowAmount),
+		Profit:       bigString(trade.Profit),
+		ProfitETH:    bigString(trade.ProfitETH),
+		GasCost:      bigString(trade.GasCost),
+		Bribe:        bigString(trade.Bribe),
+		BribePercent: trade.BribePercent,
+		BribeReason:  trade.BribeReason,
+		Status:       trade.Status,
+		Error:        trade.Error,
+		CreatedAt:    createdAt,
+	}).Error
+}
+
+func (s *sqlStore) SettleTrade(chainId uint64, hash string, status string) error {
+	return s.db.Model(&tradeRow{}).Where("chain_id = ? AND hash = ? AND status = ?", chainId, hash, TradeSent).Update("status", status).Error
+}
+
+func (s *sqlStore) LoadSettledTrades(chainId uint64, limit int) ([]TradeRecord, error) {
+	var rows []tradeRow
+	err := s.db.Where("chain_id = ? AND status IN ?", chainId, []string{TradeLanded, TradeLost}).Order("id desc").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	trades := []TradeRecord{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		trades = append(trades, TradeRecord{
+			ChainID:      row.ChainID,
+			Block:        row.Block,
+			Hash:         row.Hash,
+			Path:         row.Path,
+			PathType:     row.PathType,
+			BribePercent: row.BribePercent,
+			BribeReason:  row.BribeReason,
+			Status:       row.Status,
+			Error:        row.Error,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+	return trades, nil
+}
+
+func (s *sqlStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func bigString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
+
+// flushState writes the pools changed since the last flush. Without a store
+// the state is only written by SaveState.
+func (c *UniswapClient) flushState() error {
+	if c.store == nil {
+		return nil
+	}
+	pools := []Pool{}
+	removed := []common.Address{}
+	for address := range c.dirtyPools {
+		pool, ok := c.Pools[address]
+		if ok {
+			pools = append(pools, pool)
+		} else {
+			removed = append(removed, address)
+		}
+	}
+	err := c.store.SaveBlock(c.chainId.Uint64(), pools, removed, c.LastSeenBlock, c.FactoryCursors)
+	if err != nil {
+		return err
+	}
+	c.dirtyPools = make(map[common.Address]bool)
+	return nil
+}
+
+func (c *UniswapClient) recordTrade(tx ArbitrageTx, txHash *common.Hash, gasCost *big.Int, bribe *big.Int, decision BribeDecision, err error) {
+	if c.store == nil {
+		return
+	}
+	trade := TradeRecord{
+		ChainID:            c.chainId.Uint64(),
+		Block:              c.LastSeenBlock,
+		Path:               tx.Path,
+		PathType:           c.pathType(tx),
+		BorrowTokenAddress: tx.BorrowTokenAddress,
+		BorrowAmount:       tx.BorrowAmount,
+		Profit:             tx.Profit,
+		ProfitETH:          tx.ProfitETH,
+		GasCost:            gasCost,
+		Bribe:              bribe,
+		BribePercent:       decision.Percent,
+		BribeReason:        decision.Reason,
+		Status:             TradeSent,
+	}
+	if txHash != nil {
+		trade.Hash = txHash.String()
+	}
+	if err != nil {
+		trade.Status = TradeFailed
+		trade.Error = err.Error()
+	}
+	storeErr := c.store.RecordTrade(trade)
+	if storeErr != nil {
+		log.Error().Err(storeErr).Msg("can not record trade")
+	}
+}
+
+// settleTrade records whether the bundle of a sent trade landed.
+func (c *UniswapClient) settleTrade(txHash common.Hash, landed bool) {
+	if c.store == nil {
+		return
+	}
+	status := TradeLost
+	if landed {
+		status = TradeLanded
+	}
+	err := c.store.SettleTrade(c.chainId.Uint64(), txHash.String(), status)
+	if err != nil {
+		log.Error().Err(err).Str("tx", txHash.String()).Msg("can not settle trade")
+	}
+}
+
+// loadBribeHistory replays the latest settled trades into the bribe strategy,
+// so it does not start over on every restart.
+func (c *UniswapClient) loadBribeHistory() error {
+	if c.store == nil {
+		return nil
+	}
+	trades, err := c.store.LoadSettledTrades(c.chainId.Uint64(), bribeHistoryTrades)
+	if err != nil {
+		return err
+	}
+	for _, trade := range trades {
+		c.bribe.Record(trade.PathType, BribeDecision{Percent: trade.BribePercent, Reason: trade.BribeReason}, trade.Status == TradeLanded)
+	}
+	log.Info().Int("trades", len(trades)).Msg("bribe history loaded")
+	return nil
+}
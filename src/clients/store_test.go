@@ -0,0 +1,158 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStoreState(t *testing.T) {
+	// A DSN with a query of its own gets the pragmas appended to it.
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "state.db") + "?_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	_, found, err := store.LoadState(1)
+	if err != nil || found {
+		t.Fatalf("found %v, %v before saving", found, err)
+	}
+	factory := common.HexToAddress("0xf1")
+	v2 := Pool{Address: common.HexToAddress("0x10"), Token0: testWeth, Token1: testUsdc, Type: PoolTypeV2, Dex: "v2", Enabled: true, Reserve0: tokens(1), Reserve1: tokens(2)}
+	removed := Pool{Address: common.HexToAddress("0x11"), Token0: testUsdc, Token1: testDai, Type: PoolTypeV2, Dex: "v2", Enabled: true, Reserve0: tokens(3), Reserve1: tokens(3)}
+	err = store.SaveBlock(1, []Pool{v2, removed}, nil, 100, map[common.Address]uint64{factory: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The other chain keeps its own state.
+	err = store.SaveBlock(2, []Pool{removed}, nil, 7, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2.Reserve0 = tokens(5)
+	v2.Enabled = false
+	err = store.SaveBlock(1, []Pool{v2}, []common.Address{removed.Address}, 101, map[common.Address]uint64{factory: 101})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, found, err := store.LoadState(1)
+	if err != nil || !found {
+		t.Fatalf("found %v, %v", found, err)
+	}
+	if summary.LastSeenBlock != 101 || summary.Version != StateVersion || summary.FactoryCursors[factory] != 101 || len(summary.FactoryCursors) != 1 {
+		t.Fatalf("loaded block %d version %d cursors %v", summary.LastSeenBlock, summary.Version, summary.FactoryCursors)
+	}
+	if len(summary.Pools) != 1 || fmt.Sprintf("%+v", summary.Pools[v2.Address]) != fmt.Sprintf("%+v", v2) {
+		t.Fatalf("loaded %+v, want %+v", summary.Pools, v2)
+	}
+	other, found, err := store.LoadState(2)
+	if err != nil || !found || other.LastSeenBlock != 7 || len(other.Pools) != 1 {
+		t.Fatalf("chain 2: found %v at %d with %d pools, %v", found, other.LastSeenBlock, len(other.Pools), err)
+	}
+}
+
+func TestSQLiteStoreDenylist(t *testing.T) {
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	pool := common.HexToAddress("0x10")
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	denylist := NewDenylist()
+	denylist.Pools[pool] = DenyEntry{Reason: "reverted", Source: "simulation", FirstSeenBlock: 90, LastFailureBlock: 95, Failures: 2}
+	denylist.Tokens[testDai] = DenyEntry{Reason: "fee on transfer", Denied: true}
+	err = store.SaveDenylist(1, denylist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An entry is updated in place and a new one added.
+	updated := DenyEntry{Reason: "reverted", Source: "simulation", FirstSeenBlock: 90, LastFailureBlock: 99, Failures: 3, Denied: true, ExpiresAt: expiresAt}
+	err = store.SaveDenyEntry(1, denyKindPool, pool, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.SaveDenyEntry(1, denyKindToken, testUsdc, DenyEntry{Reason: "honeypot", Denied: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.SaveDenyEntry(2, denyKindToken, testWeth, DenyEntry{Reason: "other chain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.LoadDenylist(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Pools) != 1 || len(loaded.Tokens) != 2 {
+		t.Fatalf("loaded %d pools and %d tokens", len(loaded.Pools), len(loaded.Tokens))
+	}
+	entry := loaded.Pools[pool]
+	if !entry.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("expires at %s, want %s", entry.ExpiresAt, expiresAt)
+	}
+	entry.ExpiresAt = expiresAt
+	if entry != updated {
+		t.Fatalf("loaded %+v, want %+v", entry, updated)
+	}
+	if loaded.Tokens[testDai].Reason != "fee on transfer" || loaded.Tokens[testUsdc].Reason != "honeypot" {
+		t.Fatalf("loaded tokens %+v", loaded.Tokens)
+	}
+}
+
+func TestSQLiteStoreTrades(t *testing.T) {
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for i, status := range []string{TradeSent, TradeSent, TradeFailed, TradeSent, TradeSent} {
+		err := store.RecordTrade(TradeRecord{ChainID: 1, Block: uint64(100 + i), Hash: fmt.Sprint("0x", i), PathType: "v2", BribePercent: int64(50 + i), Status: status, Profit: big.NewInt(int64(i))})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = store.RecordTrade(TradeRecord{ChainID: 2, Hash: "0x0", Status: TradeSent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Trades are settled out of order, only sent ones can be settled, and
+	// settling a hash on one chain leaves the other alone.
+	settle := []struct {
+		hash   string
+		status string
+	}{{"0x3", TradeLost}, {"0x0", TradeLanded}, {"0x2", TradeLanded}, {"0x1", TradeLanded}, {"0x0", TradeLost}}
+	for _, trade := range settle {
+		err := store.SettleTrade(1, trade.hash, trade.status)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	trades, err := store.LoadSettledTrades(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, trade := range trades {
+		got = append(got, fmt.Sprint(trade.Hash, " ", trade.Status, " ", trade.BribePercent))
+	}
+	want := []string{"0x0 landed 50", "0x1 landed 51", "0x3 lost 53"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	// The limit keeps the latest trades, still oldest first.
+	trades, err = store.LoadSettledTrades(1, 2)
+	if err != nil || len(trades) != 2 || trades[0].Hash != "0x1" || trades[1].Hash != "0x3" {
+		t.Fatalf("got %+v, %v", trades, err)
+	}
+	trades, err = store.LoadSettledTrades(2, 10)
+	if err != nil || len(trades) != 0 {
+		t.Fatalf("chain 2 has %d settled trades, %v", len(trades), err)
+	}
+}
@@ -0,0 +1,179 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const logChannelSize = 4096
+
+// logBuffer collects the subscribed logs by block until the block is
+// processed.
+type logBuffer struct {
+	blocks map[common.Hash][]types.Log
+	// removed is the oldest block a removed log was seen for, zero if none.
+	removed uint64
+	// backfill is set when logs may have been missed, so the next block is
+	// fetched over HTTP.
+	backfill bool
+}
+
+func newLogBuffer() *logBuffer {
+	return &logBuffer{blocks: make(map[common.Hash][]types.Log)}
+}
+
+func (b *logBuffer) add(blockLog types.Log) {
+	if blockLog.Removed {
+		if b.removed == 0 || blockLog.BlockNumber < b.removed {
+			b.removed = blockLog.BlockNumber
+		}
+		return
+	}
+	b.blocks[blockLog.BlockHash] = append(b.blocks[blockLog.BlockHash], blockLog)
+}
+
+// take returns the logs of the block and drops the ones of older blocks.
+func (b *logBuffer) take(header *types.Header) []types.Log {
+	logs := b.blocks[header.Hash()]
+	for hash, blockLogs := range b.blocks {
+		if blockLogs[0].BlockNumber <= header.Number.Uint64() {
+			delete(b.blocks, hash)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].Index < logs[j].Index
+	})
+	return logs
+}
+
+// bloomMatches reports whether the block may hold a log ResolveLogs handles.
+// Blocks without one are processed without waiting for logs.
+func bloomMatches(header *types.Header) bool {
+	for _, topic := range blockLogTopics {
+		if types.BloomLookup(header.Bloom, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+func reconnectable(err error) bool {
+	return strings.Contains(err.Error(), "read: connection reset by peer") || strings.Contains(err.Error(), "i/o timeout")
+}
+
+func (c *UniswapClient) subscribeLogs(logCh chan types.Log) (ethereum.Subscription, error) {
+	return c.wsClient.SubscribeFilterLogs(c.ctx, ethereum.FilterQuery{
+		Topics: [][]common.Hash{blockLogTopics},
+	}, logCh)
+}
+
+// runSubscribed takes the logs of each block from a log subscription. A head
+// is processed once its logs stopped arriving for the settle time, or at
+// once when its bloom rules out any logs or a newer head arrived. Missed
+// blocks and dropped subscriptions are filled in over HTTP.
+func (c *UniswapClient) runSubscribed() error {
+	headerCh := make(chan *types.Header)
+	headSub, err := c.wsClient.SubscribeNewHead(c.ctx, headerCh)
+	if err != nil {
+		return err
+	}
+	defer headSub.Unsubscribe()
+	logCh := make(chan types.Log, logChannelSize)
+	logSub, err := c.subscribeLogs(logCh)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		logSub.Unsubscribe()
+	}()
+	pendingCh := make(chan *types.Transaction, pendingChannelSize)
+	pendingSub, err := c.watchMempool(pendingCh)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pendingSub.Unsubscribe()
+	}()
+	buffer := newLogBuffer()
+	heads := []*types.Header{}
+	var settleCh <-chan time.Time
+	settled := false
+	for {
+		select {
+		case header := <-headerCh:
+			heads = append(heads, header)
+			settled = false
+			settleCh = time.After(c.config.Ingestion.Settle)
+		case blockLog := <-logCh:
+			buffer.add(blockLog)
+			settled = false
+			settleCh = time.After(c.config.Ingestion.Settle)
+		case <-settleCh:
+			settled = true
+		case tx := <-pendingCh:
+			c.handlePendingTx(tx)
+		case err := <-pendingSub.Err():
+			log.Info().Err(err).Msg("pending transaction subscription dropped, resubscribing")
+			pendingSub, err = c.watchMempool(pendingCh)
+			if err != nil {
+				return err
+			}
+		case err := <-logSub.Err():
+			log.Info().Err(err).Msg("log subscription dropped, resubscribing")
+			logSub, err = c.subscribeLogs(logCh)
+			if err != nil {
+				return err
+			}
+			buffer.backfill = true
+		case err := <-headSub.Err():
+			if reconnectable(err) {
+				log.Info().Msg("Reconnecting...")
+				return c.Run()
+			}
+			return err
+		case <-c.ctx.Done():
+			return nil
+		}
+		for len(heads) > 0 {
+			header := heads[0]
+			if len(heads) == 1 && !settled && bloomMatches(header) {
+				break
+			}
+			heads = heads[1:]
+			err := c.ingestHead(header, buffer)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *UniswapClient) ingestHead(header *types.Header, buffer *logBuffer) error {
+	now := time.Now()
+	_, err := c.handleReorg(header)
+	if err != nil {
+		return err
+	}
+	if buffer.removed != 0 {
+		if buffer.removed <= c.LastSeenBlock {
+			c.rollback(buffer.removed - 1)
+		}
+		buffer.removed = 0
+	}
+	logs := c.trackedLogs(buffer.take(header), make(map[common.Address]bool))
+	if buffer.backfill || c.LastSeenBlock+1 != header.Number.Uint64() {
+		log.Info().Uint64("from", c.LastSeenBlock+1).Uint64("to", header.Number.Uint64()).Msg("backfilling logs")
+		logs, err = c.fetchLogs(header)
+		if err != nil {
+			return err
+		}
+		buffer.backfill = false
+	}
+	log.Info().Float64("untilLogs", time.Since(now).Seconds()).Msg("untilLogs")
+	return c.processBlock(header, logs, now)
+}
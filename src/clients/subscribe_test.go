@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestLogBuffer(t *testing.T) {
+	header := &types.Header{Number: big.NewInt(10), Extra: []byte("canonical")}
+	fork := &types.Header{Number: big.NewInt(10), Extra: []byte("fork")}
+	older := &types.Header{Number: big.NewInt(9)}
+	newer := &types.Header{Number: big.NewInt(11)}
+	blockLog := func(block *types.Header, index uint) types.Log {
+		return types.Log{BlockNumber: block.Number.Uint64(), BlockHash: block.Hash(), Index: index}
+	}
+	buffer := newLogBuffer()
+	for _, added := range []types.Log{
+		blockLog(header, 3),
+		blockLog(older, 0),
+		blockLog(header, 1),
+		blockLog(fork, 2),
+		blockLog(newer, 0),
+		blockLog(header, 2),
+	} {
+		buffer.add(added)
+	}
+	logs := buffer.take(header)
+	if len(logs) != 3 {
+		t.Fatalf("took %d logs, want 3", len(logs))
+	}
+	for i, taken := range logs {
+		if taken.BlockHash != header.Hash() || taken.Index != uint(i+1) {
+			t.Errorf("log %d is #%d of %s", i, taken.Index, taken.BlockHash)
+		}
+	}
+	// The fork and older blocks are dropped, the newer one waits for its
+	// head.
+	if len(buffer.blocks) != 1 || len(buffer.blocks[newer.Hash()]) != 1 {
+		t.Fatalf("kept %d blocks", len(buffer.blocks))
+	}
+	if len(buffer.take(header)) != 0 {
+		t.Fatal("took the logs of a block twice")
+	}
+	if logs := buffer.take(newer); len(logs) != 1 || len(buffer.blocks) != 0 {
+		t.Fatalf("took %d logs of the newer block and kept %d blocks", len(logs), len(buffer.blocks))
+	}
+}
+
+func TestLogBufferRemovedLogs(t *testing.T) {
+	buffer := newLogBuffer()
+	hash := common.HexToHash("0x0a")
+	for _, block := range []uint64{12, 8, 10} {
+		buffer.add(types.Log{BlockNumber: block, BlockHash: hash, Removed: true})
+	}
+	// Removed logs are not buffered, only the oldest block they were in is
+	// kept to roll back to.
+	if buffer.removed != 8 || len(buffer.blocks) != 0 {
+		t.Fatalf("removed %d with %d blocks buffered", buffer.removed, len(buffer.blocks))
+	}
+	buffer.removed = 0
+	buffer.add(types.Log{BlockNumber: 15, BlockHash: hash, Removed: true})
+	if buffer.removed != 15 {
+		t.Fatalf("removed %d, want 15", buffer.removed)
+	}
+}
+
+// A head waits for its logs to settle only when its bloom says it has some.
+func TestBloomMatches(t *testing.T) {
+	tracked := types.Bloom{}
+	tracked.Add(blockLogTopics[len(blockLogTopics)-1].Bytes())
+	other := types.Bloom{}
+	other.Add(common.HexToHash("0x01").Bytes())
+	tests := []struct {
+		name    string
+		bloom   types.Bloom
+		matches bool
+	}{
+		{"tracked topic", tracked, true},
+		{"other topic", other, false},
+		{"empty block", types.Bloom{}, false},
+	}
+	for _, test := range tests {
+		if bloomMatches(&types.Header{Bloom: test.bloom}) != test.matches {
+			t.Errorf("%s: matches %v, want %v", test.name, !test.matches, test.matches)
+		}
+	}
+}
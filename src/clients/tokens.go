@@ -0,0 +1,447 @@
+package clients
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"io"
+	"mev_bot/config"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRateLimited = errors.New("rate limited")
+
+	coinMarketCapURL        = "https://pro-api.coinmarketcap.com"
+	coinMarketCapMaxRetries = 3
+)
+
+type Token struct {
+	Symbol          string `json:"symbol"`
+	ContractAddress string `json:"contract_address"`
+	Rank            int    `json:"rank"`
+	Source          string `json:"source,omitempty"`
+}
+
+// TokenSource returns the tokens it knows on the given chain.
+type TokenSource interface {
+	Name() string
+	Tokens(ctx context.Context, chainId int64) ([]Token, error)
+}
+
+// TokenListSource reads a token list in the Uniswap Token Lists format from a
+// file or an http(s) URL.
+type TokenListSource struct {
+	Location string
+}
+
+type tokenList struct {
+	Name   string           `json:"name"`
+	Tokens []tokenListEntry `json:"tokens"`
+}
+
+type tokenListEntry struct {
+	ChainID  int64  `json:"chainId"`
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+func (s TokenListSource) Name() string {
+	return s.Location
+}
+
+func (s TokenListSource) Tokens(ctx context.Context, chainId int64) ([]Token, error) {
+	var body []byte
+	var err error
+	if strings.HasPrefix(s.Location, "http://") || strings.HasPrefix(s.Location, "https://") {
+		body, err = httpGet(ctx, s.Location, nil)
+	} else {
+		body, err = os.ReadFile(s.Location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list tokenList
+	err = json.Unmarshal(body, &list)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []Token{}
+	for i, entry := range list.Tokens {
+		if entry.ChainID != chainId || !common.IsHexAddress(entry.Address) {
+			continue
+		}
+		tokens = append(tokens, Token{
+			Symbol:          entry.Symbol,
+			ContractAddress: common.HexToAddress(entry.Address).Hex(),
+			Rank:            i + 1,
+			Source:          s.Name(),
+		})
+	}
+	return tokens, nil
+}
+
+// AllowlistSource reads a file with one token address per line. Anything after
+// a # is a comment.
+type AllowlistSource struct {
+	Path string
+}
+
+func (s AllowlistSource) Name() string {
+	return s.Path
+}
+
+func (s AllowlistSource) Tokens(ctx context.Context, chainId int64) ([]Token, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	tokens := []Token{}
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if !common.IsHexAddress(text) {
+			return nil, fmt.Errorf("%s:%d: %q is not an address", s.Path, line, text)
+		}
+		tokens = append(tokens, Token{
+			ContractAddress: common.HexToAddress(text).Hex(),
+			Source:          s.Name(),
+		})
+	}
+	return tokens, scanner.Err()
+}
+
+// CoinMarketCapSource lists the tokens with the highest 24h volume on
+// CoinMarketCap that are deployed on Platform.
+type CoinMarketCapSource struct {
+	APIKey   string
+	Limit    int
+	Platform string
+}
+
+type coinMarketCapStatus struct {
+	ErrorCode    int    `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+func (s coinMarketCapStatus) err() error {
+	if s.ErrorCode == 0 {
+		return nil
+	}
+	return fmt.Errorf("coinmarketcap error %d: %s", s.ErrorCode, s.ErrorMessage)
+}
+
+type coinMarketCapListing struct {
+	ID     int    `json:"id"`
+	Symbol string `json:"symbol"`
+	Rank   int    `json:"cmc_rank"`
+}
+
+type coinMarketCapContract struct {
+	ContractAddress string `json:"contract_address"`
+	Platform        struct {
+		Name string `json:"name"`
+	} `json:"platform"`
+}
+
+type coinMarketCapInfo struct {
+	ID        int                     `json:"id"`
+	Contracts []coinMarketCapContract `json:"contract_address"`
+}
+
+func (s CoinMarketCapSource) Name() string {
+	return "coinmarketcap"
+}
+
+func (s CoinMarketCapSource) Tokens(ctx context.Context, chainId int64) ([]Token, error) {
+	if s.Platform == "" {
+		return nil, fmt.Errorf("no coinmarketcap platform for chain %d", chainId)
+	}
+	var listings struct {
+		Status coinMarketCapStatus    `json:"status"`
+		Data   []coinMarketCapListing `json:"data"`
+	}
+	path := fmt.Sprintf("/v1/cryptocurrency/listings/latest?limit=%d&sort=volume_24h&sort_dir=desc&aux=cmc_rank", s.Limit)
+	err := s.get(ctx, path, &listings)
+	if err == nil {
+		err = listings.Status.err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(listings.Data) == 0 {
+		return []Token{}, nil
+	}
+	ids := []string{}
+	for _, listing := range listings.Data {
+		ids = append(ids, strconv.Itoa(listing.ID))
+	}
+	var infos struct {
+		Status coinMarketCapStatus          `json:"status"`
+		Data   map[string]coinMarketCapInfo `json:"data"`
+	}
+	err = s.get(ctx, "/v2/cryptocurrency/info?id="+strings.Join(ids, ","), &infos)
+	if err == nil {
+		err = infos.Status.err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	tokens := []Token{}
+	for _, listing := range listings.Data {
+		info, ok := infos.Data[strconv.Itoa(listing.ID)]
+		if !ok {
+			continue
+		}
+		for _, contract := range info.Contracts {
+			if contract.Platform.Name != s.Platform || !common.IsHexAddress(contract.ContractAddress) {
+				continue
+			}
+			tokens = append(tokens, Token{
+				Symbol:          listing.Symbol,
+				ContractAddress: common.HexToAddress(contract.ContractAddress).Hex(),
+				Rank:            listing.Rank,
+				Source:          s.Name(),
+			})
+		}
+	}
+	return tokens, nil
+}
+
+// get retries rate limited requests a few times, waiting as long as the
+// Retry-After header asks or a minute otherwise.
+func (s CoinMarketCapSource) get(ctx context.Context, path string, res interface{}) error {
+	headers := map[string]string{
+		"X-CMC_PRO_API_KEY": s.APIKey,
+		"Accept":            "application/json",
+	}
+	for attempt := 0; ; attempt++ {
+		body, err := httpGet(ctx, coinMarketCapURL+path, headers)
+		var rateLimit *rateLimitError
+		if errors.As(err, &rateLimit) && attempt < coinMarketCapMaxRetries {
+			log.Warn().Str("path", path).Dur("wait", rateLimit.wait).Msg("coinmarketcap rate limit")
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(rateLimit.wait):
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, res)
+	}
+}
+
+type rateLimitError struct {
+	wait time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+func (e *rateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+func httpGet(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait := time.Minute
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+		return nil, &rateLimitError{wait: wait}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s: %s", req.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// MergeTokens deduplicates tokens by address. The first source listing a token
+// names it, and the best rank any source gave it is kept.
+func MergeTokens(lists ...[]Token) []Token {
+	merged := []Token{}
+	positions := make(map[common.Address]int)
+	for _, tokens := range lists {
+		for _, token := range tokens {
+			address := common.HexToAddress(token.ContractAddress)
+			position, ok := positions[address]
+			if !ok {
+				positions[address] = len(merged)
+				merged = append(merged, token)
+				continue
+			}
+			existing := &merged[position]
+			if existing.Symbol == "" {
+				existing.Symbol = token.Symbol
+			}
+			if token.Rank > 0 && (existing.Rank == 0 || token.Rank < existing.Rank) {
+				existing.Rank = token.Rank
+			}
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Rank == 0 || merged[j].Rank == 0 {
+			return merged[j].Rank == 0 && merged[i].Rank != 0
+		}
+		return merged[i].Rank < merged[j].Rank
+	})
+	return merged
+}
+
+func (c *UniswapClient) tokenSources() []TokenSource {
+	tokens := c.config.Tokens
+	sources := []TokenSource{}
+	for _, location := range tokens.Lists {
+		sources = append(sources, TokenListSource{Location: location})
+	}
+	for _, path := range tokens.Allowlists {
+		sources = append(sources, AllowlistSource{Path: path})
+	}
+	if tokens.CoinMarketCap.APIKey != "" {
+		sources = append(sources, CoinMarketCapSource{
+			APIKey:   tokens.CoinMarketCap.APIKey,
+			Limit:    tokens.CoinMarketCap.Limit,
+			Platform: c.profile.CoinMarketCapPlatform,
+		})
+	}
+	return sources
+}
+
+// fetchTokens merges every source. A source that fails is logged and skipped
+// as long as another one returned tokens.
+func (c *UniswapClient) fetchTokens() ([]Token, error) {
+	lists := [][]Token{}
+	problems := []error{}
+	for _, source := range c.tokenSources() {
+		tokens, err := source.Tokens(c.ctx, c.profile.ChainID)
+		if err != nil {
+			log.Error().Err(err).Str("source", source.Name()).Msg("can not get tokens")
+			problems = append(problems, fmt.Errorf("%s: %w", source.Name(), err))
+			continue
+		}
+		log.Info().Str("source", source.Name()).Int("tokens", len(tokens)).Msg("got tokens")
+		lists = append(lists, tokens)
+	}
+	if len(lists) == 0 {
+		return nil, errors.Join(problems...)
+	}
+	return MergeTokens(lists...), nil
+}
+
+// ReadTokens fills the token filter from the merged list in the state dir, and
+// fetches the sources again when it is older than the refresh interval. The
+// stale list is used when the sources can not be reached.
+func (c *UniswapClient) ReadTokens() error {
+	var tokens []Token
+	info, statErr := os.Stat(c.tokensPath)
+	if statErr == nil && time.Since(info.ModTime()) < c.config.Tokens.RefreshInterval {
+		file, err := os.ReadFile(c.tokensPath)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(file, &tokens)
+		if err != nil {
+			return err
+		}
+	} else {
+		fetched, err := c.fetchTokens()
+		if err != nil && statErr != nil {
+			return err
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("using stale token list")
+			file, err := os.ReadFile(c.tokensPath)
+			if err != nil {
+				return err
+			}
+			err = json.Unmarshal(file, &tokens)
+			if err != nil {
+				return err
+			}
+		} else {
+			tokens = fetched
+			err = c.saveTokens(tokens)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	c.tokenMap = make(map[common.Address]bool)
+	for _, token := range tokens {
+		c.tokenMap[common.HexToAddress(token.ContractAddress)] = true
+	}
+	for _, token := range c.profile.BaseTokens {
+		c.tokenMap[token.Address] = true
+	}
+	log.Info().Str("chain", c.profile.Name).Int("tokens", len(c.tokenMap)).Msg("token filter loaded")
+	return nil
+}
+
+func (c *UniswapClient) saveTokens(tokens []Token) error {
+	file, err := json.MarshalIndent(tokens, "", " ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.tokensPath, file)
+}
+
+// tokenPolicyAllows applies the configured token policy to a new pool.
+func (c *UniswapClient) tokenPolicyAllows(pool Pool) bool {
+	policy := c.config.Tokens.Policy
+	if policy == config.TokenPolicyOff {
+		return true
+	}
+	// Balancer pools only learn their tokens when they are loaded.
+	if pool.Type == PoolTypeBalancer && len(pool.Coins) == 0 {
+		return true
+	}
+	listed := 0
+	coins := pool.coins()
+	for _, coin := range coins {
+		if c.tokenMap[coin] {
+			listed++
+		}
+	}
+	if policy == config.TokenPolicyAll {
+		return listed == len(coins)
+	}
+	return listed > 0
+}
@@ -0,0 +1,175 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"mev_bot/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeTokens(t *testing.T) {
+	usdc := "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	weth := "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+	dai := "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+	pepe := "0x6982508145454Ce325dDbE47a25d4ec3d2311933"
+	merged := MergeTokens(
+		[]Token{
+			{ContractAddress: usdc, Source: "allowlist"},
+			{ContractAddress: pepe, Source: "allowlist"},
+		},
+		[]Token{
+			// Addresses are told apart whatever their case.
+			{Symbol: "USDC", ContractAddress: strings.ToLower(usdc), Rank: 3, Source: "list"},
+			{Symbol: "WETH", ContractAddress: weth, Rank: 2, Source: "list"},
+			{Symbol: "DAI", ContractAddress: dai, Rank: 9, Source: "list"},
+		},
+		[]Token{
+			{Symbol: "Dai", ContractAddress: dai, Rank: 4, Source: "coinmarketcap"},
+			{Symbol: "Wrapped Ether", ContractAddress: weth, Rank: 5, Source: "coinmarketcap"},
+		},
+	)
+	// Ranked tokens come first by their best rank, unranked ones keep their
+	// order after them.
+	want := []Token{
+		{Symbol: "WETH", ContractAddress: weth, Rank: 2, Source: "list"},
+		{Symbol: "USDC", ContractAddress: usdc, Rank: 3, Source: "allowlist"},
+		{Symbol: "DAI", ContractAddress: dai, Rank: 4, Source: "list"},
+		{ContractAddress: pepe, Source: "allowlist"},
+	}
+	if fmt.Sprintf("%+v", merged) != fmt.Sprintf("%+v", want) {
+		t.Fatalf("got %+v, want %+v", merged, want)
+	}
+	if len(MergeTokens()) != 0 {
+		t.Fatal("merged tokens out of nothing")
+	}
+}
+
+func TestTokenPolicyAllows(t *testing.T) {
+	listed := common.HexToAddress("0x01")
+	other := common.HexToAddress("0x02")
+	third := common.HexToAddress("0x03")
+	c := &UniswapClient{config: config.Default(), tokenMap: map[common.Address]bool{listed: true, third: true}}
+	bothListed := Pool{Type: PoolTypeV2, Token0: listed, Token1: third}
+	oneListed := Pool{Type: PoolTypeV2, Token0: listed, Token1: other}
+	noneListed := Pool{Type: PoolTypeV2, Token0: other, Token1: common.HexToAddress("0x04")}
+	curve := Pool{Type: PoolTypeCurve, Token0: listed, Token1: third, Coins: []common.Address{listed, third, other}}
+	unloadedBalancer := Pool{Type: PoolTypeBalancer}
+	tests := []struct {
+		policy string
+		pool   Pool
+		allows bool
+	}{
+		{config.TokenPolicyOff, noneListed, true},
+		{config.TokenPolicyAny, bothListed, true},
+		{config.TokenPolicyAny, oneListed, true},
+		{config.TokenPolicyAny, noneListed, false},
+		{config.TokenPolicyAny, curve, true},
+		{config.TokenPolicyAny, unloadedBalancer, true},
+		{config.TokenPolicyAll, bothListed, true},
+		{config.TokenPolicyAll, oneListed, false},
+		{config.TokenPolicyAll, noneListed, false},
+		// Every coin of a pool counts, not only the first two.
+		{config.TokenPolicyAll, curve, false},
+		{config.TokenPolicyAll, unloadedBalancer, true},
+	}
+	for i, test := range tests {
+		c.config.Tokens.Policy = test.policy
+		if c.tokenPolicyAllows(test.pool) != test.allows {
+			t.Errorf("%d: %s policy allows %v, want %v", i, test.policy, !test.allows, test.allows)
+		}
+	}
+}
+
+const testTokenList = `{
+	"name": "Test List",
+	"tokens": [
+		{"chainId": 1, "address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "symbol": "USDC", "decimals": 6},
+		{"chainId": 10, "address": "0x7F5c764cBc14f9669B88837ca1490cCa17c31607", "symbol": "USDC.e", "decimals": 6},
+		{"chainId": 1, "address": "not an address", "symbol": "BAD", "decimals": 18},
+		{"chainId": 1, "address": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "symbol": "WETH", "decimals": 18}
+	]
+}`
+
+func TestTokenListSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list.json":
+			w.Write([]byte(testTokenList))
+		case "/limited.json":
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/limited-without-header.json":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			http.Error(w, "no such list", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "list.json")
+	err := os.WriteFile(path, []byte(testTokenList), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := func(source string) string {
+		return fmt.Sprintf("%+v", []Token{
+			{Symbol: "USDC", ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Rank: 1, Source: source},
+			{Symbol: "WETH", ContractAddress: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Rank: 4, Source: source},
+		})
+	}
+	// The list is read from a URL and a file alike, keeping the tokens of
+	// the chain with a valid address.
+	for _, location := range []string{server.URL + "/list.json", path} {
+		tokens, err := TokenListSource{Location: location}.Tokens(context.Background(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprintf("%+v", tokens) != want(location) {
+			t.Errorf("%s: got %+v, want %s", location, tokens, want(location))
+		}
+	}
+
+	_, err = TokenListSource{Location: server.URL + "/missing.json"}.Tokens(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), "404 Not Found: no such list") {
+		t.Fatalf("missing list: %v", err)
+	}
+	for location, wait := range map[string]time.Duration{"/limited.json": 7 * time.Second, "/limited-without-header.json": time.Minute} {
+		_, err = TokenListSource{Location: server.URL + location}.Tokens(context.Background(), 1)
+		var rateLimit *rateLimitError
+		if !errors.Is(err, ErrRateLimited) || !errors.As(err, &rateLimit) || rateLimit.wait != wait {
+			t.Fatalf("%s: got %v, want a wait of %s", location, err, wait)
+		}
+	}
+}
+
+// The CoinMarketCap source waits out a rate limit, but gives up when the
+// context ends first.
+func TestCoinMarketCapRateLimit(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-CMC_PRO_API_KEY") != "key" {
+			t.Errorf("sent the key %q", r.Header.Get("X-CMC_PRO_API_KEY"))
+		}
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	defer func(url string) {
+		coinMarketCapURL = url
+	}(coinMarketCapURL)
+	coinMarketCapURL = server.URL
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := CoinMarketCapSource{APIKey: "key", Limit: 10, Platform: "Ethereum"}.Tokens(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) || requests != 1 {
+		t.Fatalf("got %v after %d requests", err, requests)
+	}
+}
@@ -0,0 +1,188 @@
+package clients
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/contracts"
+)
+
+func (p *Pool) v3State() *amm.V3State {
+	if p.V3 == nil {
+		p.V3 = amm.NewV3State(amm.TickSpacingForFee(p.Fee))
+	}
+	return p.V3
+}
+
+func (c *UniswapClient) applyV3Ticks(logs []types.Log, newPools []Pool) error {
+	contract, err := contracts.NewIEvents(c.address, c.client)
+	if err != nil {
+		return err
+	}
+	states := make(map[common.Address]*amm.V3State)
+	for _, pool := range newPools {
+		if pool.V3 != nil {
+			states[pool.Address] = pool.V3
+		}
+	}
+	for address, pool := range c.Pools {
+		if pool.Type != PoolTypeV3 {
+			continue
+		}
+		states[address] = pool.v3State()
+		c.Pools[address] = pool
+	}
+	applied := 0
+	for _, blockLog := range logs {
+		state, ok := states[blockLog.Address]
+		if !ok {
+			continue
+		}
+		mintEvent, err := contract.ParseMint(blockLog)
+		if err == nil {
+			state.ApplyLog(blockLog.BlockNumber, blockLog.Index, int(mintEvent.TickLower.Int64()), int(mintEvent.TickUpper.Int64()), mintEvent.Amount)
+			applied++
+			continue
+		}
+		burnEvent, err := contract.ParseBurn(blockLog)
+		if err == nil {
+			state.ApplyLog(blockLog.BlockNumber, blockLog.Index, int(burnEvent.TickLower.Int64()), int(burnEvent.TickUpper.Int64()), new(big.Int).Neg(burnEvent.Amount))
+			applied++
+		}
+	}
+	log.Info().Int("applied", applied).Int("total", len(logs)).Msg("v3 tick summary")
+	return nil
+}
+
+// applyV3Log applies a Swap, Mint or Burn event to the pool. It returns false
+// for other events.
+func (c *UniswapClient) applyV3Log(contract *contracts.IEvents, pool Pool, blockLog types.Log) bool {
+	state := pool.v3State()
+	swapEvent, err := contract.ParseSwap(blockLog)
+	if err == nil {
+		state.SetSlot(blockLog.BlockNumber, blockLog.Index, swapEvent.SqrtPriceX96, swapEvent.Liquidity, int(swapEvent.Tick.Int64()))
+		c.setPool(pool)
+		return true
+	}
+	mintEvent, err := contract.ParseMint(blockLog)
+	if err == nil {
+		state.ApplyLog(blockLog.BlockNumber, blockLog.Index, int(mintEvent.TickLower.Int64()), int(mintEvent.TickUpper.Int64()), mintEvent.Amount)
+		c.setPool(pool)
+		return true
+	}
+	burnEvent, err := contract.ParseBurn(blockLog)
+	if err == nil {
+		state.ApplyLog(blockLog.BlockNumber, blockLog.Index, int(burnEvent.TickLower.Int64()), int(burnEvent.TickUpper.Int64()), new(big.Int).Neg(burnEvent.Amount))
+		c.setPool(pool)
+		return true
+	}
+	return false
+}
+
+// v3Slot is the price, tick and active liquidity of a v3 pool at a block.
+type v3Slot struct {
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+}
+
+// readV3Slots reads slot0 and liquidity of the pools at the block through
+// Multicall3. Pools whose calls fail are left out.
+func (c *UniswapClient) readV3Slots(addresses []common.Address, blockNumber uint64) (map[common.Address]v3Slot, error) {
+	poolAbi, err := contracts.IUniswapV3PoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	multicall, err := contracts.IMulticall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	slot0Data, err := poolAbi.Pack("slot0")
+	if err != nil {
+		return nil, err
+	}
+	liquidityData, err := poolAbi.Pack("liquidity")
+	if err != nil {
+		return nil, err
+	}
+	slots := make(map[common.Address]v3Slot)
+	batchSize := c.config.Batches.Reserves
+	for i := 0; i < len(addresses); i += batchSize {
+		end := i + batchSize
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		batch := addresses[i:end]
+		calls := []contracts.IMulticall3Call3{}
+		for _, address := range batch {
+			calls = append(calls,
+				contracts.IMulticall3Call3{Target: address, AllowFailure: true, CallData: slot0Data},
+				contracts.IMulticall3Call3{Target: address, AllowFailure: true, CallData: liquidityData},
+			)
+		}
+		data, err := multicall.Pack("aggregate3", calls)
+		if err != nil {
+			return nil, err
+		}
+		out, err := c.client.CallContract(c.ctx, ethereum.CallMsg{To: &multicall3, Data: data}, new(big.Int).SetUint64(blockNumber))
+		if err != nil {
+			return nil, err
+		}
+		values, err := multicall.Unpack("aggregate3", out)
+		if err != nil {
+			return nil, err
+		}
+		results := *abi.ConvertType(values[0], new([]contracts.IMulticall3Result)).(*[]contracts.IMulticall3Result)
+		if len(results) != len(calls) {
+			return nil, fmt.Errorf("got %d results for %d calls", len(results), len(calls))
+		}
+		for j, address := range batch {
+			slot0, liquidity := results[2*j], results[2*j+1]
+			if !slot0.Success || !liquidity.Success {
+				continue
+			}
+			slot0Values, err := poolAbi.Unpack("slot0", slot0.ReturnData)
+			if err != nil {
+				continue
+			}
+			liquidityValues, err := poolAbi.Unpack("liquidity", liquidity.ReturnData)
+			if err != nil {
+				continue
+			}
+			slots[address] = v3Slot{
+				SqrtPriceX96: slot0Values[0].(*big.Int),
+				Tick:         int(slot0Values[1].(*big.Int).Int64()),
+				Liquidity:    liquidityValues[0].(*big.Int),
+			}
+		}
+	}
+	return slots, nil
+}
+
+// seedV3Slots sets price, tick and active liquidity of every v3 pool as of
+// the block. The Mint and Burn logs up to the block have to be applied
+// already, the later ones and the Swap logs keep the state current.
+func (c *UniswapClient) seedV3Slots(blockNumber uint64) error {
+	addresses := []common.Address{}
+	for address, pool := range c.Pools {
+		if pool.Type == PoolTypeV3 {
+			addresses = append(addresses, address)
+		}
+	}
+	slots, err := c.readV3Slots(addresses, blockNumber)
+	if err != nil {
+		return err
+	}
+	for address, slot := range slots {
+		pool := c.Pools[address]
+		pool.v3State().Seed(slot.SqrtPriceX96, slot.Liquidity, slot.Tick)
+		c.setPool(pool)
+	}
+	log.Info().Int("seeded", len(slots)).Int("total", len(addresses)).Msg("v3 slot summary")
+	return nil
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"mev_bot/amm"
+	"mev_bot/contracts"
+	"testing"
+)
+
+func eventLog(t *testing.T, pool common.Address, index uint, name string, indexed []interface{}, data ...interface{}) types.Log {
+	t.Helper()
+	eventsAbi, err := contracts.IEventsMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := eventsAbi.Events[name]
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockLog := types.Log{Address: pool, Topics: []common.Hash{event.ID}, Data: packed, BlockNumber: 100, Index: index}
+	// abi.MakeTopics drops the sign of negative ints.
+	for _, value := range indexed {
+		switch value := value.(type) {
+		case common.Address:
+			blockLog.Topics = append(blockLog.Topics, common.BytesToHash(value.Bytes()))
+		case *big.Int:
+			blockLog.Topics = append(blockLog.Topics, common.BytesToHash(math.U256Bytes(new(big.Int).Set(value))))
+		}
+	}
+	return blockLog
+}
+
+func tokens(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+// The expected amounts are from the swap snapshots of Uniswap v3-core for
+// the medium fee pool at a 1:1 price with 2e18 of full range liquidity.
+func TestV3LogReplayQuote(t *testing.T) {
+	address := common.HexToAddress("0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8")
+	pool := Pool{
+		Address: address,
+		Token0:  common.HexToAddress("0x01"),
+		Token1:  common.HexToAddress("0x02"),
+		Fee:     big.NewInt(3000),
+		Type:    PoolTypeV3,
+		V3:      amm.NewV3State(60),
+	}
+	pool.V3.Synced = true
+	pool.V3.Seed(amm.Q96, big.NewInt(0), 0)
+	c := &UniswapClient{
+		Pools:      map[common.Address]Pool{address: pool},
+		dirtyPools: make(map[common.Address]bool),
+		index:      NewPoolIndex(nil, 3),
+	}
+	contract, err := contracts.NewIEvents(address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := common.HexToAddress("0x03")
+	minTick, maxTick := big.NewInt(-887220), big.NewInt(887220)
+	logs := []types.Log{
+		eventLog(t, address, 1, "Mint", []interface{}{owner, minTick, maxTick}, owner, tokens(3), tokens(3), tokens(3)),
+		eventLog(t, address, 2, "Burn", []interface{}{owner, minTick, maxTick}, tokens(1), tokens(1), tokens(1)),
+		eventLog(t, address, 3, "Mint", []interface{}{owner, big.NewInt(600), big.NewInt(1200)}, owner, tokens(5), big.NewInt(0), tokens(1)),
+	}
+	for _, blockLog := range logs {
+		if !c.applyV3Log(contract, c.Pools[address], blockLog) {
+			t.Fatalf("log %d not applied", blockLog.Index)
+		}
+	}
+	state := c.Pools[address].V3
+	if state.Liquidity.Cmp(tokens(2)) != 0 {
+		t.Fatalf("active liquidity %s, want %s", state.Liquidity, tokens(2))
+	}
+	if len(state.Ticks) != 4 {
+		t.Fatalf("%d initialized ticks, want 4", len(state.Ticks))
+	}
+	// A swap there and back leaves the pool where it was.
+	swap := eventLog(t, address, 4, "Swap", []interface{}{owner, owner}, big.NewInt(1), big.NewInt(-1), amm.Q96, tokens(2), big.NewInt(0))
+	if !c.applyV3Log(contract, c.Pools[address], swap) {
+		t.Fatal("swap not applied")
+	}
+	amountOut := c.Pools[address].quoteHop(pool.Token0, pool.Token1, tokens(1))
+	want, _ := new(big.Int).SetString("665331998665331998", 10)
+	if amountOut.Cmp(want) != 0 {
+		t.Fatalf("quote %s, want %s", amountOut, want)
+	}
+	// Logs that were applied already are skipped.
+	c.applyV3Log(contract, c.Pools[address], logs[0])
+	if c.Pools[address].V3.Liquidity.Cmp(tokens(2)) != 0 {
+		t.Fatal("replayed mint was applied twice")
+	}
+}
@@ -0,0 +1,681 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	PrivateKey string `yaml:"privateKey"`
+	StateDir   string `yaml:"stateDir"`
+	// SnapshotInterval is how often the JSON state is written while running.
+	// Zero only writes it after the initial sync and on shutdown.
+	SnapshotInterval time.Duration `yaml:"snapshotInterval"`
+	// ReorgDepth is how many blocks can be rolled back on a reorg.
+	ReorgDepth    int                 `yaml:"reorgDepth"`
+	Chains        []ChainConfig       `yaml:"chains"`
+	Strategy      StrategyConfig      `yaml:"strategy"`
+	Batches       BatchConfig         `yaml:"batches"`
+	Tokens        TokensConfig        `yaml:"tokens"`
+	Denylist      DenylistConfig      `yaml:"denylist"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Ingestion     IngestionConfig     `yaml:"ingestion"`
+	Mempool       MempoolConfig       `yaml:"mempool"`
+	Bundles       BundlesConfig       `yaml:"bundles"`
+	Fees          FeesConfig          `yaml:"fees"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+}
+
+type ChainConfig struct {
+	// Name is optional. When set, the RPC has to report the chain of the
+	// profile with that name.
+	Name          string `yaml:"name"`
+	RPCURL        string `yaml:"rpcUrl"`
+	WSURL         string `yaml:"wsUrl"`
+	HistoryRPCURL string `yaml:"historyRpcUrl"`
+	MEVAddress    string `yaml:"mevAddress"`
+	// PrivateRPC overrides the endpoint the profile sends transactions to.
+	PrivateRPC string `yaml:"privateRpc"`
+	// Builders overrides the eth_sendBundle endpoints of the profile, and
+	// BundleSimulator its eth_callBundle endpoint.
+	Builders        []string `yaml:"builders"`
+	BundleSimulator string   `yaml:"bundleSimulator"`
+	// Dexes and BaseTokens replace the ones of the chain profile when set.
+	Dexes      []DexConfig       `yaml:"dexes"`
+	BaseTokens []BaseTokenConfig `yaml:"baseTokens"`
+}
+
+type DexConfig struct {
+	Name            string `yaml:"name"`
+	Factory         string `yaml:"factory"`
+	Protocol        string `yaml:"protocol"`
+	Fee             int64  `yaml:"fee"`
+	InitCodeHash    string `yaml:"initCodeHash"`
+	DeploymentBlock uint64 `yaml:"deploymentBlock"`
+}
+
+type BaseTokenConfig struct {
+	Address    string `yaml:"address"`
+	Symbol     string `yaml:"symbol"`
+	Decimals   int64  `yaml:"decimals"`
+	MinReserve int64  `yaml:"minReserve"`
+}
+
+type StrategyConfig struct {
+	// BribePercent is the share of the profit the fixed bribe strategy pays
+	// the builder.
+	BribePercent int64       `yaml:"bribePercent"`
+	Bribe        BribeConfig `yaml:"bribe"`
+	// GridSteps borrow amounts are quoted, GridStepPercent of the borrow
+	// pool's reserve apart.
+	GridSteps       int64 `yaml:"gridSteps"`
+	GridStepPercent int64 `yaml:"gridStepPercent"`
+	// OptimizerPrecision is the number of steps the borrow range is split in
+	// before the golden section search stops.
+	OptimizerPrecision        int64         `yaml:"optimizerPrecision"`
+	OptimizerMaxBorrowPercent int64         `yaml:"optimizerMaxBorrowPercent"`
+	MaxPathDepth              int           `yaml:"maxPathDepth"`
+	PathSearchBudget          time.Duration `yaml:"pathSearchBudget"`
+	// Quote tolerances are in millionths. Pools whose local quote is further
+	// from the on chain one are disabled when they are discovered.
+	CurveQuoteTolerance    int64 `yaml:"curveQuoteTolerance"`
+	BalancerQuoteTolerance int64 `yaml:"balancerQuoteTolerance"`
+}
+
+const (
+	BribeFixed    = "fixed"
+	BribeGas      = "gas"
+	BribeAdaptive = "adaptive"
+)
+
+// BribeConfig selects how the share of the profit paid to the builder is
+// chosen. fixed pays BribePercent, gas pays what reaches TipPerGas and
+// adaptive learns which share lands bundles for each kind of path.
+type BribeConfig struct {
+	Strategy string `yaml:"strategy"`
+	// MinPercent and MaxPercent bound the gas and adaptive strategies.
+	MinPercent int64 `yaml:"minPercent"`
+	MaxPercent int64 `yaml:"maxPercent"`
+	// TipPerGas is the builder payment in wei per unit of gas the gas
+	// strategy aims for.
+	TipPerGas int64 `yaml:"tipPerGas"`
+	// Step is the distance between the shares the adaptive strategy tries.
+	// It picks the lowest one that lands at least TargetLandRate of bundles.
+	Step           int64   `yaml:"step"`
+	TargetLandRate float64 `yaml:"targetLandRate"`
+	// Window is how many of the last bundles at a share the adaptive
+	// strategy learns from, for each kind of path.
+	Window int `yaml:"window"`
+	// ExploreEvery makes every so many bundles of a kind of path try the
+	// share below the chosen one.
+	ExploreEvery int `yaml:"exploreEvery"`
+}
+
+type BatchConfig struct {
+	Reserves  int    `yaml:"reserves"`
+	LogBlocks uint64 `yaml:"logBlocks"`
+}
+
+// Token policies decide which pools are kept when they are discovered.
+const (
+	TokenPolicyOff = "off"
+	// TokenPolicyAny keeps pools with at least one listed coin.
+	TokenPolicyAny = "any"
+	// TokenPolicyAll keeps pools whose coins are all listed.
+	TokenPolicyAll = "all"
+)
+
+type TokensConfig struct {
+	Policy string `yaml:"policy"`
+	// Lists are Uniswap token lists, given as a file path or an http(s) URL.
+	Lists []string `yaml:"lists"`
+	// Allowlists are files with one token address per line.
+	Allowlists    []string            `yaml:"allowlists"`
+	CoinMarketCap CoinMarketCapConfig `yaml:"coinMarketCap"`
+	// RefreshInterval is how long the merged list is read from the state dir
+	// before the sources are fetched again.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+	Safety          SafetyConfig  `yaml:"safety"`
+}
+
+// SafetyConfig controls the buy and sell simulation tokens go through before
+// they are traded.
+type SafetyConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxTaxBps is the highest transfer tax, in basis points, a token may
+	// take on a buy or a sell.
+	MaxTaxBps int64 `yaml:"maxTaxBps"`
+	// RetryInterval is how long tokens that could not be simulated wait
+	// before they are simulated again.
+	RetryInterval time.Duration `yaml:"retryInterval"`
+	// RecheckInterval is how long a token that passed stays trusted before
+	// it is simulated again, since a token can turn malicious later.
+	RecheckInterval time.Duration `yaml:"recheckInterval"`
+}
+
+// CoinMarketCapConfig enables the CoinMarketCap source when APIKey is set.
+type CoinMarketCapConfig struct {
+	APIKey string `yaml:"apiKey"`
+	Limit  int    `yaml:"limit"`
+}
+
+type DenylistConfig struct {
+	// Expiry is how long a pool or token stays denied before it is tried
+	// again.
+	Expiry time.Duration `yaml:"expiry"`
+	// MaxFailures failed trades deny a pool. Malicious pool reverts and
+	// failed token simulations deny at once.
+	MaxFailures int `yaml:"maxFailures"`
+	// Pools and Tokens are denied for good.
+	Pools  []string `yaml:"pools"`
+	Tokens []string `yaml:"tokens"`
+}
+
+const (
+	DatabaseSQLite   = "sqlite"
+	DatabasePostgres = "postgres"
+)
+
+// DatabaseConfig selects where the state is kept. Without a driver it is
+// kept in JSON files in the state dir.
+type DatabaseConfig struct {
+	Driver string `yaml:"driver"`
+	// DSN is a file path for sqlite, state.db in the state dir by default,
+	// and a connection string for postgres.
+	DSN string `yaml:"dsn"`
+}
+
+const (
+	IngestionPoll      = "poll"
+	IngestionSubscribe = "subscribe"
+)
+
+// IngestionConfig selects how the logs of a block are read. poll fetches
+// them over HTTP for every new head, subscribe takes them from a websocket
+// log subscription and only falls back to HTTP to fill gaps.
+type IngestionConfig struct {
+	Mode string `yaml:"mode"`
+	// Settle is how long to wait for more logs of a block once its head and
+	// the logs so far have arrived.
+	Settle time.Duration `yaml:"settle"`
+}
+
+// MempoolConfig enables the pending transaction pipeline, which decodes the
+// router swaps in the mempool of the websocket node and looks for backruns.
+type MempoolConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// PathSearchBudget bounds the path search of each pending transaction,
+	// which runs between blocks and has to keep up with the mempool.
+	PathSearchBudget time.Duration `yaml:"pathSearchBudget"`
+}
+
+// BundlesConfig configures the bundles sent to the builders of a chain.
+type BundlesConfig struct {
+	// SigningKey signs the bundle payloads. Builders rank searchers by it, so
+	// it should be kept, but it holds no funds and must not be the private
+	// key. A throwaway key is used when it is empty.
+	SigningKey string `yaml:"signingKey"`
+	// TargetBlocks is how many blocks after the latest one a bundle is sent
+	// for.
+	TargetBlocks int `yaml:"targetBlocks"`
+	// BackrunGasLimit is the gas limit of backruns, which can not be
+	// estimated before the transaction they follow is mined.
+	BackrunGasLimit uint64 `yaml:"backrunGasLimit"`
+	// MinNetProfit is the simulated profit in wei a bundle has to beat after
+	// gas and the bribe.
+	MinNetProfit int64 `yaml:"minNetProfit"`
+}
+
+// Fee strategies pick the priority fee of the arbitrage transactions.
+const (
+	// FeeFixed pays PriorityFee.
+	FeeFixed = "fixed"
+	// FeeSuggested pays the node's eth_maxPriorityFeePerGas, at least
+	// PriorityFee.
+	FeeSuggested = "suggested"
+)
+
+// FeesConfig prices the EIP-1559 fees of the arbitrage transactions. Fees are
+// in wei per gas.
+type FeesConfig struct {
+	Strategy    string `yaml:"strategy"`
+	PriorityFee int64  `yaml:"priorityFee"`
+	// HeadroomBlocks is how many blocks of the steepest base fee rise
+	// maxFeePerGas covers after the target block, so a transaction sent to
+	// the mempool stays valid while it waits. Bundles only cover the blocks
+	// they target.
+	HeadroomBlocks int `yaml:"headroomBlocks"`
+	// MaxFeePerGas caps maxFeePerGas. Trades are not sent when the predicted
+	// base fee and the priority fee are above it.
+	MaxFeePerGas int64 `yaml:"maxFeePerGas"`
+}
+
+type NotificationsConfig struct {
+	Telegram TelegramConfig `yaml:"telegram"`
+}
+
+type TelegramConfig struct {
+	BotToken string `yaml:"botToken"`
+	ChatID   string `yaml:"chatId"`
+}
+
+func Default() Config {
+	return Config{
+		StateDir:         "data",
+		SnapshotInterval: 10 * time.Minute,
+		ReorgDepth:       64,
+		Ingestion: IngestionConfig{
+			Mode:   IngestionPoll,
+			Settle: 50 * time.Millisecond,
+		},
+		Mempool: MempoolConfig{
+			PathSearchBudget: 100 * time.Millisecond,
+		},
+		Strategy: StrategyConfig{
+			BribePercent:              5,
+			GridSteps:                 20,
+			GridStepPercent:           1,
+			OptimizerPrecision:        100000,
+			OptimizerMaxBorrowPercent: 50,
+			MaxPathDepth:              3,
+			PathSearchBudget:          2 * time.Second,
+			CurveQuoteTolerance:       1,
+			BalancerQuoteTolerance:    1,
+			Bribe: BribeConfig{
+				Strategy:       BribeFixed,
+				MinPercent:     1,
+				MaxPercent:     90,
+				TipPerGas:      2000000000,
+				Step:           5,
+				TargetLandRate: 0.5,
+				Window:         20,
+				ExploreEvery:   10,
+			},
+		},
+		Bundles: BundlesConfig{
+			TargetBlocks:    1,
+			BackrunGasLimit: 600000,
+		},
+		Fees: FeesConfig{
+			Strategy:       FeeSuggested,
+			HeadroomBlocks: 3,
+			MaxFeePerGas:   500000000000,
+		},
+		Denylist: DenylistConfig{
+			Expiry:      7 * 24 * time.Hour,
+			MaxFailures: 3,
+		},
+		Batches: BatchConfig{
+			Reserves:  2000,
+			LogBlocks: 10000,
+		},
+		Tokens: TokensConfig{
+			Policy:          TokenPolicyOff,
+			CoinMarketCap:   CoinMarketCapConfig{Limit: 500},
+			RefreshInterval: 24 * time.Hour,
+			Safety: SafetyConfig{
+				Enabled:         true,
+				RetryInterval:   24 * time.Hour,
+				RecheckInterval: 6 * time.Hour,
+			},
+		},
+	}
+}
+
+// Load reads the config file on top of the defaults, applies the environment
+// overrides and validates the result. A missing file is not an error, so the
+// bot can still be configured from the environment alone.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	file, err := os.Open(path)
+	if err == nil {
+		defer file.Close()
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		err = decoder.Decode(&cfg)
+		// An empty file, or one with only comments, has no document.
+		if err != nil && !errors.Is(err, io.EOF) {
+			return cfg, fmt.Errorf("can not parse %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return cfg, err
+	}
+	err = cfg.applyEnv()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// applyEnv overrides the config with the environment variables the bot used
+// before it had a config file. The per chain variables take one comma
+// separated value per chain, and a single value is shared by all chains.
+func (c *Config) applyEnv() error {
+	setString(&c.PrivateKey, "PRIV_KEY")
+	setString(&c.StateDir, "STATE_DIR")
+	setString(&c.Notifications.Telegram.BotToken, "BOT_TOKEN")
+	setString(&c.Notifications.Telegram.ChatID, "CHAT_ID")
+	setString(&c.Tokens.Policy, "TOKEN_POLICY")
+	setString(&c.Tokens.CoinMarketCap.APIKey, "CMC_API_KEY")
+	setString(&c.Ingestion.Mode, "INGESTION_MODE")
+	setString(&c.Strategy.Bribe.Strategy, "BRIBE_STRATEGY")
+	setString(&c.Bundles.SigningKey, "BUNDLE_SIGNING_KEY")
+	setString(&c.Fees.Strategy, "FEE_STRATEGY")
+	setString(&c.Database.Driver, "DB_DRIVER")
+	setString(&c.Database.DSN, "DB_DSN")
+	if os.Getenv("DB_HOST") != "" {
+		if c.Database.Driver == "" {
+			c.Database.Driver = DatabasePostgres
+		}
+		// The DB_* variables describe a postgres server, so they leave the
+		// path of a sqlite database alone.
+		if c.Database.Driver == DatabasePostgres {
+			// A URL escapes the password, which a key value DSN would need
+			// quoted.
+			dsn := url.URL{
+				Scheme: "postgres",
+				User:   url.UserPassword(os.Getenv("DB_USER"), os.Getenv("DB_PASS")),
+				Host:   os.Getenv("DB_HOST"),
+				Path:   "/" + os.Getenv("DB_NAME"),
+			}
+			c.Database.DSN = dsn.String()
+		}
+	}
+	value, ok := os.LookupEnv("BRIBE_PERCENT")
+	if ok && value != "" {
+		bribePercent, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("BRIBE_PERCENT: %w", err)
+		}
+		c.Strategy.BribePercent = bribePercent
+	}
+	value, ok = os.LookupEnv("MEMPOOL_ENABLED")
+	if ok && value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("MEMPOOL_ENABLED: %w", err)
+		}
+		c.Mempool.Enabled = enabled
+	}
+	rpcURLs := splitEnv("RPC_URL")
+	for len(c.Chains) < len(rpcURLs) {
+		c.Chains = append(c.Chains, ChainConfig{})
+	}
+	for name, field := range map[string]func(*ChainConfig) *string{
+		"RPC_URL":         func(chain *ChainConfig) *string { return &chain.RPCURL },
+		"WS_URL":          func(chain *ChainConfig) *string { return &chain.WSURL },
+		"HISTORY_RPC_URL": func(chain *ChainConfig) *string { return &chain.HistoryRPCURL },
+		"MEV_ADDRESS":     func(chain *ChainConfig) *string { return &chain.MEVAddress },
+	} {
+		values := splitEnv(name)
+		for i := range c.Chains {
+			if len(values) == 1 {
+				*field(&c.Chains[i]) = values[0]
+			} else if i < len(values) {
+				*field(&c.Chains[i]) = values[i]
+			}
+		}
+	}
+	return nil
+}
+
+func setString(target *string, name string) {
+	value, ok := os.LookupEnv(name)
+	if ok && value != "" {
+		*target = value
+	}
+}
+
+func splitEnv(name string) []string {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+	values := []string{}
+	for _, part := range strings.Split(value, ",") {
+		values = append(values, strings.TrimSpace(part))
+	}
+	return values
+}
+
+func (c *Config) Validate() error {
+	problems := []error{}
+	if len(strings.TrimPrefix(c.PrivateKey, "0x")) != 64 {
+		problems = append(problems, errors.New("privateKey must be a 32 byte hex key"))
+	}
+	c.PrivateKey = strings.TrimPrefix(c.PrivateKey, "0x")
+	if c.StateDir == "" {
+		problems = append(problems, errors.New("stateDir is required"))
+	}
+	if c.SnapshotInterval < 0 {
+		problems = append(problems, errors.New("snapshotInterval can not be negative"))
+	}
+	if c.ReorgDepth < 1 {
+		problems = append(problems, errors.New("reorgDepth must be at least 1"))
+	}
+	if len(c.Chains) == 0 {
+		problems = append(problems, errors.New("at least one chain is required"))
+	}
+	for i := range c.Chains {
+		chain := &c.Chains[i]
+		if chain.HistoryRPCURL == "" {
+			chain.HistoryRPCURL = chain.RPCURL
+		}
+		problems = append(problems, chain.validate(i)...)
+	}
+	strategy := c.Strategy
+	if strategy.BribePercent < 0 || strategy.BribePercent > 100 {
+		problems = append(problems, errors.New("strategy.bribePercent must be between 0 and 100"))
+	}
+	problems = append(problems, strategy.Bribe.validate()...)
+	if strategy.GridSteps <= 0 || strategy.GridStepPercent <= 0 || strategy.GridSteps*strategy.GridStepPercent > 100 {
+		problems = append(problems, errors.New("strategy grid must have positive steps covering at most 100 percent"))
+	}
+	if strategy.OptimizerPrecision <= 0 {
+		problems = append(problems, errors.New("strategy.optimizerPrecision must be positive"))
+	}
+	if strategy.OptimizerMaxBorrowPercent <= 0 || strategy.OptimizerMaxBorrowPercent > 100 {
+		problems = append(problems, errors.New("strategy.optimizerMaxBorrowPercent must be between 1 and 100"))
+	}
+	if strategy.MaxPathDepth < 2 {
+		problems = append(problems, errors.New("strategy.maxPathDepth must be at least 2"))
+	}
+	if strategy.PathSearchBudget <= 0 {
+		problems = append(problems, errors.New("strategy.pathSearchBudget must be positive"))
+	}
+	if strategy.CurveQuoteTolerance < 0 || strategy.BalancerQuoteTolerance < 0 {
+		problems = append(problems, errors.New("strategy quote tolerances can not be negative"))
+	}
+	if c.Batches.Reserves <= 0 || c.Batches.LogBlocks == 0 {
+		problems = append(problems, errors.New("batch sizes must be positive"))
+	}
+	problems = append(problems, c.Tokens.validate()...)
+	problems = append(problems, c.Denylist.validate()...)
+	if c.Ingestion.Mode != IngestionPoll && c.Ingestion.Mode != IngestionSubscribe {
+		problems = append(problems, fmt.Errorf("ingestion.mode must be %s or %s", IngestionPoll, IngestionSubscribe))
+	}
+	if c.Ingestion.Settle <= 0 {
+		problems = append(problems, errors.New("ingestion.settle must be positive"))
+	}
+	if c.Mempool.PathSearchBudget <= 0 {
+		problems = append(problems, errors.New("mempool.pathSearchBudget must be positive"))
+	}
+	if c.Bundles.SigningKey != "" && len(strings.TrimPrefix(c.Bundles.SigningKey, "0x")) != 64 {
+		problems = append(problems, errors.New("bundles.signingKey must be a 32 byte hex key"))
+	}
+	c.Bundles.SigningKey = strings.TrimPrefix(c.Bundles.SigningKey, "0x")
+	if c.Bundles.SigningKey != "" && c.Bundles.SigningKey == c.PrivateKey {
+		problems = append(problems, errors.New("bundles.signingKey must not be the private key"))
+	}
+	if c.Bundles.TargetBlocks < 1 || c.Bundles.TargetBlocks > 2 {
+		problems = append(problems, errors.New("bundles.targetBlocks must be 1 or 2"))
+	}
+	if c.Bundles.MinNetProfit < 0 {
+		problems = append(problems, errors.New("bundles.minNetProfit can not be negative"))
+	}
+	if c.Bundles.BackrunGasLimit == 0 {
+		problems = append(problems, errors.New("bundles.backrunGasLimit must be positive"))
+	}
+	problems = append(problems, c.Fees.validate()...)
+	switch c.Database.Driver {
+	case "", DatabaseSQLite:
+	case DatabasePostgres:
+		if c.Database.DSN == "" {
+			problems = append(problems, errors.New("database.dsn is required for postgres"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("database.driver must be %s or %s", DatabaseSQLite, DatabasePostgres))
+	}
+	return errors.Join(problems...)
+}
+
+func (c ChainConfig) validate(i int) []error {
+	problems := []error{}
+	if c.RPCURL == "" {
+		problems = append(problems, fmt.Errorf("chains[%d].rpcUrl is required", i))
+	}
+	if c.WSURL == "" {
+		problems = append(problems, fmt.Errorf("chains[%d].wsUrl is required", i))
+	}
+	if c.MEVAddress != "" && !common.IsHexAddress(c.MEVAddress) {
+		problems = append(problems, fmt.Errorf("chains[%d].mevAddress is not an address", i))
+	}
+	if c.BundleSimulator != "" && !strings.HasPrefix(c.BundleSimulator, "http://") && !strings.HasPrefix(c.BundleSimulator, "https://") {
+		problems = append(problems, fmt.Errorf("chains[%d].bundleSimulator is not an http url", i))
+	}
+	for j, builder := range c.Builders {
+		if !strings.HasPrefix(builder, "http://") && !strings.HasPrefix(builder, "https://") {
+			problems = append(problems, fmt.Errorf("chains[%d].builders[%d] is not an http url", i, j))
+		}
+	}
+	for j, dex := range c.Dexes {
+		if dex.Name == "" {
+			problems = append(problems, fmt.Errorf("chains[%d].dexes[%d].name is required", i, j))
+		}
+		if !common.IsHexAddress(dex.Factory) {
+			problems = append(problems, fmt.Errorf("chains[%d].dexes[%d].factory is not an address", i, j))
+		}
+		if dex.Fee < 0 {
+			problems = append(problems, fmt.Errorf("chains[%d].dexes[%d].fee can not be negative", i, j))
+		}
+	}
+	for j, token := range c.BaseTokens {
+		if !common.IsHexAddress(token.Address) {
+			problems = append(problems, fmt.Errorf("chains[%d].baseTokens[%d].address is not an address", i, j))
+		}
+		if token.Decimals < 0 || token.MinReserve < 0 {
+			problems = append(problems, fmt.Errorf("chains[%d].baseTokens[%d] can not have negative values", i, j))
+		}
+	}
+	return problems
+}
+
+func (c BribeConfig) validate() []error {
+	problems := []error{}
+	switch c.Strategy {
+	case BribeFixed, BribeGas, BribeAdaptive:
+	default:
+		problems = append(problems, fmt.Errorf("strategy.bribe.strategy must be %s, %s or %s", BribeFixed, BribeGas, BribeAdaptive))
+	}
+	if c.MinPercent < 0 || c.MaxPercent > 100 || c.MinPercent > c.MaxPercent {
+		problems = append(problems, errors.New("strategy.bribe percents must be between 0 and 100 with minPercent at most maxPercent"))
+	}
+	if c.TipPerGas < 0 {
+		problems = append(problems, errors.New("strategy.bribe.tipPerGas can not be negative"))
+	}
+	if c.Step <= 0 {
+		problems = append(problems, errors.New("strategy.bribe.step must be positive"))
+	}
+	if c.TargetLandRate <= 0 || c.TargetLandRate > 1 {
+		problems = append(problems, errors.New("strategy.bribe.targetLandRate must be above 0 and at most 1"))
+	}
+	if c.Window <= 0 {
+		problems = append(problems, errors.New("strategy.bribe.window must be positive"))
+	}
+	if c.ExploreEvery <= 0 {
+		problems = append(problems, errors.New("strategy.bribe.exploreEvery must be positive"))
+	}
+	return problems
+}
+
+func (c TokensConfig) validate() []error {
+	problems := []error{}
+	if c.Safety.MaxTaxBps < 0 || c.Safety.MaxTaxBps > 10000 {
+		problems = append(problems, errors.New("tokens.safety.maxTaxBps must be between 0 and 10000"))
+	}
+	if c.Safety.RetryInterval <= 0 {
+		problems = append(problems, errors.New("tokens.safety.retryInterval must be positive"))
+	}
+	if c.Safety.RecheckInterval <= 0 {
+		problems = append(problems, errors.New("tokens.safety.recheckInterval must be positive"))
+	}
+	switch c.Policy {
+	case TokenPolicyOff:
+		return problems
+	case TokenPolicyAny, TokenPolicyAll:
+	default:
+		problems = append(problems, fmt.Errorf("tokens.policy must be one of %s, %s or %s", TokenPolicyOff, TokenPolicyAny, TokenPolicyAll))
+	}
+	if len(c.Lists) == 0 && len(c.Allowlists) == 0 && c.CoinMarketCap.APIKey == "" {
+		problems = append(problems, errors.New("tokens.policy needs at least one token source"))
+	}
+	if c.CoinMarketCap.APIKey != "" && c.CoinMarketCap.Limit <= 0 {
+		problems = append(problems, errors.New("tokens.coinMarketCap.limit must be positive"))
+	}
+	if c.RefreshInterval <= 0 {
+		problems = append(problems, errors.New("tokens.refreshInterval must be positive"))
+	}
+	return problems
+}
+
+func (c DenylistConfig) validate() []error {
+	problems := []error{}
+	if c.Expiry <= 0 {
+		problems = append(problems, errors.New("denylist.expiry must be positive"))
+	}
+	if c.MaxFailures <= 0 {
+		problems = append(problems, errors.New("denylist.maxFailures must be positive"))
+	}
+	for i, address := range c.Pools {
+		if !common.IsHexAddress(address) {
+			problems = append(problems, fmt.Errorf("denylist.pools[%d] is not an address", i))
+		}
+	}
+	for i, address := range c.Tokens {
+		if !common.IsHexAddress(address) {
+			problems = append(problems, fmt.Errorf("denylist.tokens[%d] is not an address", i))
+		}
+	}
+	return problems
+}
+
+func (c FeesConfig) validate() []error {
+	problems := []error{}
+	switch c.Strategy {
+	case FeeFixed, FeeSuggested:
+	default:
+		problems = append(problems, fmt.Errorf("fees.strategy must be %s or %s", FeeFixed, FeeSuggested))
+	}
+	if c.PriorityFee < 0 {
+		problems = append(problems, errors.New("fees.priorityFee can not be negative"))
+	}
+	if c.HeadroomBlocks < 0 {
+		problems = append(problems, errors.New("fees.headroomBlocks can not be negative"))
+	}
+	if c.MaxFeePerGas <= 0 {
+		problems = append(problems, errors.New("fees.maxFeePerGas must be positive"))
+	}
+	if c.PriorityFee > c.MaxFeePerGas {
+		problems = append(problems, errors.New("fees.priorityFee can not be above fees.maxFeePerGas"))
+	}
+	return problems
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKey = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// clearEnv empties the variables applyEnv reads, so the environment of the
+// test run does not leak into the config.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"PRIV_KEY", "STATE_DIR", "BOT_TOKEN", "CHAT_ID", "TOKEN_POLICY", "CMC_API_KEY", "INGESTION_MODE",
+		"BRIBE_STRATEGY", "BUNDLE_SIGNING_KEY", "FEE_STRATEGY", "DB_DRIVER", "DB_DSN", "DB_HOST", "DB_USER",
+		"DB_PASS", "DB_NAME", "BRIBE_PERCENT", "MEMPOOL_ENABLED", "RPC_URL", "WS_URL", "HISTORY_RPC_URL", "MEV_ADDRESS",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, `
+privateKey: `+testKey+`
+reorgDepth: 12
+chains:
+  - name: mainnet
+    rpcUrl: http://localhost:8545
+    wsUrl: ws://localhost:8546
+strategy:
+  bribePercent: 10
+  bribe:
+    strategy: adaptive
+ingestion:
+  settle: 20ms
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file overrides the defaults it sets and keeps the others.
+	if cfg.ReorgDepth != 12 || cfg.Strategy.BribePercent != 10 || cfg.Strategy.Bribe.Strategy != BribeAdaptive || cfg.Ingestion.Settle != 20*time.Millisecond {
+		t.Fatalf("loaded %+v", cfg)
+	}
+	if cfg.Strategy.Bribe.Window != Default().Strategy.Bribe.Window || cfg.Ingestion.Mode != IngestionPoll || cfg.StateDir != "data" {
+		t.Fatalf("lost the defaults: %+v", cfg)
+	}
+	// Validation trims the key and falls back to the rpc for history.
+	if cfg.PrivateKey != strings.TrimPrefix(testKey, "0x") || cfg.Chains[0].HistoryRPCURL != "http://localhost:8545" {
+		t.Fatalf("key %s, history rpc %s", cfg.PrivateKey, cfg.Chains[0].HistoryRPCURL)
+	}
+
+	// The environment wins over the file.
+	t.Setenv("BRIBE_PERCENT", "20")
+	t.Setenv("WS_URL", "ws://node:8546")
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Strategy.BribePercent != 20 || cfg.Chains[0].WSURL != "ws://node:8546" {
+		t.Fatalf("bribe percent %d, ws url %s", cfg.Strategy.BribePercent, cfg.Chains[0].WSURL)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	clearEnv(t)
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		errors  []string
+	}{
+		{"unknown field", "privateKey: " + testKey + "\nreorgDeph: 3\n", nil, []string{"field reorgDeph not found"}},
+		{"bad env value", "", map[string]string{"BRIBE_PERCENT": "ten"}, []string{"BRIBE_PERCENT"}},
+		{"nothing set", "", nil, []string{"privateKey must be a 32 byte hex key", "at least one chain is required"}},
+		{
+			"invalid values",
+			`
+privateKey: ` + testKey + `
+reorgDepth: 0
+chains:
+  - rpcUrl: http://localhost:8545
+    mevAddress: nope
+    builders: [ws://builder]
+strategy:
+  maxPathDepth: 1
+  bribe:
+    strategy: random
+tokens:
+  policy: all
+database:
+  driver: postgres
+bundles:
+  signingKey: ` + testKey + `
+fees:
+  priorityFee: 600000000000
+`,
+			nil,
+			[]string{
+				"reorgDepth must be at least 1",
+				"chains[0].wsUrl is required",
+				"chains[0].mevAddress is not an address",
+				"chains[0].builders[0] is not an http url",
+				"strategy.maxPathDepth must be at least 2",
+				"strategy.bribe.strategy must be fixed, gas or adaptive",
+				"tokens.policy needs at least one token source",
+				"database.dsn is required for postgres",
+				"bundles.signingKey must not be the private key",
+				"fees.priorityFee can not be above fees.maxFeePerGas",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			_, err := Load(writeConfig(t, test.content))
+			if err == nil {
+				t.Fatal("loaded an invalid config")
+			}
+			for _, want := range test.errors {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("%q does not say %q", err, want)
+				}
+			}
+			if len(test.errors) > 1 && strings.Count(err.Error(), "\n")+1 != len(test.errors) {
+				t.Errorf("got %d problems, want %d: %v", strings.Count(err.Error(), "\n")+1, len(test.errors), err)
+			}
+		})
+	}
+}
+
+func TestApplyEnvChains(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("PRIV_KEY", testKey)
+	// A single value is shared by all chains, a list gives one per chain and
+	// a shorter list leaves the rest alone.
+	t.Setenv("RPC_URL", "http://a:8545, http://b:8545,http://c:8545")
+	t.Setenv("WS_URL", "ws://shared:8546")
+	t.Setenv("HISTORY_RPC_URL", "http://archive-a:8545,http://archive-b:8545")
+	t.Setenv("MEV_ADDRESS", "0x00000000000000000000000000000000000000a1,0x00000000000000000000000000000000000000a2,0x00000000000000000000000000000000000000a3")
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ChainConfig{
+		{RPCURL: "http://a:8545", WSURL: "ws://shared:8546", HistoryRPCURL: "http://archive-a:8545", MEVAddress: "0x00000000000000000000000000000000000000a1"},
+		{RPCURL: "http://b:8545", WSURL: "ws://shared:8546", HistoryRPCURL: "http://archive-b:8545", MEVAddress: "0x00000000000000000000000000000000000000a2"},
+		{RPCURL: "http://c:8545", WSURL: "ws://shared:8546", HistoryRPCURL: "http://c:8545", MEVAddress: "0x00000000000000000000000000000000000000a3"},
+	}
+	if len(cfg.Chains) != len(want) {
+		t.Fatalf("got %d chains, want %d", len(cfg.Chains), len(want))
+	}
+	for i := range want {
+		chain := cfg.Chains[i]
+		if chain.RPCURL != want[i].RPCURL || chain.WSURL != want[i].WSURL || chain.HistoryRPCURL != want[i].HistoryRPCURL || chain.MEVAddress != want[i].MEVAddress {
+			t.Errorf("chain %d is %+v, want %+v", i, chain, want[i])
+		}
+	}
+}
+
+func TestApplyEnvChainsOfTheFile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, `
+privateKey: `+testKey+`
+chains:
+  - name: mainnet
+    rpcUrl: http://file-a:8545
+    wsUrl: ws://file-a:8546
+  - name: base
+    rpcUrl: http://file-b:8545
+    wsUrl: ws://file-b:8546
+`)
+	t.Setenv("RPC_URL", "http://env-a:8545")
+	t.Setenv("WS_URL", "ws://env-a:8546")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, chain := range cfg.Chains {
+		if chain.RPCURL != "http://env-a:8545" || chain.WSURL != "ws://env-a:8546" {
+			t.Errorf("chain %d is %+v", i, chain)
+		}
+	}
+	if cfg.Chains[1].Name != "base" {
+		t.Fatalf("chain 1 is %s", cfg.Chains[1].Name)
+	}
+}
+
+func TestApplyEnvDatabase(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		dsn    string
+		want   string
+	}{
+		{"no driver", "", "", DatabasePostgres},
+		{"postgres", DatabasePostgres, "", DatabasePostgres},
+		{"sqlite", DatabaseSQLite, "/var/lib/bot/state.db", DatabaseSQLite},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("DB_DRIVER", test.driver)
+			t.Setenv("DB_DSN", test.dsn)
+			t.Setenv("DB_HOST", "db.internal:5432")
+			t.Setenv("DB_USER", "bot")
+			t.Setenv("DB_PASS", "p@ss word/with:odd=chars")
+			t.Setenv("DB_NAME", "mev")
+			cfg := Default()
+			err := cfg.applyEnv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Database.Driver != test.want {
+				t.Fatalf("driver %s, want %s", cfg.Database.Driver, test.want)
+			}
+			if test.want == DatabaseSQLite {
+				if cfg.Database.DSN != test.dsn {
+					t.Fatalf("changed the sqlite path to %s", cfg.Database.DSN)
+				}
+				return
+			}
+			dsn, err := url.Parse(cfg.Database.DSN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			password, _ := dsn.User.Password()
+			if dsn.Scheme != "postgres" || dsn.Host != "db.internal:5432" || dsn.User.Username() != "bot" || password != "p@ss word/with:odd=chars" || dsn.Path != "/mev" {
+				t.Fatalf("dsn %s", cfg.Database.DSN)
+			}
+		})
+	}
+}
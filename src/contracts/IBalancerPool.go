@@ -0,0 +1,866 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IBalancerPoolMetaData contains all meta data concerning the IBalancerPool contract.
+var IBalancerPoolMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getAmplificationParameter\",\"inputs\":[],\"outputs\":[{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"isUpdating\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"precision\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getNormalizedWeights\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getPoolId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getSwapFeePercentage\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"AmpUpdateStarted\",\"inputs\":[{\"name\":\"startValue\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"endValue\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"startTime\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"endTime\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"AmpUpdateStopped\",\"inputs\":[{\"name\":\"currentValue\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"GradualWeightUpdateScheduled\",\"inputs\":[{\"name\":\"startTime\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"endTime\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"startWeights\",\"type\":\"uint256[]\",\"indexed\":false,\"internalType\":\"uint256[]\"},{\"name\":\"endWeights\",\"type\":\"uint256[]\",\"indexed\":false,\"internalType\":\"uint256[]\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SwapFeePercentageChanged\",\"inputs\":[{\"name\":\"swapFeePercentage\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false}]",
+}
+
+// IBalancerPoolABI is the input ABI used to generate the binding from.
+// Deprecated: Use IBalancerPoolMetaData.ABI instead.
+var IBalancerPoolABI = IBalancerPoolMetaData.ABI
+
+// IBalancerPool is an auto generated Go binding around an Ethereum contract.
+type IBalancerPool struct {
+	IBalancerPoolCaller     // Read-only binding to the contract
+	IBalancerPoolTransactor // Write-only binding to the contract
+	IBalancerPoolFilterer   // Log filterer for contract events
+}
+
+// IBalancerPoolCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IBalancerPoolCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerPoolTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IBalancerPoolTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerPoolFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IBalancerPoolFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerPoolSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IBalancerPoolSession struct {
+	Contract     *IBalancerPool    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IBalancerPoolCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IBalancerPoolCallerSession struct {
+	Contract *IBalancerPoolCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// IBalancerPoolTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IBalancerPoolTransactorSession struct {
+	Contract     *IBalancerPoolTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// IBalancerPoolRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IBalancerPoolRaw struct {
+	Contract *IBalancerPool // Generic contract binding to access the raw methods on
+}
+
+// IBalancerPoolCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IBalancerPoolCallerRaw struct {
+	Contract *IBalancerPoolCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IBalancerPoolTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IBalancerPoolTransactorRaw struct {
+	Contract *IBalancerPoolTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIBalancerPool creates a new instance of IBalancerPool, bound to a specific deployed contract.
+func NewIBalancerPool(address common.Address, backend bind.ContractBackend) (*IBalancerPool, error) {
+	contract, err := bindIBalancerPool(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPool{IBalancerPoolCaller: IBalancerPoolCaller{contract: contract}, IBalancerPoolTransactor: IBalancerPoolTransactor{contract: contract}, IBalancerPoolFilterer: IBalancerPoolFilterer{contract: contract}}, nil
+}
+
+// NewIBalancerPoolCaller creates a new read-only instance of IBalancerPool, bound to a specific deployed contract.
+func NewIBalancerPoolCaller(address common.Address, caller bind.ContractCaller) (*IBalancerPoolCaller, error) {
+	contract, err := bindIBalancerPool(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolCaller{contract: contract}, nil
+}
+
+// NewIBalancerPoolTransactor creates a new write-only instance of IBalancerPool, bound to a specific deployed contract.
+func NewIBalancerPoolTransactor(address common.Address, transactor bind.ContractTransactor) (*IBalancerPoolTransactor, error) {
+	contract, err := bindIBalancerPool(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolTransactor{contract: contract}, nil
+}
+
+// NewIBalancerPoolFilterer creates a new log filterer instance of IBalancerPool, bound to a specific deployed contract.
+func NewIBalancerPoolFilterer(address common.Address, filterer bind.ContractFilterer) (*IBalancerPoolFilterer, error) {
+	contract, err := bindIBalancerPool(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolFilterer{contract: contract}, nil
+}
+
+// bindIBalancerPool binds a generic wrapper to an already deployed contract.
+func bindIBalancerPool(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IBalancerPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IBalancerPool *IBalancerPoolRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IBalancerPool.Contract.IBalancerPoolCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IBalancerPool *IBalancerPoolRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IBalancerPool.Contract.IBalancerPoolTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IBalancerPool *IBalancerPoolRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IBalancerPool.Contract.IBalancerPoolTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IBalancerPool *IBalancerPoolCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IBalancerPool.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IBalancerPool *IBalancerPoolTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IBalancerPool.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IBalancerPool *IBalancerPoolTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IBalancerPool.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetAmplificationParameter is a free data retrieval call binding the contract method 0x6daccffa.
+//
+// Solidity: function getAmplificationParameter() view returns(uint256 value, bool isUpdating, uint256 precision)
+func (_IBalancerPool *IBalancerPoolCaller) GetAmplificationParameter(opts *bind.CallOpts) (struct {
+	Value      *big.Int
+	IsUpdating bool
+	Precision  *big.Int
+}, error) {
+	var out []interface{}
+	err := _IBalancerPool.contract.Call(opts, &out, "getAmplificationParameter")
+
+	outstruct := new(struct {
+		Value      *big.Int
+		IsUpdating bool
+		Precision  *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Value = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.IsUpdating = *abi.ConvertType(out[1], new(bool)).(*bool)
+	outstruct.Precision = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetAmplificationParameter is a free data retrieval call binding the contract method 0x6daccffa.
+//
+// Solidity: function getAmplificationParameter() view returns(uint256 value, bool isUpdating, uint256 precision)
+func (_IBalancerPool *IBalancerPoolSession) GetAmplificationParameter() (struct {
+	Value      *big.Int
+	IsUpdating bool
+	Precision  *big.Int
+}, error) {
+	return _IBalancerPool.Contract.GetAmplificationParameter(&_IBalancerPool.CallOpts)
+}
+
+// GetAmplificationParameter is a free data retrieval call binding the contract method 0x6daccffa.
+//
+// Solidity: function getAmplificationParameter() view returns(uint256 value, bool isUpdating, uint256 precision)
+func (_IBalancerPool *IBalancerPoolCallerSession) GetAmplificationParameter() (struct {
+	Value      *big.Int
+	IsUpdating bool
+	Precision  *big.Int
+}, error) {
+	return _IBalancerPool.Contract.GetAmplificationParameter(&_IBalancerPool.CallOpts)
+}
+
+// GetNormalizedWeights is a free data retrieval call binding the contract method 0xf89f27ed.
+//
+// Solidity: function getNormalizedWeights() view returns(uint256[])
+func (_IBalancerPool *IBalancerPoolCaller) GetNormalizedWeights(opts *bind.CallOpts) ([]*big.Int, error) {
+	var out []interface{}
+	err := _IBalancerPool.contract.Call(opts, &out, "getNormalizedWeights")
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetNormalizedWeights is a free data retrieval call binding the contract method 0xf89f27ed.
+//
+// Solidity: function getNormalizedWeights() view returns(uint256[])
+func (_IBalancerPool *IBalancerPoolSession) GetNormalizedWeights() ([]*big.Int, error) {
+	return _IBalancerPool.Contract.GetNormalizedWeights(&_IBalancerPool.CallOpts)
+}
+
+// GetNormalizedWeights is a free data retrieval call binding the contract method 0xf89f27ed.
+//
+// Solidity: function getNormalizedWeights() view returns(uint256[])
+func (_IBalancerPool *IBalancerPoolCallerSession) GetNormalizedWeights() ([]*big.Int, error) {
+	return _IBalancerPool.Contract.GetNormalizedWeights(&_IBalancerPool.CallOpts)
+}
+
+// GetPoolId is a free data retrieval call binding the contract method 0x38fff2d0.
+//
+// Solidity: function getPoolId() view returns(bytes32)
+func (_IBalancerPool *IBalancerPoolCaller) GetPoolId(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _IBalancerPool.contract.Call(opts, &out, "getPoolId")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetPoolId is a free data retrieval call binding the contract method 0x38fff2d0.
+//
+// Solidity: function getPoolId() view returns(bytes32)
+func (_IBalancerPool *IBalancerPoolSession) GetPoolId() ([32]byte, error) {
+	return _IBalancerPool.Contract.GetPoolId(&_IBalancerPool.CallOpts)
+}
+
+// GetPoolId is a free data retrieval call binding the contract method 0x38fff2d0.
+//
+// Solidity: function getPoolId() view returns(bytes32)
+func (_IBalancerPool *IBalancerPoolCallerSession) GetPoolId() ([32]byte, error) {
+	return _IBalancerPool.Contract.GetPoolId(&_IBalancerPool.CallOpts)
+}
+
+// GetSwapFeePercentage is a free data retrieval call binding the contract method 0x55c67628.
+//
+// Solidity: function getSwapFeePercentage() view returns(uint256)
+func (_IBalancerPool *IBalancerPoolCaller) GetSwapFeePercentage(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IBalancerPool.contract.Call(opts, &out, "getSwapFeePercentage")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetSwapFeePercentage is a free data retrieval call binding the contract method 0x55c67628.
+//
+// Solidity: function getSwapFeePercentage() view returns(uint256)
+func (_IBalancerPool *IBalancerPoolSession) GetSwapFeePercentage() (*big.Int, error) {
+	return _IBalancerPool.Contract.GetSwapFeePercentage(&_IBalancerPool.CallOpts)
+}
+
+// GetSwapFeePercentage is a free data retrieval call binding the contract method 0x55c67628.
+//
+// Solidity: function getSwapFeePercentage() view returns(uint256)
+func (_IBalancerPool *IBalancerPoolCallerSession) GetSwapFeePercentage() (*big.Int, error) {
+	return _IBalancerPool.Contract.GetSwapFeePercentage(&_IBalancerPool.CallOpts)
+}
+
+// IBalancerPoolAmpUpdateStartedIterator is returned from FilterAmpUpdateStarted and is used to iterate over the raw logs and unpacked data for AmpUpdateStarted events raised by the IBalancerPool contract.
+type IBalancerPoolAmpUpdateStartedIterator struct {
+	Event *IBalancerPoolAmpUpdateStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerPoolAmpUpdateStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerPoolAmpUpdateStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerPoolAmpUpdateStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerPoolAmpUpdateStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerPoolAmpUpdateStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerPoolAmpUpdateStarted represents a AmpUpdateStarted event raised by the IBalancerPool contract.
+type IBalancerPoolAmpUpdateStarted struct {
+	StartValue *big.Int
+	EndValue   *big.Int
+	StartTime  *big.Int
+	EndTime    *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterAmpUpdateStarted is a free log retrieval operation binding the contract event 0x1835882ee7a34ac194f717a35e09bb1d24c82a3b9d854ab6c9749525b714cdf2.
+//
+// Solidity: event AmpUpdateStarted(uint256 startValue, uint256 endValue, uint256 startTime, uint256 endTime)
+func (_IBalancerPool *IBalancerPoolFilterer) FilterAmpUpdateStarted(opts *bind.FilterOpts) (*IBalancerPoolAmpUpdateStartedIterator, error) {
+
+	logs, sub, err := _IBalancerPool.contract.FilterLogs(opts, "AmpUpdateStarted")
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolAmpUpdateStartedIterator{contract: _IBalancerPool.contract, event: "AmpUpdateStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchAmpUpdateStarted is a free log subscription operation binding the contract event 0x1835882ee7a34ac194f717a35e09bb1d24c82a3b9d854ab6c9749525b714cdf2.
+//
+// Solidity: event AmpUpdateStarted(uint256 startValue, uint256 endValue, uint256 startTime, uint256 endTime)
+func (_IBalancerPool *IBalancerPoolFilterer) WatchAmpUpdateStarted(opts *bind.WatchOpts, sink chan<- *IBalancerPoolAmpUpdateStarted) (event.Subscription, error) {
+
+	logs, sub, err := _IBalancerPool.contract.WatchLogs(opts, "AmpUpdateStarted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerPoolAmpUpdateStarted)
+				if err := _IBalancerPool.contract.UnpackLog(event, "AmpUpdateStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAmpUpdateStarted is a log parse operation binding the contract event 0x1835882ee7a34ac194f717a35e09bb1d24c82a3b9d854ab6c9749525b714cdf2.
+//
+// Solidity: event AmpUpdateStarted(uint256 startValue, uint256 endValue, uint256 startTime, uint256 endTime)
+func (_IBalancerPool *IBalancerPoolFilterer) ParseAmpUpdateStarted(log types.Log) (*IBalancerPoolAmpUpdateStarted, error) {
+	event := new(IBalancerPoolAmpUpdateStarted)
+	if err := _IBalancerPool.contract.UnpackLog(event, "AmpUpdateStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerPoolAmpUpdateStoppedIterator is returned from FilterAmpUpdateStopped and is used to iterate over the raw logs and unpacked data for AmpUpdateStopped events raised by the IBalancerPool contract.
+type IBalancerPoolAmpUpdateStoppedIterator struct {
+	Event *IBalancerPoolAmpUpdateStopped // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerPoolAmpUpdateStoppedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerPoolAmpUpdateStopped)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerPoolAmpUpdateStopped)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerPoolAmpUpdateStoppedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerPoolAmpUpdateStoppedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerPoolAmpUpdateStopped represents a AmpUpdateStopped event raised by the IBalancerPool contract.
+type IBalancerPoolAmpUpdateStopped struct {
+	CurrentValue *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterAmpUpdateStopped is a free log retrieval operation binding the contract event 0xa0d01593e47e69d07e0ccd87bece09411e07dd1ed40ca8f2e7af2976542a0233.
+//
+// Solidity: event AmpUpdateStopped(uint256 currentValue)
+func (_IBalancerPool *IBalancerPoolFilterer) FilterAmpUpdateStopped(opts *bind.FilterOpts) (*IBalancerPoolAmpUpdateStoppedIterator, error) {
+
+	logs, sub, err := _IBalancerPool.contract.FilterLogs(opts, "AmpUpdateStopped")
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolAmpUpdateStoppedIterator{contract: _IBalancerPool.contract, event: "AmpUpdateStopped", logs: logs, sub: sub}, nil
+}
+
+// WatchAmpUpdateStopped is a free log subscription operation binding the contract event 0xa0d01593e47e69d07e0ccd87bece09411e07dd1ed40ca8f2e7af2976542a0233.
+//
+// Solidity: event AmpUpdateStopped(uint256 currentValue)
+func (_IBalancerPool *IBalancerPoolFilterer) WatchAmpUpdateStopped(opts *bind.WatchOpts, sink chan<- *IBalancerPoolAmpUpdateStopped) (event.Subscription, error) {
+
+	logs, sub, err := _IBalancerPool.contract.WatchLogs(opts, "AmpUpdateStopped")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerPoolAmpUpdateStopped)
+				if err := _IBalancerPool.contract.UnpackLog(event, "AmpUpdateStopped", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAmpUpdateStopped is a log parse operation binding the contract event 0xa0d01593e47e69d07e0ccd87bece09411e07dd1ed40ca8f2e7af2976542a0233.
+//
+// Solidity: event AmpUpdateStopped(uint256 currentValue)
+func (_IBalancerPool *IBalancerPoolFilterer) ParseAmpUpdateStopped(log types.Log) (*IBalancerPoolAmpUpdateStopped, error) {
+	event := new(IBalancerPoolAmpUpdateStopped)
+	if err := _IBalancerPool.contract.UnpackLog(event, "AmpUpdateStopped", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerPoolGradualWeightUpdateScheduledIterator is returned from FilterGradualWeightUpdateScheduled and is used to iterate over the raw logs and unpacked data for GradualWeightUpdateScheduled events raised by the IBalancerPool contract.
+type IBalancerPoolGradualWeightUpdateScheduledIterator struct {
+	Event *IBalancerPoolGradualWeightUpdateScheduled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerPoolGradualWeightUpdateScheduledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerPoolGradualWeightUpdateScheduled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerPoolGradualWeightUpdateScheduled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerPoolGradualWeightUpdateScheduledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerPoolGradualWeightUpdateScheduledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerPoolGradualWeightUpdateScheduled represents a GradualWeightUpdateScheduled event raised by the IBalancerPool contract.
+type IBalancerPoolGradualWeightUpdateScheduled struct {
+	StartTime    *big.Int
+	EndTime      *big.Int
+	StartWeights []*big.Int
+	EndWeights   []*big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterGradualWeightUpdateScheduled is a free log retrieval operation binding the contract event 0x0f3631f9dab08169d1db21c6dc5f32536fb2b0a6b9bb5330d71c52132f968be0.
+//
+// Solidity: event GradualWeightUpdateScheduled(uint256 startTime, uint256 endTime, uint256[] startWeights, uint256[] endWeights)
+func (_IBalancerPool *IBalancerPoolFilterer) FilterGradualWeightUpdateScheduled(opts *bind.FilterOpts) (*IBalancerPoolGradualWeightUpdateScheduledIterator, error) {
+
+	logs, sub, err := _IBalancerPool.contract.FilterLogs(opts, "GradualWeightUpdateScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolGradualWeightUpdateScheduledIterator{contract: _IBalancerPool.contract, event: "GradualWeightUpdateScheduled", logs: logs, sub: sub}, nil
+}
+
+// WatchGradualWeightUpdateScheduled is a free log subscription operation binding the contract event 0x0f3631f9dab08169d1db21c6dc5f32536fb2b0a6b9bb5330d71c52132f968be0.
+//
+// Solidity: event GradualWeightUpdateScheduled(uint256 startTime, uint256 endTime, uint256[] startWeights, uint256[] endWeights)
+func (_IBalancerPool *IBalancerPoolFilterer) WatchGradualWeightUpdateScheduled(opts *bind.WatchOpts, sink chan<- *IBalancerPoolGradualWeightUpdateScheduled) (event.Subscription, error) {
+
+	logs, sub, err := _IBalancerPool.contract.WatchLogs(opts, "GradualWeightUpdateScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerPoolGradualWeightUpdateScheduled)
+				if err := _IBalancerPool.contract.UnpackLog(event, "GradualWeightUpdateScheduled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGradualWeightUpdateScheduled is a log parse operation binding the contract event 0x0f3631f9dab08169d1db21c6dc5f32536fb2b0a6b9bb5330d71c52132f968be0.
+//
+// Solidity: event GradualWeightUpdateScheduled(uint256 startTime, uint256 endTime, uint256[] startWeights, uint256[] endWeights)
+func (_IBalancerPool *IBalancerPoolFilterer) ParseGradualWeightUpdateScheduled(log types.Log) (*IBalancerPoolGradualWeightUpdateScheduled, error) {
+	event := new(IBalancerPoolGradualWeightUpdateScheduled)
+	if err := _IBalancerPool.contract.UnpackLog(event, "GradualWeightUpdateScheduled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerPoolSwapFeePercentageChangedIterator is returned from FilterSwapFeePercentageChanged and is used to iterate over the raw logs and unpacked data for SwapFeePercentageChanged events raised by the IBalancerPool contract.
+type IBalancerPoolSwapFeePercentageChangedIterator struct {
+	Event *IBalancerPoolSwapFeePercentageChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerPoolSwapFeePercentageChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerPoolSwapFeePercentageChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerPoolSwapFeePercentageChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerPoolSwapFeePercentageChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerPoolSwapFeePercentageChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerPoolSwapFeePercentageChanged represents a SwapFeePercentageChanged event raised by the IBalancerPool contract.
+type IBalancerPoolSwapFeePercentageChanged struct {
+	SwapFeePercentage *big.Int
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterSwapFeePercentageChanged is a free log retrieval operation binding the contract event 0xa9ba3ffe0b6c366b81232caab38605a0699ad5398d6cce76f91ee809e322dafc.
+//
+// Solidity: event SwapFeePercentageChanged(uint256 swapFeePercentage)
+func (_IBalancerPool *IBalancerPoolFilterer) FilterSwapFeePercentageChanged(opts *bind.FilterOpts) (*IBalancerPoolSwapFeePercentageChangedIterator, error) {
+
+	logs, sub, err := _IBalancerPool.contract.FilterLogs(opts, "SwapFeePercentageChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerPoolSwapFeePercentageChangedIterator{contract: _IBalancerPool.contract, event: "SwapFeePercentageChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchSwapFeePercentageChanged is a free log subscription operation binding the contract event 0xa9ba3ffe0b6c366b81232caab38605a0699ad5398d6cce76f91ee809e322dafc.
+//
+// Solidity: event SwapFeePercentageChanged(uint256 swapFeePercentage)
+func (_IBalancerPool *IBalancerPoolFilterer) WatchSwapFeePercentageChanged(opts *bind.WatchOpts, sink chan<- *IBalancerPoolSwapFeePercentageChanged) (event.Subscription, error) {
+
+	logs, sub, err := _IBalancerPool.contract.WatchLogs(opts, "SwapFeePercentageChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerPoolSwapFeePercentageChanged)
+				if err := _IBalancerPool.contract.UnpackLog(event, "SwapFeePercentageChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSwapFeePercentageChanged is a log parse operation binding the contract event 0xa9ba3ffe0b6c366b81232caab38605a0699ad5398d6cce76f91ee809e322dafc.
+//
+// Solidity: event SwapFeePercentageChanged(uint256 swapFeePercentage)
+func (_IBalancerPool *IBalancerPoolFilterer) ParseSwapFeePercentageChanged(log types.Log) (*IBalancerPoolSwapFeePercentageChanged, error) {
+	event := new(IBalancerPoolSwapFeePercentageChanged)
+	if err := _IBalancerPool.contract.UnpackLog(event, "SwapFeePercentageChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
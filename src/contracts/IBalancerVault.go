@@ -0,0 +1,1230 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IVaultBatchSwapStep is an auto generated low-level Go binding around an user-defined struct.
+type IVaultBatchSwapStep struct {
+	PoolId        [32]byte
+	AssetInIndex  *big.Int
+	AssetOutIndex *big.Int
+	Amount        *big.Int
+	UserData      []byte
+}
+
+// IVaultFundManagement is an auto generated low-level Go binding around an user-defined struct.
+type IVaultFundManagement struct {
+	Sender              common.Address
+	FromInternalBalance bool
+	Recipient           common.Address
+	ToInternalBalance   bool
+}
+
+// IBalancerVaultMetaData contains all meta data concerning the IBalancerVault contract.
+var IBalancerVaultMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getPool\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"enumIVault.PoolSpecialization\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getPoolTokens\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"tokens\",\"type\":\"address[]\",\"internalType\":\"contractIERC20[]\"},{\"name\":\"balances\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"},{\"name\":\"lastChangeBlock\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"queryBatchSwap\",\"inputs\":[{\"name\":\"kind\",\"type\":\"uint8\",\"internalType\":\"enumIVault.SwapKind\"},{\"name\":\"swaps\",\"type\":\"tuple[]\",\"components\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"assetInIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"assetOutIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"userData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"internalType\":\"structIVault.BatchSwapStep[]\"},{\"name\":\"assets\",\"type\":\"address[]\",\"internalType\":\"contractIAsset[]\"},{\"name\":\"funds\",\"type\":\"tuple\",\"components\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"fromInternalBalance\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"recipient\",\"type\":\"address\",\"internalType\":\"addresspayable\"},{\"name\":\"toInternalBalance\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"internalType\":\"structIVault.FundManagement\"}],\"outputs\":[{\"name\":\"\",\"type\":\"int256[]\",\"internalType\":\"int256[]\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"PoolBalanceChanged\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"liquidityProvider\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"tokens\",\"type\":\"address[]\",\"indexed\":false,\"internalType\":\"contractIERC20[]\"},{\"name\":\"deltas\",\"type\":\"int256[]\",\"indexed\":false,\"internalType\":\"int256[]\"},{\"name\":\"protocolFeeAmounts\",\"type\":\"uint256[]\",\"indexed\":false,\"internalType\":\"uint256[]\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"PoolBalanceManaged\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"assetManager\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"token\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"contractIERC20\"},{\"name\":\"cashDelta\",\"type\":\"int256\",\"indexed\":false,\"internalType\":\"int256\"},{\"name\":\"managedDelta\",\"type\":\"int256\",\"indexed\":false,\"internalType\":\"int256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"PoolRegistered\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"poolAddress\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"specialization\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"enumIVault.PoolSpecialization\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Swap\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"tokenIn\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"contractIERC20\"},{\"name\":\"tokenOut\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"contractIERC20\"},{\"name\":\"amountIn\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"amountOut\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensDeregistered\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"tokens\",\"type\":\"address[]\",\"indexed\":false,\"internalType\":\"contractIERC20[]\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"TokensRegistered\",\"inputs\":[{\"name\":\"poolId\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"tokens\",\"type\":\"address[]\",\"indexed\":false,\"internalType\":\"contractIERC20[]\"},{\"name\":\"assetManagers\",\"type\":\"address[]\",\"indexed\":false,\"internalType\":\"address[]\"}],\"anonymous\":false}]",
+}
+
+// IBalancerVaultABI is the input ABI used to generate the binding from.
+// Deprecated: Use IBalancerVaultMetaData.ABI instead.
+var IBalancerVaultABI = IBalancerVaultMetaData.ABI
+
+// IBalancerVault is an auto generated Go binding around an Ethereum contract.
+type IBalancerVault struct {
+	IBalancerVaultCaller     // Read-only binding to the contract
+	IBalancerVaultTransactor // Write-only binding to the contract
+	IBalancerVaultFilterer   // Log filterer for contract events
+}
+
+// IBalancerVaultCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IBalancerVaultCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerVaultTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IBalancerVaultTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerVaultFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IBalancerVaultFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IBalancerVaultSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IBalancerVaultSession struct {
+	Contract     *IBalancerVault   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IBalancerVaultCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IBalancerVaultCallerSession struct {
+	Contract *IBalancerVaultCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// IBalancerVaultTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IBalancerVaultTransactorSession struct {
+	Contract     *IBalancerVaultTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// IBalancerVaultRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IBalancerVaultRaw struct {
+	Contract *IBalancerVault // Generic contract binding to access the raw methods on
+}
+
+// IBalancerVaultCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IBalancerVaultCallerRaw struct {
+	Contract *IBalancerVaultCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IBalancerVaultTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IBalancerVaultTransactorRaw struct {
+	Contract *IBalancerVaultTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIBalancerVault creates a new instance of IBalancerVault, bound to a specific deployed contract.
+func NewIBalancerVault(address common.Address, backend bind.ContractBackend) (*IBalancerVault, error) {
+	contract, err := bindIBalancerVault(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVault{IBalancerVaultCaller: IBalancerVaultCaller{contract: contract}, IBalancerVaultTransactor: IBalancerVaultTransactor{contract: contract}, IBalancerVaultFilterer: IBalancerVaultFilterer{contract: contract}}, nil
+}
+
+// NewIBalancerVaultCaller creates a new read-only instance of IBalancerVault, bound to a specific deployed contract.
+func NewIBalancerVaultCaller(address common.Address, caller bind.ContractCaller) (*IBalancerVaultCaller, error) {
+	contract, err := bindIBalancerVault(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultCaller{contract: contract}, nil
+}
+
+// NewIBalancerVaultTransactor creates a new write-only instance of IBalancerVault, bound to a specific deployed contract.
+func NewIBalancerVaultTransactor(address common.Address, transactor bind.ContractTransactor) (*IBalancerVaultTransactor, error) {
+	contract, err := bindIBalancerVault(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultTransactor{contract: contract}, nil
+}
+
+// NewIBalancerVaultFilterer creates a new log filterer instance of IBalancerVault, bound to a specific deployed contract.
+func NewIBalancerVaultFilterer(address common.Address, filterer bind.ContractFilterer) (*IBalancerVaultFilterer, error) {
+	contract, err := bindIBalancerVault(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultFilterer{contract: contract}, nil
+}
+
+// bindIBalancerVault binds a generic wrapper to an already deployed contract.
+func bindIBalancerVault(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IBalancerVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IBalancerVault *IBalancerVaultRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IBalancerVault.Contract.IBalancerVaultCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IBalancerVault *IBalancerVaultRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.IBalancerVaultTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IBalancerVault *IBalancerVaultRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.IBalancerVaultTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IBalancerVault *IBalancerVaultCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IBalancerVault.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IBalancerVault *IBalancerVaultTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IBalancerVault *IBalancerVaultTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetPool is a free data retrieval call binding the contract method 0xf6c00927.
+//
+// Solidity: function getPool(bytes32 poolId) view returns(address, uint8)
+func (_IBalancerVault *IBalancerVaultCaller) GetPool(opts *bind.CallOpts, poolId [32]byte) (common.Address, uint8, error) {
+	var out []interface{}
+	err := _IBalancerVault.contract.Call(opts, &out, "getPool", poolId)
+
+	if err != nil {
+		return *new(common.Address), *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	out1 := *abi.ConvertType(out[1], new(uint8)).(*uint8)
+
+	return out0, out1, err
+
+}
+
+// GetPool is a free data retrieval call binding the contract method 0xf6c00927.
+//
+// Solidity: function getPool(bytes32 poolId) view returns(address, uint8)
+func (_IBalancerVault *IBalancerVaultSession) GetPool(poolId [32]byte) (common.Address, uint8, error) {
+	return _IBalancerVault.Contract.GetPool(&_IBalancerVault.CallOpts, poolId)
+}
+
+// GetPool is a free data retrieval call binding the contract method 0xf6c00927.
+//
+// Solidity: function getPool(bytes32 poolId) view returns(address, uint8)
+func (_IBalancerVault *IBalancerVaultCallerSession) GetPool(poolId [32]byte) (common.Address, uint8, error) {
+	return _IBalancerVault.Contract.GetPool(&_IBalancerVault.CallOpts, poolId)
+}
+
+// GetPoolTokens is a free data retrieval call binding the contract method 0xf94d4668.
+//
+// Solidity: function getPoolTokens(bytes32 poolId) view returns(address[] tokens, uint256[] balances, uint256 lastChangeBlock)
+func (_IBalancerVault *IBalancerVaultCaller) GetPoolTokens(opts *bind.CallOpts, poolId [32]byte) (struct {
+	Tokens          []common.Address
+	Balances        []*big.Int
+	LastChangeBlock *big.Int
+}, error) {
+	var out []interface{}
+	err := _IBalancerVault.contract.Call(opts, &out, "getPoolTokens", poolId)
+
+	outstruct := new(struct {
+		Tokens          []common.Address
+		Balances        []*big.Int
+		LastChangeBlock *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Tokens = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	outstruct.Balances = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+	outstruct.LastChangeBlock = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetPoolTokens is a free data retrieval call binding the contract method 0xf94d4668.
+//
+// Solidity: function getPoolTokens(bytes32 poolId) view returns(address[] tokens, uint256[] balances, uint256 lastChangeBlock)
+func (_IBalancerVault *IBalancerVaultSession) GetPoolTokens(poolId [32]byte) (struct {
+	Tokens          []common.Address
+	Balances        []*big.Int
+	LastChangeBlock *big.Int
+}, error) {
+	return _IBalancerVault.Contract.GetPoolTokens(&_IBalancerVault.CallOpts, poolId)
+}
+
+// GetPoolTokens is a free data retrieval call binding the contract method 0xf94d4668.
+//
+// Solidity: function getPoolTokens(bytes32 poolId) view returns(address[] tokens, uint256[] balances, uint256 lastChangeBlock)
+func (_IBalancerVault *IBalancerVaultCallerSession) GetPoolTokens(poolId [32]byte) (struct {
+	Tokens          []common.Address
+	Balances        []*big.Int
+	LastChangeBlock *big.Int
+}, error) {
+	return _IBalancerVault.Contract.GetPoolTokens(&_IBalancerVault.CallOpts, poolId)
+}
+
+// QueryBatchSwap is a paid mutator transaction binding the contract method 0xf84d066e.
+//
+// Solidity: function queryBatchSwap(uint8 kind, (bytes32,uint256,uint256,uint256,bytes)[] swaps, address[] assets, (address,bool,address,bool) funds) returns(int256[])
+func (_IBalancerVault *IBalancerVaultTransactor) QueryBatchSwap(opts *bind.TransactOpts, kind uint8, swaps []IVaultBatchSwapStep, assets []common.Address, funds IVaultFundManagement) (*types.Transaction, error) {
+	return _IBalancerVault.contract.Transact(opts, "queryBatchSwap", kind, swaps, assets, funds)
+}
+
+// QueryBatchSwap is a paid mutator transaction binding the contract method 0xf84d066e.
+//
+// Solidity: function queryBatchSwap(uint8 kind, (bytes32,uint256,uint256,uint256,bytes)[] swaps, address[] assets, (address,bool,address,bool) funds) returns(int256[])
+func (_IBalancerVault *IBalancerVaultSession) QueryBatchSwap(kind uint8, swaps []IVaultBatchSwapStep, assets []common.Address, funds IVaultFundManagement) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.QueryBatchSwap(&_IBalancerVault.TransactOpts, kind, swaps, assets, funds)
+}
+
+// QueryBatchSwap is a paid mutator transaction binding the contract method 0xf84d066e.
+//
+// Solidity: function queryBatchSwap(uint8 kind, (bytes32,uint256,uint256,uint256,bytes)[] swaps, address[] assets, (address,bool,address,bool) funds) returns(int256[])
+func (_IBalancerVault *IBalancerVaultTransactorSession) QueryBatchSwap(kind uint8, swaps []IVaultBatchSwapStep, assets []common.Address, funds IVaultFundManagement) (*types.Transaction, error) {
+	return _IBalancerVault.Contract.QueryBatchSwap(&_IBalancerVault.TransactOpts, kind, swaps, assets, funds)
+}
+
+// IBalancerVaultPoolBalanceChangedIterator is returned from FilterPoolBalanceChanged and is used to iterate over the raw logs and unpacked data for PoolBalanceChanged events raised by the IBalancerVault contract.
+type IBalancerVaultPoolBalanceChangedIterator struct {
+	Event *IBalancerVaultPoolBalanceChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultPoolBalanceChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultPoolBalanceChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultPoolBalanceChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultPoolBalanceChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultPoolBalanceChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultPoolBalanceChanged represents a PoolBalanceChanged event raised by the IBalancerVault contract.
+type IBalancerVaultPoolBalanceChanged struct {
+	PoolId             [32]byte
+	LiquidityProvider  common.Address
+	Tokens             []common.Address
+	Deltas             []*big.Int
+	ProtocolFeeAmounts []*big.Int
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterPoolBalanceChanged is a free log retrieval operation binding the contract event 0xe5ce249087ce04f05a957192435400fd97868dba0e6a4b4c049abf8af80dae78.
+//
+// Solidity: event PoolBalanceChanged(bytes32 indexed poolId, address indexed liquidityProvider, address[] tokens, int256[] deltas, uint256[] protocolFeeAmounts)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterPoolBalanceChanged(opts *bind.FilterOpts, poolId [][32]byte, liquidityProvider []common.Address) (*IBalancerVaultPoolBalanceChangedIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var liquidityProviderRule []interface{}
+	for _, liquidityProviderItem := range liquidityProvider {
+		liquidityProviderRule = append(liquidityProviderRule, liquidityProviderItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "PoolBalanceChanged", poolIdRule, liquidityProviderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultPoolBalanceChangedIterator{contract: _IBalancerVault.contract, event: "PoolBalanceChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchPoolBalanceChanged is a free log subscription operation binding the contract event 0xe5ce249087ce04f05a957192435400fd97868dba0e6a4b4c049abf8af80dae78.
+//
+// Solidity: event PoolBalanceChanged(bytes32 indexed poolId, address indexed liquidityProvider, address[] tokens, int256[] deltas, uint256[] protocolFeeAmounts)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchPoolBalanceChanged(opts *bind.WatchOpts, sink chan<- *IBalancerVaultPoolBalanceChanged, poolId [][32]byte, liquidityProvider []common.Address) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var liquidityProviderRule []interface{}
+	for _, liquidityProviderItem := range liquidityProvider {
+		liquidityProviderRule = append(liquidityProviderRule, liquidityProviderItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "PoolBalanceChanged", poolIdRule, liquidityProviderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultPoolBalanceChanged)
+				if err := _IBalancerVault.contract.UnpackLog(event, "PoolBalanceChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePoolBalanceChanged is a log parse operation binding the contract event 0xe5ce249087ce04f05a957192435400fd97868dba0e6a4b4c049abf8af80dae78.
+//
+// Solidity: event PoolBalanceChanged(bytes32 indexed poolId, address indexed liquidityProvider, address[] tokens, int256[] deltas, uint256[] protocolFeeAmounts)
+func (_IBalancerVault *IBalancerVaultFilterer) ParsePoolBalanceChanged(log types.Log) (*IBalancerVaultPoolBalanceChanged, error) {
+	event := new(IBalancerVaultPoolBalanceChanged)
+	if err := _IBalancerVault.contract.UnpackLog(event, "PoolBalanceChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerVaultPoolBalanceManagedIterator is returned from FilterPoolBalanceManaged and is used to iterate over the raw logs and unpacked data for PoolBalanceManaged events raised by the IBalancerVault contract.
+type IBalancerVaultPoolBalanceManagedIterator struct {
+	Event *IBalancerVaultPoolBalanceManaged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultPoolBalanceManagedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultPoolBalanceManaged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultPoolBalanceManaged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultPoolBalanceManagedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultPoolBalanceManagedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultPoolBalanceManaged represents a PoolBalanceManaged event raised by the IBalancerVault contract.
+type IBalancerVaultPoolBalanceManaged struct {
+	PoolId       [32]byte
+	AssetManager common.Address
+	Token        common.Address
+	CashDelta    *big.Int
+	ManagedDelta *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterPoolBalanceManaged is a free log retrieval operation binding the contract event 0x6edcaf6241105b4c94c2efdbf3a6b12458eb3d07be3a0e81d24b13c44045fe7a.
+//
+// Solidity: event PoolBalanceManaged(bytes32 indexed poolId, address indexed assetManager, address indexed token, int256 cashDelta, int256 managedDelta)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterPoolBalanceManaged(opts *bind.FilterOpts, poolId [][32]byte, assetManager []common.Address, token []common.Address) (*IBalancerVaultPoolBalanceManagedIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var assetManagerRule []interface{}
+	for _, assetManagerItem := range assetManager {
+		assetManagerRule = append(assetManagerRule, assetManagerItem)
+	}
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "PoolBalanceManaged", poolIdRule, assetManagerRule, tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultPoolBalanceManagedIterator{contract: _IBalancerVault.contract, event: "PoolBalanceManaged", logs: logs, sub: sub}, nil
+}
+
+// WatchPoolBalanceManaged is a free log subscription operation binding the contract event 0x6edcaf6241105b4c94c2efdbf3a6b12458eb3d07be3a0e81d24b13c44045fe7a.
+//
+// Solidity: event PoolBalanceManaged(bytes32 indexed poolId, address indexed assetManager, address indexed token, int256 cashDelta, int256 managedDelta)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchPoolBalanceManaged(opts *bind.WatchOpts, sink chan<- *IBalancerVaultPoolBalanceManaged, poolId [][32]byte, assetManager []common.Address, token []common.Address) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var assetManagerRule []interface{}
+	for _, assetManagerItem := range assetManager {
+		assetManagerRule = append(assetManagerRule, assetManagerItem)
+	}
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "PoolBalanceManaged", poolIdRule, assetManagerRule, tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultPoolBalanceManaged)
+				if err := _IBalancerVault.contract.UnpackLog(event, "PoolBalanceManaged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePoolBalanceManaged is a log parse operation binding the contract event 0x6edcaf6241105b4c94c2efdbf3a6b12458eb3d07be3a0e81d24b13c44045fe7a.
+//
+// Solidity: event PoolBalanceManaged(bytes32 indexed poolId, address indexed assetManager, address indexed token, int256 cashDelta, int256 managedDelta)
+func (_IBalancerVault *IBalancerVaultFilterer) ParsePoolBalanceManaged(log types.Log) (*IBalancerVaultPoolBalanceManaged, error) {
+	event := new(IBalancerVaultPoolBalanceManaged)
+	if err := _IBalancerVault.contract.UnpackLog(event, "PoolBalanceManaged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerVaultPoolRegisteredIterator is returned from FilterPoolRegistered and is used to iterate over the raw logs and unpacked data for PoolRegistered events raised by the IBalancerVault contract.
+type IBalancerVaultPoolRegisteredIterator struct {
+	Event *IBalancerVaultPoolRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultPoolRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultPoolRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultPoolRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultPoolRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultPoolRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultPoolRegistered represents a PoolRegistered event raised by the IBalancerVault contract.
+type IBalancerVaultPoolRegistered struct {
+	PoolId         [32]byte
+	PoolAddress    common.Address
+	Specialization uint8
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterPoolRegistered is a free log retrieval operation binding the contract event 0x3c13bc30b8e878c53fd2a36b679409c073afd75950be43d8858768e956fbc20e.
+//
+// Solidity: event PoolRegistered(bytes32 indexed poolId, address indexed poolAddress, uint8 specialization)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterPoolRegistered(opts *bind.FilterOpts, poolId [][32]byte, poolAddress []common.Address) (*IBalancerVaultPoolRegisteredIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var poolAddressRule []interface{}
+	for _, poolAddressItem := range poolAddress {
+		poolAddressRule = append(poolAddressRule, poolAddressItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "PoolRegistered", poolIdRule, poolAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultPoolRegisteredIterator{contract: _IBalancerVault.contract, event: "PoolRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchPoolRegistered is a free log subscription operation binding the contract event 0x3c13bc30b8e878c53fd2a36b679409c073afd75950be43d8858768e956fbc20e.
+//
+// Solidity: event PoolRegistered(bytes32 indexed poolId, address indexed poolAddress, uint8 specialization)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchPoolRegistered(opts *bind.WatchOpts, sink chan<- *IBalancerVaultPoolRegistered, poolId [][32]byte, poolAddress []common.Address) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var poolAddressRule []interface{}
+	for _, poolAddressItem := range poolAddress {
+		poolAddressRule = append(poolAddressRule, poolAddressItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "PoolRegistered", poolIdRule, poolAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultPoolRegistered)
+				if err := _IBalancerVault.contract.UnpackLog(event, "PoolRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePoolRegistered is a log parse operation binding the contract event 0x3c13bc30b8e878c53fd2a36b679409c073afd75950be43d8858768e956fbc20e.
+//
+// Solidity: event PoolRegistered(bytes32 indexed poolId, address indexed poolAddress, uint8 specialization)
+func (_IBalancerVault *IBalancerVaultFilterer) ParsePoolRegistered(log types.Log) (*IBalancerVaultPoolRegistered, error) {
+	event := new(IBalancerVaultPoolRegistered)
+	if err := _IBalancerVault.contract.UnpackLog(event, "PoolRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerVaultSwapIterator is returned from FilterSwap and is used to iterate over the raw logs and unpacked data for Swap events raised by the IBalancerVault contract.
+type IBalancerVaultSwapIterator struct {
+	Event *IBalancerVaultSwap // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultSwapIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultSwap)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultSwap)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultSwapIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultSwapIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultSwap represents a Swap event raised by the IBalancerVault contract.
+type IBalancerVaultSwap struct {
+	PoolId    [32]byte
+	TokenIn   common.Address
+	TokenOut  common.Address
+	AmountIn  *big.Int
+	AmountOut *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterSwap is a free log retrieval operation binding the contract event 0x2170c741c41531aec20e7c107c24eecfdd15e69c9bb0a8dd37b1840b9e0b207b.
+//
+// Solidity: event Swap(bytes32 indexed poolId, address indexed tokenIn, address indexed tokenOut, uint256 amountIn, uint256 amountOut)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterSwap(opts *bind.FilterOpts, poolId [][32]byte, tokenIn []common.Address, tokenOut []common.Address) (*IBalancerVaultSwapIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var tokenInRule []interface{}
+	for _, tokenInItem := range tokenIn {
+		tokenInRule = append(tokenInRule, tokenInItem)
+	}
+	var tokenOutRule []interface{}
+	for _, tokenOutItem := range tokenOut {
+		tokenOutRule = append(tokenOutRule, tokenOutItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "Swap", poolIdRule, tokenInRule, tokenOutRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultSwapIterator{contract: _IBalancerVault.contract, event: "Swap", logs: logs, sub: sub}, nil
+}
+
+// WatchSwap is a free log subscription operation binding the contract event 0x2170c741c41531aec20e7c107c24eecfdd15e69c9bb0a8dd37b1840b9e0b207b.
+//
+// Solidity: event Swap(bytes32 indexed poolId, address indexed tokenIn, address indexed tokenOut, uint256 amountIn, uint256 amountOut)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchSwap(opts *bind.WatchOpts, sink chan<- *IBalancerVaultSwap, poolId [][32]byte, tokenIn []common.Address, tokenOut []common.Address) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+	var tokenInRule []interface{}
+	for _, tokenInItem := range tokenIn {
+		tokenInRule = append(tokenInRule, tokenInItem)
+	}
+	var tokenOutRule []interface{}
+	for _, tokenOutItem := range tokenOut {
+		tokenOutRule = append(tokenOutRule, tokenOutItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "Swap", poolIdRule, tokenInRule, tokenOutRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultSwap)
+				if err := _IBalancerVault.contract.UnpackLog(event, "Swap", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSwap is a log parse operation binding the contract event 0x2170c741c41531aec20e7c107c24eecfdd15e69c9bb0a8dd37b1840b9e0b207b.
+//
+// Solidity: event Swap(bytes32 indexed poolId, address indexed tokenIn, address indexed tokenOut, uint256 amountIn, uint256 amountOut)
+func (_IBalancerVault *IBalancerVaultFilterer) ParseSwap(log types.Log) (*IBalancerVaultSwap, error) {
+	event := new(IBalancerVaultSwap)
+	if err := _IBalancerVault.contract.UnpackLog(event, "Swap", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerVaultTokensDeregisteredIterator is returned from FilterTokensDeregistered and is used to iterate over the raw logs and unpacked data for TokensDeregistered events raised by the IBalancerVault contract.
+type IBalancerVaultTokensDeregisteredIterator struct {
+	Event *IBalancerVaultTokensDeregistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultTokensDeregisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultTokensDeregistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultTokensDeregistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultTokensDeregisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultTokensDeregisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultTokensDeregistered represents a TokensDeregistered event raised by the IBalancerVault contract.
+type IBalancerVaultTokensDeregistered struct {
+	PoolId [32]byte
+	Tokens []common.Address
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensDeregistered is a free log retrieval operation binding the contract event 0x7dcdc6d02ef40c7c1a7046a011b058bd7f988fa14e20a66344f9d4e60657d610.
+//
+// Solidity: event TokensDeregistered(bytes32 indexed poolId, address[] tokens)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterTokensDeregistered(opts *bind.FilterOpts, poolId [][32]byte) (*IBalancerVaultTokensDeregisteredIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "TokensDeregistered", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultTokensDeregisteredIterator{contract: _IBalancerVault.contract, event: "TokensDeregistered", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensDeregistered is a free log subscription operation binding the contract event 0x7dcdc6d02ef40c7c1a7046a011b058bd7f988fa14e20a66344f9d4e60657d610.
+//
+// Solidity: event TokensDeregistered(bytes32 indexed poolId, address[] tokens)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchTokensDeregistered(opts *bind.WatchOpts, sink chan<- *IBalancerVaultTokensDeregistered, poolId [][32]byte) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "TokensDeregistered", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultTokensDeregistered)
+				if err := _IBalancerVault.contract.UnpackLog(event, "TokensDeregistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensDeregistered is a log parse operation binding the contract event 0x7dcdc6d02ef40c7c1a7046a011b058bd7f988fa14e20a66344f9d4e60657d610.
+//
+// Solidity: event TokensDeregistered(bytes32 indexed poolId, address[] tokens)
+func (_IBalancerVault *IBalancerVaultFilterer) ParseTokensDeregistered(log types.Log) (*IBalancerVaultTokensDeregistered, error) {
+	event := new(IBalancerVaultTokensDeregistered)
+	if err := _IBalancerVault.contract.UnpackLog(event, "TokensDeregistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IBalancerVaultTokensRegisteredIterator is returned from FilterTokensRegistered and is used to iterate over the raw logs and unpacked data for TokensRegistered events raised by the IBalancerVault contract.
+type IBalancerVaultTokensRegisteredIterator struct {
+	Event *IBalancerVaultTokensRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IBalancerVaultTokensRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IBalancerVaultTokensRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IBalancerVaultTokensRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IBalancerVaultTokensRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IBalancerVaultTokensRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IBalancerVaultTokensRegistered represents a TokensRegistered event raised by the IBalancerVault contract.
+type IBalancerVaultTokensRegistered struct {
+	PoolId        [32]byte
+	Tokens        []common.Address
+	AssetManagers []common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensRegistered is a free log retrieval operation binding the contract event 0xf5847d3f2197b16cdcd2098ec95d0905cd1abdaf415f07bb7cef2bba8ac5dec4.
+//
+// Solidity: event TokensRegistered(bytes32 indexed poolId, address[] tokens, address[] assetManagers)
+func (_IBalancerVault *IBalancerVaultFilterer) FilterTokensRegistered(opts *bind.FilterOpts, poolId [][32]byte) (*IBalancerVaultTokensRegisteredIterator, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.FilterLogs(opts, "TokensRegistered", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IBalancerVaultTokensRegisteredIterator{contract: _IBalancerVault.contract, event: "TokensRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensRegistered is a free log subscription operation binding the contract event 0xf5847d3f2197b16cdcd2098ec95d0905cd1abdaf415f07bb7cef2bba8ac5dec4.
+//
+// Solidity: event TokensRegistered(bytes32 indexed poolId, address[] tokens, address[] assetManagers)
+func (_IBalancerVault *IBalancerVaultFilterer) WatchTokensRegistered(opts *bind.WatchOpts, sink chan<- *IBalancerVaultTokensRegistered, poolId [][32]byte) (event.Subscription, error) {
+
+	var poolIdRule []interface{}
+	for _, poolIdItem := range poolId {
+		poolIdRule = append(poolIdRule, poolIdItem)
+	}
+
+	logs, sub, err := _IBalancerVault.contract.WatchLogs(opts, "TokensRegistered", poolIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IBalancerVaultTokensRegistered)
+				if err := _IBalancerVault.contract.UnpackLog(event, "TokensRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensRegistered is a log parse operation binding the contract event 0xf5847d3f2197b16cdcd2098ec95d0905cd1abdaf415f07bb7cef2bba8ac5dec4.
+//
+// Solidity: event TokensRegistered(bytes32 indexed poolId, address[] tokens, address[] assetManagers)
+func (_IBalancerVault *IBalancerVaultFilterer) ParseTokensRegistered(log types.Log) (*IBalancerVaultTokensRegistered, error) {
+	event := new(IBalancerVaultTokensRegistered)
+	if err := _IBalancerVault.contract.UnpackLog(event, "TokensRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
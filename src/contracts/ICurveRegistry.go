@@ -0,0 +1,687 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ICurveRegistryMetaData contains all meta data concerning the ICurveRegistry contract.
+var ICurveRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"pool_count\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"pool_list\",\"inputs\":[{\"name\":\"arg0\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"get_coins\",\"inputs\":[{\"name\":\"_pool\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address[8]\",\"internalType\":\"address[8]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"get_decimals\",\"inputs\":[{\"name\":\"_pool\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[8]\",\"internalType\":\"uint256[8]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"get_balances\",\"inputs\":[{\"name\":\"_pool\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[8]\",\"internalType\":\"uint256[8]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"get_A\",\"inputs\":[{\"name\":\"_pool\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"get_fees\",\"inputs\":[{\"name\":\"_pool\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"PoolAdded\",\"inputs\":[{\"name\":\"pool\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"rate_method_id\",\"type\":\"bytes\",\"indexed\":false,\"internalType\":\"bytes\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"PoolRemoved\",\"inputs\":[{\"name\":\"pool\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false}]",
+}
+
+// ICurveRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use ICurveRegistryMetaData.ABI instead.
+var ICurveRegistryABI = ICurveRegistryMetaData.ABI
+
+// ICurveRegistry is an auto generated Go binding around an Ethereum contract.
+type ICurveRegistry struct {
+	ICurveRegistryCaller     // Read-only binding to the contract
+	ICurveRegistryTransactor // Write-only binding to the contract
+	ICurveRegistryFilterer   // Log filterer for contract events
+}
+
+// ICurveRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ICurveRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ICurveRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ICurveRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ICurveRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ICurveRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ICurveRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ICurveRegistrySession struct {
+	Contract     *ICurveRegistry   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ICurveRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ICurveRegistryCallerSession struct {
+	Contract *ICurveRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// ICurveRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ICurveRegistryTransactorSession struct {
+	Contract     *ICurveRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// ICurveRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ICurveRegistryRaw struct {
+	Contract *ICurveRegistry // Generic contract binding to access the raw methods on
+}
+
+// ICurveRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ICurveRegistryCallerRaw struct {
+	Contract *ICurveRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ICurveRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ICurveRegistryTransactorRaw struct {
+	Contract *ICurveRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewICurveRegistry creates a new instance of ICurveRegistry, bound to a specific deployed contract.
+func NewICurveRegistry(address common.Address, backend bind.ContractBackend) (*ICurveRegistry, error) {
+	contract, err := bindICurveRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistry{ICurveRegistryCaller: ICurveRegistryCaller{contract: contract}, ICurveRegistryTransactor: ICurveRegistryTransactor{contract: contract}, ICurveRegistryFilterer: ICurveRegistryFilterer{contract: contract}}, nil
+}
+
+// NewICurveRegistryCaller creates a new read-only instance of ICurveRegistry, bound to a specific deployed contract.
+func NewICurveRegistryCaller(address common.Address, caller bind.ContractCaller) (*ICurveRegistryCaller, error) {
+	contract, err := bindICurveRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistryCaller{contract: contract}, nil
+}
+
+// NewICurveRegistryTransactor creates a new write-only instance of ICurveRegistry, bound to a specific deployed contract.
+func NewICurveRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*ICurveRegistryTransactor, error) {
+	contract, err := bindICurveRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistryTransactor{contract: contract}, nil
+}
+
+// NewICurveRegistryFilterer creates a new log filterer instance of ICurveRegistry, bound to a specific deployed contract.
+func NewICurveRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*ICurveRegistryFilterer, error) {
+	contract, err := bindICurveRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistryFilterer{contract: contract}, nil
+}
+
+// bindICurveRegistry binds a generic wrapper to an already deployed contract.
+func bindICurveRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ICurveRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ICurveRegistry *ICurveRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ICurveRegistry.Contract.ICurveRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ICurveRegistry *ICurveRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ICurveRegistry.Contract.ICurveRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ICurveRegistry *ICurveRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ICurveRegistry.Contract.ICurveRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ICurveRegistry *ICurveRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ICurveRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ICurveRegistry *ICurveRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ICurveRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ICurveRegistry *ICurveRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ICurveRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetA is a free data retrieval call binding the contract method 0x55b30b19.
+//
+// Solidity: function get_A(address _pool) view returns(uint256)
+func (_ICurveRegistry *ICurveRegistryCaller) GetA(opts *bind.CallOpts, _pool common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "get_A", _pool)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetA is a free data retrieval call binding the contract method 0x55b30b19.
+//
+// Solidity: function get_A(address _pool) view returns(uint256)
+func (_ICurveRegistry *ICurveRegistrySession) GetA(_pool common.Address) (*big.Int, error) {
+	return _ICurveRegistry.Contract.GetA(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetA is a free data retrieval call binding the contract method 0x55b30b19.
+//
+// Solidity: function get_A(address _pool) view returns(uint256)
+func (_ICurveRegistry *ICurveRegistryCallerSession) GetA(_pool common.Address) (*big.Int, error) {
+	return _ICurveRegistry.Contract.GetA(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetBalances is a free data retrieval call binding the contract method 0x92e3cc2d.
+//
+// Solidity: function get_balances(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistryCaller) GetBalances(opts *bind.CallOpts, _pool common.Address) ([8]*big.Int, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "get_balances", _pool)
+
+	if err != nil {
+		return *new([8]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([8]*big.Int)).(*[8]*big.Int)
+
+	return out0, err
+
+}
+
+// GetBalances is a free data retrieval call binding the contract method 0x92e3cc2d.
+//
+// Solidity: function get_balances(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistrySession) GetBalances(_pool common.Address) ([8]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetBalances(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetBalances is a free data retrieval call binding the contract method 0x92e3cc2d.
+//
+// Solidity: function get_balances(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistryCallerSession) GetBalances(_pool common.Address) ([8]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetBalances(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetCoins is a free data retrieval call binding the contract method 0x9ac90d3d.
+//
+// Solidity: function get_coins(address _pool) view returns(address[8])
+func (_ICurveRegistry *ICurveRegistryCaller) GetCoins(opts *bind.CallOpts, _pool common.Address) ([8]common.Address, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "get_coins", _pool)
+
+	if err != nil {
+		return *new([8]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([8]common.Address)).(*[8]common.Address)
+
+	return out0, err
+
+}
+
+// GetCoins is a free data retrieval call binding the contract method 0x9ac90d3d.
+//
+// Solidity: function get_coins(address _pool) view returns(address[8])
+func (_ICurveRegistry *ICurveRegistrySession) GetCoins(_pool common.Address) ([8]common.Address, error) {
+	return _ICurveRegistry.Contract.GetCoins(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetCoins is a free data retrieval call binding the contract method 0x9ac90d3d.
+//
+// Solidity: function get_coins(address _pool) view returns(address[8])
+func (_ICurveRegistry *ICurveRegistryCallerSession) GetCoins(_pool common.Address) ([8]common.Address, error) {
+	return _ICurveRegistry.Contract.GetCoins(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetDecimals is a free data retrieval call binding the contract method 0x52b51555.
+//
+// Solidity: function get_decimals(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistryCaller) GetDecimals(opts *bind.CallOpts, _pool common.Address) ([8]*big.Int, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "get_decimals", _pool)
+
+	if err != nil {
+		return *new([8]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([8]*big.Int)).(*[8]*big.Int)
+
+	return out0, err
+
+}
+
+// GetDecimals is a free data retrieval call binding the contract method 0x52b51555.
+//
+// Solidity: function get_decimals(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistrySession) GetDecimals(_pool common.Address) ([8]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetDecimals(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetDecimals is a free data retrieval call binding the contract method 0x52b51555.
+//
+// Solidity: function get_decimals(address _pool) view returns(uint256[8])
+func (_ICurveRegistry *ICurveRegistryCallerSession) GetDecimals(_pool common.Address) ([8]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetDecimals(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetFees is a free data retrieval call binding the contract method 0x7cdb72b0.
+//
+// Solidity: function get_fees(address _pool) view returns(uint256[2])
+func (_ICurveRegistry *ICurveRegistryCaller) GetFees(opts *bind.CallOpts, _pool common.Address) ([2]*big.Int, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "get_fees", _pool)
+
+	if err != nil {
+		return *new([2]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([2]*big.Int)).(*[2]*big.Int)
+
+	return out0, err
+
+}
+
+// GetFees is a free data retrieval call binding the contract method 0x7cdb72b0.
+//
+// Solidity: function get_fees(address _pool) view returns(uint256[2])
+func (_ICurveRegistry *ICurveRegistrySession) GetFees(_pool common.Address) ([2]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetFees(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// GetFees is a free data retrieval call binding the contract method 0x7cdb72b0.
+//
+// Solidity: function get_fees(address _pool) view returns(uint256[2])
+func (_ICurveRegistry *ICurveRegistryCallerSession) GetFees(_pool common.Address) ([2]*big.Int, error) {
+	return _ICurveRegistry.Contract.GetFees(&_ICurveRegistry.CallOpts, _pool)
+}
+
+// PoolCount is a free data retrieval call binding the contract method 0x956aae3a.
+//
+// Solidity: function pool_count() view returns(uint256)
+func (_ICurveRegistry *ICurveRegistryCaller) PoolCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "pool_count")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PoolCount is a free data retrieval call binding the contract method 0x956aae3a.
+//
+// Solidity: function pool_count() view returns(uint256)
+func (_ICurveRegistry *ICurveRegistrySession) PoolCount() (*big.Int, error) {
+	return _ICurveRegistry.Contract.PoolCount(&_ICurveRegistry.CallOpts)
+}
+
+// PoolCount is a free data retrieval call binding the contract method 0x956aae3a.
+//
+// Solidity: function pool_count() view returns(uint256)
+func (_ICurveRegistry *ICurveRegistryCallerSession) PoolCount() (*big.Int, error) {
+	return _ICurveRegistry.Contract.PoolCount(&_ICurveRegistry.CallOpts)
+}
+
+// PoolList is a free data retrieval call binding the contract method 0x3a1d5d8e.
+//
+// Solidity: function pool_list(uint256 arg0) view returns(address)
+func (_ICurveRegistry *ICurveRegistryCaller) PoolList(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _ICurveRegistry.contract.Call(opts, &out, "pool_list", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PoolList is a free data retrieval call binding the contract method 0x3a1d5d8e.
+//
+// Solidity: function pool_list(uint256 arg0) view returns(address)
+func (_ICurveRegistry *ICurveRegistrySession) PoolList(arg0 *big.Int) (common.Address, error) {
+	return _ICurveRegistry.Contract.PoolList(&_ICurveRegistry.CallOpts, arg0)
+}
+
+// PoolList is a free data retrieval call binding the contract method 0x3a1d5d8e.
+//
+// Solidity: function pool_list(uint256 arg0) view returns(address)
+func (_ICurveRegistry *ICurveRegistryCallerSession) PoolList(arg0 *big.Int) (common.Address, error) {
+	return _ICurveRegistry.Contract.PoolList(&_ICurveRegistry.CallOpts, arg0)
+}
+
+// ICurveRegistryPoolAddedIterator is returned from FilterPoolAdded and is used to iterate over the raw logs and unpacked data for PoolAdded events raised by the ICurveRegistry contract.
+type ICurveRegistryPoolAddedIterator struct {
+	Event *ICurveRegistryPoolAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ICurveRegistryPoolAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ICurveRegistryPoolAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ICurveRegistryPoolAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ICurveRegistryPoolAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ICurveRegistryPoolAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ICurveRegistryPoolAdded represents a PoolAdded event raised by the ICurveRegistry contract.
+type ICurveRegistryPoolAdded struct {
+	Pool         common.Address
+	RateMethodId []byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterPoolAdded is a free log retrieval operation binding the contract event 0xe485c16479ab7092c0b3fc4649843c06be7f072194675261590c84473ab0aea9.
+//
+// Solidity: event PoolAdded(address indexed pool, bytes rate_method_id)
+func (_ICurveRegistry *ICurveRegistryFilterer) FilterPoolAdded(opts *bind.FilterOpts, pool []common.Address) (*ICurveRegistryPoolAddedIterator, error) {
+
+	var poolRule []interface{}
+	for _, poolItem := range pool {
+		poolRule = append(poolRule, poolItem)
+	}
+
+	logs, sub, err := _ICurveRegistry.contract.FilterLogs(opts, "PoolAdded", poolRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistryPoolAddedIterator{contract: _ICurveRegistry.contract, event: "PoolAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchPoolAdded is a free log subscription operation binding the contract event 0xe485c16479ab7092c0b3fc4649843c06be7f072194675261590c84473ab0aea9.
+//
+// Solidity: event PoolAdded(address indexed pool, bytes rate_method_id)
+func (_ICurveRegistry *ICurveRegistryFilterer) WatchPoolAdded(opts *bind.WatchOpts, sink chan<- *ICurveRegistryPoolAdded, pool []common.Address) (event.Subscription, error) {
+
+	var poolRule []interface{}
+	for _, poolItem := range pool {
+		poolRule = append(poolRule, poolItem)
+	}
+
+	logs, sub, err := _ICurveRegistry.contract.WatchLogs(opts, "PoolAdded", poolRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ICurveRegistryPoolAdded)
+				if err := _ICurveRegistry.contract.UnpackLog(event, "PoolAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePoolAdded is a log parse operation binding the contract event 0xe485c16479ab7092c0b3fc4649843c06be7f072194675261590c84473ab0aea9.
+//
+// Solidity: event PoolAdded(address indexed pool, bytes rate_method_id)
+func (_ICurveRegistry *ICurveRegistryFilterer) ParsePoolAdded(log types.Log) (*ICurveRegistryPoolAdded, error) {
+	event := new(ICurveRegistryPoolAdded)
+	if err := _ICurveRegistry.contract.UnpackLog(event, "PoolAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ICurveRegistryPoolRemovedIterator is returned from FilterPoolRemoved and is used to iterate over the raw logs and unpacked data for PoolRemoved events raised by the ICurveRegistry contract.
+type ICurveRegistryPoolRemovedIterator struct {
+	Event *ICurveRegistryPoolRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ICurveRegistryPoolRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ICurveRegistryPoolRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ICurveRegistryPoolRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ICurveRegistryPoolRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ICurveRegistryPoolRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ICurveRegistryPoolRemoved represents a PoolRemoved event raised by the ICurveRegistry contract.
+type ICurveRegistryPoolRemoved struct {
+	Pool common.Address
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterPoolRemoved is a free log retrieval operation binding the contract event 0x4106dfdaa577573db51c0ca93f766dbedfa0758faa2e7f5bcdb7c142be803c3f.
+//
+// Solidity: event PoolRemoved(address indexed pool)
+func (_ICurveRegistry *ICurveRegistryFilterer) FilterPoolRemoved(opts *bind.FilterOpts, pool []common.Address) (*ICurveRegistryPoolRemovedIterator, error) {
+
+	var poolRule []interface{}
+	for _, poolItem := range pool {
+		poolRule = append(poolRule, poolItem)
+	}
+
+	logs, sub, err := _ICurveRegistry.contract.FilterLogs(opts, "PoolRemoved", poolRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ICurveRegistryPoolRemovedIterator{contract: _ICurveRegistry.contract, event: "PoolRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchPoolRemoved is a free log subscription operation binding the contract event 0x4106dfdaa577573db51c0ca93f766dbedfa0758faa2e7f5bcdb7c142be803c3f.
+//
+// Solidity: event PoolRemoved(address indexed pool)
+func (_ICurveRegistry *ICurveRegistryFilterer) WatchPoolRemoved(opts *bind.WatchOpts, sink chan<- *ICurveRegistryPoolRemoved, pool []common.Address) (event.Subscription, error) {
+
+	var poolRule []interface{}
+	for _, poolItem := range pool {
+		poolRule = append(poolRule, poolItem)
+	}
+
+	logs, sub, err := _ICurveRegistry.contract.WatchLogs(opts, "PoolRemoved", poolRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ICurveRegistryPoolRemoved)
+				if err := _ICurveRegistry.contract.UnpackLog(event, "PoolRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePoolRemoved is a log parse operation binding the contract event 0x4106dfdaa577573db51c0ca93f766dbedfa0758faa2e7f5bcdb7c142be803c3f.
+//
+// Solidity: event PoolRemoved(address indexed pool)
+func (_ICurveRegistry *ICurveRegistryFilterer) ParsePoolRemoved(log types.Log) (*ICurveRegistryPoolRemoved, error) {
+	event := new(ICurveRegistryPoolRemoved)
+	if err := _ICurveRegistry.contract.UnpackLog(event, "PoolRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,215 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IMulticall3Call3 is an auto generated low-level Go binding around an user-defined struct.
+type IMulticall3Call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// IMulticall3Result is an auto generated low-level Go binding around an user-defined struct.
+type IMulticall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// IMulticall3MetaData contains all meta data concerning the IMulticall3 contract.
+var IMulticall3MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"aggregate3\",\"inputs\":[{\"name\":\"calls\",\"type\":\"tuple[]\",\"internalType\":\"structIMulticall3.Call3[]\",\"components\":[{\"name\":\"target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"allowFailure\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"callData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}]}],\"outputs\":[{\"name\":\"returnData\",\"type\":\"tuple[]\",\"internalType\":\"structIMulticall3.Result[]\",\"components\":[{\"name\":\"success\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"returnData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}]}],\"stateMutability\":\"payable\"}]",
+}
+
+// IMulticall3ABI is the input ABI used to generate the binding from.
+// Deprecated: Use IMulticall3MetaData.ABI instead.
+var IMulticall3ABI = IMulticall3MetaData.ABI
+
+// IMulticall3 is an auto generated Go binding around an Ethereum contract.
+type IMulticall3 struct {
+	IMulticall3Caller     // Read-only binding to the contract
+	IMulticall3Transactor // Write-only binding to the contract
+	IMulticall3Filterer   // Log filterer for contract events
+}
+
+// IMulticall3Caller is an auto generated read-only Go binding around an Ethereum contract.
+type IMulticall3Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IMulticall3Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type IMulticall3Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IMulticall3Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IMulticall3Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IMulticall3Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IMulticall3Session struct {
+	Contract     *IMulticall3      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IMulticall3CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IMulticall3CallerSession struct {
+	Contract *IMulticall3Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// IMulticall3TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IMulticall3TransactorSession struct {
+	Contract     *IMulticall3Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// IMulticall3Raw is an auto generated low-level Go binding around an Ethereum contract.
+type IMulticall3Raw struct {
+	Contract *IMulticall3 // Generic contract binding to access the raw methods on
+}
+
+// IMulticall3CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IMulticall3CallerRaw struct {
+	Contract *IMulticall3Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// IMulticall3TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IMulticall3TransactorRaw struct {
+	Contract *IMulticall3Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIMulticall3 creates a new instance of IMulticall3, bound to a specific deployed contract.
+func NewIMulticall3(address common.Address, backend bind.ContractBackend) (*IMulticall3, error) {
+	contract, err := bindIMulticall3(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IMulticall3{IMulticall3Caller: IMulticall3Caller{contract: contract}, IMulticall3Transactor: IMulticall3Transactor{contract: contract}, IMulticall3Filterer: IMulticall3Filterer{contract: contract}}, nil
+}
+
+// NewIMulticall3Caller creates a new read-only instance of IMulticall3, bound to a specific deployed contract.
+func NewIMulticall3Caller(address common.Address, caller bind.ContractCaller) (*IMulticall3Caller, error) {
+	contract, err := bindIMulticall3(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IMulticall3Caller{contract: contract}, nil
+}
+
+// NewIMulticall3Transactor creates a new write-only instance of IMulticall3, bound to a specific deployed contract.
+func NewIMulticall3Transactor(address common.Address, transactor bind.ContractTransactor) (*IMulticall3Transactor, error) {
+	contract, err := bindIMulticall3(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IMulticall3Transactor{contract: contract}, nil
+}
+
+// NewIMulticall3Filterer creates a new log filterer instance of IMulticall3, bound to a specific deployed contract.
+func NewIMulticall3Filterer(address common.Address, filterer bind.ContractFilterer) (*IMulticall3Filterer, error) {
+	contract, err := bindIMulticall3(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IMulticall3Filterer{contract: contract}, nil
+}
+
+// bindIMulticall3 binds a generic wrapper to an already deployed contract.
+func bindIMulticall3(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IMulticall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IMulticall3 *IMulticall3Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IMulticall3.Contract.IMulticall3Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IMulticall3 *IMulticall3Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IMulticall3.Contract.IMulticall3Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IMulticall3 *IMulticall3Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IMulticall3.Contract.IMulticall3Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IMulticall3 *IMulticall3CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IMulticall3.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IMulticall3 *IMulticall3TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IMulticall3.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IMulticall3 *IMulticall3TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IMulticall3.Contract.contract.Transact(opts, method, params...)
+}
+
+// Aggregate3 is a paid mutator transaction binding the contract method 0x82ad56cb.
+//
+// Solidity: function aggregate3((address,bool,bytes)[] calls) payable returns((bool,bytes)[] returnData)
+func (_IMulticall3 *IMulticall3Transactor) Aggregate3(opts *bind.TransactOpts, calls []IMulticall3Call3) (*types.Transaction, error) {
+	return _IMulticall3.contract.Transact(opts, "aggregate3", calls)
+}
+
+// Aggregate3 is a paid mutator transaction binding the contract method 0x82ad56cb.
+//
+// Solidity: function aggregate3((address,bool,bytes)[] calls) payable returns((bool,bytes)[] returnData)
+func (_IMulticall3 *IMulticall3Session) Aggregate3(calls []IMulticall3Call3) (*types.Transaction, error) {
+	return _IMulticall3.Contract.Aggregate3(&_IMulticall3.TransactOpts, calls)
+}
+
+// Aggregate3 is a paid mutator transaction binding the contract method 0x82ad56cb.
+//
+// Solidity: function aggregate3((address,bool,bytes)[] calls) payable returns((bool,bytes)[] returnData)
+func (_IMulticall3 *IMulticall3TransactorSession) Aggregate3(calls []IMulticall3Call3) (*types.Transaction, error) {
+	return _IMulticall3.Contract.Aggregate3(&_IMulticall3.TransactOpts, calls)
+}
@@ -0,0 +1,223 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IUniversalRouterMetaData contains all meta data concerning the IUniversalRouter contract.
+var IUniversalRouterMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"execute\",\"inputs\":[{\"name\":\"commands\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"inputs\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"execute\",\"inputs\":[{\"name\":\"commands\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"inputs\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"},{\"name\":\"deadline\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"}]",
+}
+
+// IUniversalRouterABI is the input ABI used to generate the binding from.
+// Deprecated: Use IUniversalRouterMetaData.ABI instead.
+var IUniversalRouterABI = IUniversalRouterMetaData.ABI
+
+// IUniversalRouter is an auto generated Go binding around an Ethereum contract.
+type IUniversalRouter struct {
+	IUniversalRouterCaller     // Read-only binding to the contract
+	IUniversalRouterTransactor // Write-only binding to the contract
+	IUniversalRouterFilterer   // Log filterer for contract events
+}
+
+// IUniversalRouterCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IUniversalRouterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniversalRouterTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IUniversalRouterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniversalRouterFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IUniversalRouterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniversalRouterSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IUniversalRouterSession struct {
+	Contract     *IUniversalRouter // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IUniversalRouterCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IUniversalRouterCallerSession struct {
+	Contract *IUniversalRouterCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// IUniversalRouterTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IUniversalRouterTransactorSession struct {
+	Contract     *IUniversalRouterTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// IUniversalRouterRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IUniversalRouterRaw struct {
+	Contract *IUniversalRouter // Generic contract binding to access the raw methods on
+}
+
+// IUniversalRouterCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IUniversalRouterCallerRaw struct {
+	Contract *IUniversalRouterCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IUniversalRouterTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IUniversalRouterTransactorRaw struct {
+	Contract *IUniversalRouterTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIUniversalRouter creates a new instance of IUniversalRouter, bound to a specific deployed contract.
+func NewIUniversalRouter(address common.Address, backend bind.ContractBackend) (*IUniversalRouter, error) {
+	contract, err := bindIUniversalRouter(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniversalRouter{IUniversalRouterCaller: IUniversalRouterCaller{contract: contract}, IUniversalRouterTransactor: IUniversalRouterTransactor{contract: contract}, IUniversalRouterFilterer: IUniversalRouterFilterer{contract: contract}}, nil
+}
+
+// NewIUniversalRouterCaller creates a new read-only instance of IUniversalRouter, bound to a specific deployed contract.
+func NewIUniversalRouterCaller(address common.Address, caller bind.ContractCaller) (*IUniversalRouterCaller, error) {
+	contract, err := bindIUniversalRouter(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniversalRouterCaller{contract: contract}, nil
+}
+
+// NewIUniversalRouterTransactor creates a new write-only instance of IUniversalRouter, bound to a specific deployed contract.
+func NewIUniversalRouterTransactor(address common.Address, transactor bind.ContractTransactor) (*IUniversalRouterTransactor, error) {
+	contract, err := bindIUniversalRouter(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniversalRouterTransactor{contract: contract}, nil
+}
+
+// NewIUniversalRouterFilterer creates a new log filterer instance of IUniversalRouter, bound to a specific deployed contract.
+func NewIUniversalRouterFilterer(address common.Address, filterer bind.ContractFilterer) (*IUniversalRouterFilterer, error) {
+	contract, err := bindIUniversalRouter(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniversalRouterFilterer{contract: contract}, nil
+}
+
+// bindIUniversalRouter binds a generic wrapper to an already deployed contract.
+func bindIUniversalRouter(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IUniversalRouterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IUniversalRouter *IUniversalRouterRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IUniversalRouter.Contract.IUniversalRouterCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IUniversalRouter *IUniversalRouterRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.IUniversalRouterTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IUniversalRouter *IUniversalRouterRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.IUniversalRouterTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IUniversalRouter *IUniversalRouterCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IUniversalRouter.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IUniversalRouter *IUniversalRouterTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IUniversalRouter *IUniversalRouterTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.contract.Transact(opts, method, params...)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_IUniversalRouter *IUniversalRouterTransactor) Execute(opts *bind.TransactOpts, commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _IUniversalRouter.contract.Transact(opts, "execute", commands, inputs)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_IUniversalRouter *IUniversalRouterSession) Execute(commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.Execute(&_IUniversalRouter.TransactOpts, commands, inputs)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_IUniversalRouter *IUniversalRouterTransactorSession) Execute(commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.Execute(&_IUniversalRouter.TransactOpts, commands, inputs)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_IUniversalRouter *IUniversalRouterTransactor) Execute0(opts *bind.TransactOpts, commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _IUniversalRouter.contract.Transact(opts, "execute0", commands, inputs, deadline)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_IUniversalRouter *IUniversalRouterSession) Execute0(commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.Execute0(&_IUniversalRouter.TransactOpts, commands, inputs, deadline)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_IUniversalRouter *IUniversalRouterTransactorSession) Execute0(commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _IUniversalRouter.Contract.Execute0(&_IUniversalRouter.TransactOpts, commands, inputs, deadline)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"mev_bot/clients"
+	"mev_bot/config"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
@@ -17,23 +19,43 @@ func main() {
 	defer stop()
 	envFile := os.Getenv("ENV_FILE")
 	err := godotenv.Load(envFile)
-	if err != nil {
+	if err != nil && envFile != "" {
 		log.Fatal().Msg("Error loading .env file")
 	}
-	rpcURL := os.Getenv("RPC_URL")
-	botToken := os.Getenv("BOT_TOKEN")
-	chatId := os.Getenv("CHAT_ID")
-	mevContractAddress := os.Getenv("MEV_ADDRESS")
-	privKey := os.Getenv("PRIV_KEY")
-	wsURL := os.Getenv("WS_URL")
-	historyUrl := os.Getenv("HISTORY_RPC_URL")
-	client, err := clients.NewUniswapClient(rpcURL, wsURL, historyUrl, botToken, chatId, mevContractAddress, privKey, ctx)
+	configFile := os.Getenv("CONFIG_FILE")
+	if configFile == "" {
+		configFile = "config.yaml"
+	}
+	cfg, err := config.Load(configFile)
 	if err != nil {
-		log.Fatal().Err(err).Msg("can not get client")
+		log.Fatal().Err(err).Msg("invalid config")
 	}
-	err = client.Run()
+	store, err := clients.OpenStateStore(cfg)
 	if err != nil {
-		_ = client.SaveState()
-		log.Info().Err(err).Msg("test")
+		log.Fatal().Err(err).Msg("can not open database")
+	}
+	if store != nil {
+		defer store.Close()
+	}
+	var wg sync.WaitGroup
+	for _, chain := range cfg.Chains {
+		client, err := clients.NewUniswapClient(cfg, chain, store, ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("can not get client")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := client.Run()
+			if err != nil {
+				log.Info().Err(err).Msg("test")
+			}
+			// Run also returns on SIGINT and SIGTERM, which cancel ctx.
+			err = client.SaveState()
+			if err != nil {
+				log.Error().Err(err).Msg("can not save state")
+			}
+		}()
 	}
+	wg.Wait()
 }